@@ -14,6 +14,13 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	Timeout  time.Duration
+	// MaxConns and MinConns limit the number of connections of the connection pool
+	MaxConns int32 `mapstructure:"max_conns"`
+	MinConns int32 `mapstructure:"min_conns"`
+	// MaxConnIdleTime is the duration after which an idle connection of the pool is closed
+	MaxConnIdleTime time.Duration `mapstructure:"max_conn_idle_time"`
+	// MaxConnLifetime is the duration after which a connection of the pool is closed and replaced
+	MaxConnLifetime time.Duration `mapstructure:"max_conn_lifetime"`
 }
 
 type MQTTConfig struct {
@@ -57,6 +64,80 @@ type KeyCloakFrontendConfig struct {
 	TokenURL     string `mapstructure:"token_url"`
 }
 
+// MoistureThresholdConfig holds the lower moisture bounds (in percent) for a watering status.
+// A moisture level below Moderate results in a bad watering status.
+type MoistureThresholdConfig struct {
+	Good     float64
+	Moderate float64
+}
+
+// WateringConfig configures how sensor readings are mapped to a tree cluster watering status.
+// Thresholds are keyed by soil condition; Default is used for unknown soil conditions.
+// WaterPerTree is the amount of water in liters a tree gets on a watering tour.
+type WateringConfig struct {
+	Default      MoistureThresholdConfig
+	Thresholds   map[string]MoistureThresholdConfig
+	WaterPerTree float64 `mapstructure:"water_per_tree"`
+}
+
+// RoutingConfig configures the offline calculation of watering tours.
+// Distances are straight lines multiplied by RoadFactor to estimate the distance on roads.
+// Tours start and end at the Depot, the tank is refilled at the nearest of the WaterPoints.
+// Without water points the tank is refilled at the depot.
+type RoutingConfig struct {
+	Depot          WaterPointConfig
+	WaterPoints    []WaterPointConfig `mapstructure:"water_points"`
+	RoadFactor     float64            `mapstructure:"road_factor"`
+	AverageSpeed   float64            `mapstructure:"average_speed"` // km/h
+	TreeDuration   time.Duration      `mapstructure:"tree_duration"`
+	RefillDuration time.Duration      `mapstructure:"refill_duration"`
+}
+
+type WaterPointConfig struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// TreeClusterConfig configures tree clusters.
+type TreeClusterConfig struct {
+	Hull HullConfig
+}
+
+// HullConfig configures the area covered by a tree cluster, which is calculated from its trees.
+// Concavity ranges from 0 to 1, 1 results in the convex hull and smaller values follow the trees
+// more closely, e.g. along a street. Buffer widens the hull by the given meters around the trees.
+type HullConfig struct {
+	Concavity float64
+	Buffer    float64
+}
+
+// ObjectStorageConfig configures where uploaded files like images are stored.
+// Type selects the backend and is either "local" or "s3".
+type ObjectStorageConfig struct {
+	Type          string
+	MaxUploadSize int64 `mapstructure:"max_upload_size"`
+	Local         LocalObjectStorageConfig
+	S3            S3ObjectStorageConfig
+}
+
+type LocalObjectStorageConfig struct {
+	Path    string
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// S3ObjectStorageConfig holds the connection to an S3 compatible object storage like MinIO.
+// PublicURL is used to build the url of stored objects and defaults to the endpoint.
+type S3ObjectStorageConfig struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	UseSSL    bool   `mapstructure:"use_ssl"`
+	PublicURL string `mapstructure:"public_url"`
+}
+
 type IdentityAuthConfig struct {
 	KeyCloak KeyCloakConfig
 }
@@ -66,6 +147,10 @@ type Config struct {
 	Dashboard    DashboardConfig
 	MQTT         MQTTConfig
 	IdentityAuth IdentityAuthConfig `mapstructure:"auth"`
+	Watering     WateringConfig
+	Routing      RoutingConfig
+	TreeCluster  TreeClusterConfig   `mapstructure:"tree_cluster"`
+	Storage      ObjectStorageConfig `mapstructure:"object_storage"`
 }
 
 func InitConfig() (*Config, error) {
@@ -14,6 +14,7 @@ func InitViper() (*Config, error) {
 	viper.SetEnvPrefix("GE")
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	setDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
@@ -27,3 +28,26 @@ func InitViper() (*Config, error) {
 
 	return &cfg, nil
 }
+
+func setDefaults() {
+	viper.SetDefault("routing.depot.name", "TBZ Flensburg")
+	viper.SetDefault("routing.depot.latitude", 54.768)
+	viper.SetDefault("routing.depot.longitude", 9.435)
+	viper.SetDefault("routing.road_factor", 1.3)
+	viper.SetDefault("routing.average_speed", 25)
+	viper.SetDefault("routing.tree_duration", "2m")
+	viper.SetDefault("routing.refill_duration", "15m")
+
+	viper.SetDefault("tree_cluster.hull.concavity", 1)
+	viper.SetDefault("tree_cluster.hull.buffer", 5)
+
+	viper.SetDefault("server.database.max_conns", 10)
+	viper.SetDefault("server.database.min_conns", 2)
+	viper.SetDefault("server.database.max_conn_idle_time", "30m")
+	viper.SetDefault("server.database.max_conn_lifetime", "1h")
+
+	viper.SetDefault("object_storage.type", "local")
+	viper.SetDefault("object_storage.max_upload_size", 10<<20)
+	viper.SetDefault("object_storage.local.path", "./data/images")
+	viper.SetDefault("object_storage.s3.region", "us-east-1")
+}
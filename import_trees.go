@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tree"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
+)
+
+const importTreesUsage = "usage: import-trees [-format csv|geojson] [-dry-run] <file>"
+
+// runImportTrees imports a tree inventory file into the database, e.g. the tree cadastre of a city
+// that is too large to upload through the API:
+//
+//	green-ecolution-backend import-trees -dry-run baumkataster.csv
+func runImportTrees(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("import-trees", flag.ContinueOnError)
+	format := flags.String("format", "", "file format (csv or geojson), detected from the file extension if not set")
+	dryRun := flags.Bool("dry-run", false, "only check the file without importing the trees")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(importTreesUsage)
+	}
+
+	filename := flags.Arg(0)
+	importFormat := entities.TreeImportFormat(*format)
+	if importFormat == "" {
+		importFormat = entities.TreeImportFormatFromFilename(filename)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	pool, err := postgres.NewPool(ctx, &cfg.Server.Database)
+	if err != nil {
+		return fmt.Errorf("error while connecting to PostgreSQL: %w", err)
+	}
+	defer pool.Close()
+
+	repo := postgres.NewRepository(pool, &cfg.TreeCluster)
+	svc := tree.NewTreeService(repo.Tree, repo.Sensor, repo.TreeCluster, repo.Region, repo.Image, repo.UnitOfWork)
+
+	result, err := svc.Import(ctx, &entities.TreeImport{
+		Format: importFormat,
+		File:   file,
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	printImportResult(os.Stdout, result)
+	return nil
+}
+
+func printImportResult(w io.Writer, result *entities.TreeImportResult) {
+	for _, e := range result.Errors {
+		if e.TreeNumber != nil {
+			fmt.Fprintf(w, "row %d (tree %d): %s\n", e.Row, *e.TreeNumber, e.Message)
+		} else {
+			fmt.Fprintf(w, "row %d: %s\n", e.Row, e.Message)
+		}
+	}
+
+	if result.DryRun {
+		fmt.Fprint(w, "dry run, nothing imported: ")
+	}
+	fmt.Fprintf(w, "%d rows, %d created, %d updated, %d skipped\n", result.Total, result.Created, result.Updated, result.Skipped)
+}
@@ -19,4 +19,27 @@ type Flowerbed struct {
 	Archived       bool
 	Latitude       float64
 	Longitude      float64
+	Geometry       Polygon
+}
+
+type FlowerbedCreate struct {
+	Size           float64 `validate:"gte=0"`
+	Description    string
+	NumberOfPlants int32 `validate:"gte=0"`
+	MoistureLevel  float64
+	Address        string
+	Geometry       Polygon `validate:"required"`
+	SensorID       *int32
+	ImageIDs       []*int32
+}
+
+type FlowerbedUpdate struct {
+	Size           float64 `validate:"gte=0"`
+	Description    string
+	NumberOfPlants int32 `validate:"gte=0"`
+	MoistureLevel  float64
+	Address        string
+	Geometry       Polygon `validate:"required"`
+	SensorID       *int32
+	ImageIDs       []*int32
 }
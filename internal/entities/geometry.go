@@ -0,0 +1,236 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Coordinate is a position in WGS 84 (EPSG:4326). PostGIS and GeoJSON expect the longitude
+// as x coordinate, geometries are built with WKT instead of ordering the values by hand.
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// NewCoordinate returns the coordinate if the latitude and longitude are in range
+func NewCoordinate(latitude, longitude float64) (Coordinate, error) {
+	c := Coordinate{Latitude: latitude, Longitude: longitude}
+	if err := c.Validate(); err != nil {
+		return Coordinate{}, err
+	}
+
+	return c, nil
+}
+
+func (c Coordinate) Validate() error {
+	if math.IsNaN(c.Latitude) || c.Latitude < -90 || c.Latitude > 90 {
+		return fmt.Errorf("latitude %v out of range", c.Latitude)
+	}
+	if math.IsNaN(c.Longitude) || c.Longitude < -180 || c.Longitude > 180 {
+		return fmt.Errorf("longitude %v out of range", c.Longitude)
+	}
+
+	return nil
+}
+
+// WKT returns the coordinate as well-known text point with longitude as x coordinate
+func (c Coordinate) WKT() string {
+	return "POINT(" + strconv.FormatFloat(c.Longitude, 'f', -1, 64) + " " + strconv.FormatFloat(c.Latitude, 'f', -1, 64) + ")"
+}
+
+// Polygon is a list of linear rings, the first ring is the exterior ring.
+// Every position is a [longitude, latitude] pair in WGS 84 (EPSG:4326).
+type Polygon [][][]float64
+
+// MultiPolygon is a list of polygons, e.g. a region consisting of several separate areas
+type MultiPolygon []Polygon
+
+// Validate checks that the polygon consists of closed rings with at least four valid positions.
+func (p Polygon) Validate() error {
+	if len(p) == 0 {
+		return errors.New("polygon has no rings")
+	}
+
+	for i, ring := range p {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d must have at least 4 positions", i)
+		}
+
+		for _, pos := range ring {
+			if len(pos) != 2 {
+				return fmt.Errorf("ring %d contains a position without longitude and latitude", i)
+			}
+			if pos[0] < -180 || pos[0] > 180 || pos[1] < -90 || pos[1] > 90 {
+				return fmt.Errorf("ring %d contains an out of range position [%f, %f]", i, pos[0], pos[1])
+			}
+		}
+
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return fmt.Errorf("ring %d is not closed", i)
+		}
+	}
+
+	return nil
+}
+
+// Centroid returns the center of mass of the exterior ring. The polygon has to be valid.
+func (p Polygon) Centroid() (lat, long float64) {
+	ring := p[0]
+
+	var area, cx, cy float64
+	for i := 0; i < len(ring)-1; i++ {
+		x0, y0 := ring[i][0], ring[i][1]
+		x1, y1 := ring[i+1][0], ring[i+1][1]
+		cross := x0*y1 - x1*y0
+		area += cross
+		cx += (x0 + x1) * cross
+		cy += (y0 + y1) * cross
+	}
+
+	// Degenerated polygons without area fall back to the average of the positions
+	if area == 0 {
+		cx, cy = 0, 0
+		n := float64(len(ring) - 1)
+		for _, pos := range ring[:len(ring)-1] {
+			cx += pos[0]
+			cy += pos[1]
+		}
+		return cy / n, cx / n
+	}
+
+	area /= 2
+	return cy / (6 * area), cx / (6 * area)
+}
+
+// Validate checks that the multi polygon has at least one polygon and all polygons are valid.
+func (m MultiPolygon) Validate() error {
+	if len(m) == 0 {
+		return errors.New("multi polygon has no polygons")
+	}
+
+	for i, p := range m {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("polygon %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// WKT returns the multi polygon as well-known text with longitude as x coordinate
+func (m MultiPolygon) WKT() string {
+	var sb strings.Builder
+	sb.WriteString("MULTIPOLYGON(")
+	for i, p := range m {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("(")
+		for j, ring := range p {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("(")
+			for k, pos := range ring {
+				if k > 0 {
+					sb.WriteString(",")
+				}
+				sb.WriteString(strconv.FormatFloat(pos[0], 'f', -1, 64))
+				sb.WriteString(" ")
+				sb.WriteString(strconv.FormatFloat(pos[1], 'f', -1, 64))
+			}
+			sb.WriteString(")")
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// ParseMultiPolygonWKT reads a POLYGON or MULTIPOLYGON in well-known text, e.g.
+// "POLYGON((9.43 54.78, 9.44 54.78, 9.44 54.79, 9.43 54.78))". A polygon is returned as
+// multi polygon with a single polygon.
+func ParseMultiPolygonWKT(wkt string) (MultiPolygon, error) {
+	text := strings.ToUpper(strings.TrimSpace(wkt))
+	// EWKT may carry the spatial reference, only WGS 84 is supported
+	if srid, rest, ok := strings.Cut(text, ";"); ok {
+		if strings.TrimSpace(srid) != "SRID=4326" {
+			return nil, fmt.Errorf("unsupported spatial reference %q, expected SRID=4326", srid)
+		}
+		text = strings.TrimSpace(rest)
+	}
+
+	switch {
+	case strings.HasPrefix(text, "MULTIPOLYGON"):
+		var mp MultiPolygon
+		if err := parseWKTList(strings.TrimPrefix(text, "MULTIPOLYGON"), 3, &mp); err != nil {
+			return nil, err
+		}
+		return mp, nil
+	case strings.HasPrefix(text, "POLYGON"):
+		var p Polygon
+		if err := parseWKTList(strings.TrimPrefix(text, "POLYGON"), 2, &p); err != nil {
+			return nil, err
+		}
+		return MultiPolygon{p}, nil
+	default:
+		return nil, errors.New("geometry must be a POLYGON or MULTIPOLYGON")
+	}
+}
+
+// parseWKTList converts the nested parentheses of the WKT coordinates to JSON arrays
+// and decodes them. depth is the number of nested lists around the positions.
+func parseWKTList(text string, depth int, dst any) error {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "(") || strings.Count(text, "(") != strings.Count(text, ")") {
+		return errors.New("invalid well-known text")
+	}
+
+	var sb strings.Builder
+	level := 0
+	for _, token := range wktTokens.FindAllString(text, -1) {
+		switch token {
+		case "(":
+			level++
+			sb.WriteString("[")
+			if level == depth {
+				sb.WriteString("[")
+			}
+		case ")":
+			if level == depth {
+				sb.WriteString("]")
+			}
+			level--
+			sb.WriteString("]")
+		case ",":
+			if level == depth {
+				sb.WriteString("],[")
+			} else {
+				sb.WriteString(",")
+			}
+		default:
+			if level != depth {
+				return errors.New("invalid well-known text")
+			}
+			if !strings.HasSuffix(sb.String(), "[") {
+				sb.WriteString(",")
+			}
+			sb.WriteString(token)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(sb.String()), dst); err != nil {
+		return fmt.Errorf("invalid well-known text: %w", err)
+	}
+
+	return nil
+}
+
+var wktTokens = regexp.MustCompile(`[(),]|[-+0-9.eE]+|\S`)
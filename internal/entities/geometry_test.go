@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMultiPolygonWKT(t *testing.T) {
+	t.Run("should parse polygon as multi polygon", func(t *testing.T) {
+		// when
+		mp, err := ParseMultiPolygonWKT("POLYGON((9.43 54.78, 9.44 54.78, 9.44 54.79, 9.43 54.78))")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, MultiPolygon{{{{9.43, 54.78}, {9.44, 54.78}, {9.44, 54.79}, {9.43, 54.78}}}}, mp)
+	})
+
+	t.Run("should parse multi polygon with hole", func(t *testing.T) {
+		// when
+		mp, err := ParseMultiPolygonWKT("SRID=4326;MULTIPOLYGON(((0 0,10 0,10 10,0 0),(1 1,2 1,2 2,1 1)),((20 20,30 20,30 30,20 20)))")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, MultiPolygon{
+			{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}, {{1, 1}, {2, 1}, {2, 2}, {1, 1}}},
+			{{{20, 20}, {30, 20}, {30, 30}, {20, 20}}},
+		}, mp)
+	})
+
+	t.Run("should be the inverse of WKT", func(t *testing.T) {
+		// given
+		mp := MultiPolygon{{{{9.43, 54.78}, {9.44, 54.78}, {9.44, 54.79}, {9.43, 54.78}}}}
+
+		// when
+		parsed, err := ParseMultiPolygonWKT(mp.WKT())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, mp, parsed)
+	})
+
+	t.Run("should return error for invalid input", func(t *testing.T) {
+		for _, wkt := range []string{
+			"POINT(9.43 54.78)",
+			"POLYGON((9.43 54.78, 9.44 54.78)",
+			"POLYGON(9.43 54.78, 9.44 54.78)",
+			"SRID=25832;POLYGON((0 0,10 0,10 10,0 0))",
+			"POLYGON((a b, c d))",
+		} {
+			_, err := ParseMultiPolygonWKT(wkt)
+			assert.Error(t, err, wkt)
+		}
+	})
+}
+
+func TestCoordinate(t *testing.T) {
+	t.Run("should write longitude as x coordinate", func(t *testing.T) {
+		// when
+		c, err := NewCoordinate(54.82124518093376, 9.485702120628517)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "POINT(9.485702120628517 54.82124518093376)", c.WKT())
+	})
+
+	t.Run("should return error for out of range values", func(t *testing.T) {
+		for _, c := range []Coordinate{
+			{Latitude: 91, Longitude: 9.48},
+			{Latitude: -90.5, Longitude: 9.48},
+			{Latitude: 54.82, Longitude: 180.1},
+			{Latitude: math.NaN(), Longitude: 9.48},
+		} {
+			_, err := NewCoordinate(c.Latitude, c.Longitude)
+			assert.Error(t, err, c)
+		}
+	})
+}
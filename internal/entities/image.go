@@ -1,6 +1,13 @@
 package entities
 
-import "time"
+import (
+	"io"
+	"time"
+)
+
+// MaxImageDistance is the distance in meters between the position a photo was taken at and
+// the tree or flowerbed it is linked to, up to which the photo is considered to show that object.
+const MaxImageDistance = 100.0
 
 type Image struct {
 	ID        int32
@@ -9,4 +16,37 @@ type Image struct {
 	URL       string
 	Filename  *string
 	MimeType  *string
+	ObjectKey *string
+	// CapturedAt, Latitude and Longitude are read from the EXIF data of the photo if available
+	CapturedAt *time.Time
+	Latitude   *float64
+	Longitude  *float64
+	// LocationMismatch is set if the photo was taken far away from the tree or flowerbed it is linked to.
+	// It belongs to the link, so it is only set for images read as part of a tree or flowerbed.
+	LocationMismatch bool
+	Thumbnails       []*ImageThumbnail
+}
+
+type ImageThumbnailSize string
+
+const (
+	ImageThumbnailSizeSmall  ImageThumbnailSize = "small"
+	ImageThumbnailSizeMedium ImageThumbnailSize = "medium"
+	ImageThumbnailSizeLarge  ImageThumbnailSize = "large"
+)
+
+type ImageThumbnail struct {
+	Size      ImageThumbnailSize
+	Width     int32
+	Height    int32
+	URL       string
+	ObjectKey string
+}
+
+// ImageUpload is an image file uploaded by a user. Size is the size of the file in bytes
+// as announced by the client.
+type ImageUpload struct {
+	Filename string
+	Size     int64
+	File     io.Reader
 }
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+)
+
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// Query describes which page of a list is requested and how the list is sorted.
+// Pages start at 1. A limit of 0 returns all entries.
+type Query struct {
+	Page          int32
+	Limit         int32
+	SortBy        string
+	SortDirection SortDirection
+}
+
+// Offset returns the number of entries before the requested page
+func (q Query) Offset() int32 {
+	if q.Page <= 1 || q.Limit <= 0 {
+		return 0
+	}
+
+	return (q.Page - 1) * q.Limit
+}
+
+// TreeQuery is a query for trees with additional filters. Filters which are nil are not applied.
+type TreeQuery struct {
+	Query
+	Species       *string
+	MinAge        *int32
+	MaxAge        *int32
+	TreeClusterID *int32
+	SpatialFilter
+}
+
+// SpatialFilter restricts a list to the entities at a location.
+// Filters which are nil are not applied, all others have to match.
+type SpatialFilter struct {
+	// BoundingBox matches entities inside the box
+	BoundingBox *BoundingBox
+	// Radius matches entities within the radius around a point
+	Radius *Radius
+	// Area matches entities intersecting the polygons
+	Area MultiPolygon
+}
+
+// BoundingBox is the area between the south west corner Min and the north east corner Max
+type BoundingBox struct {
+	Min Coordinate
+	Max Coordinate
+}
+
+// Radius is a circle around the center with a radius in meters
+type Radius struct {
+	Center Coordinate
+	Meters float64
+}
+
+func (f SpatialFilter) Validate() error {
+	if b := f.BoundingBox; b != nil {
+		if err := b.Min.Validate(); err != nil {
+			return fmt.Errorf("bounding box: %w", err)
+		}
+		if err := b.Max.Validate(); err != nil {
+			return fmt.Errorf("bounding box: %w", err)
+		}
+		if b.Min.Longitude > b.Max.Longitude || b.Min.Latitude > b.Max.Latitude {
+			return errors.New("bounding box: minimum must not be greater than maximum")
+		}
+	}
+
+	if r := f.Radius; r != nil {
+		if err := r.Center.Validate(); err != nil {
+			return fmt.Errorf("radius: %w", err)
+		}
+		if r.Meters <= 0 {
+			return errors.New("radius: must be greater than 0")
+		}
+	}
+
+	if f.Area != nil {
+		if err := f.Area.Validate(); err != nil {
+			return fmt.Errorf("area: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// TreeClusterQuery is a query for tree clusters with additional filters. Filters which are nil are not applied.
+type TreeClusterQuery struct {
+	Query
+	WateringStatus *TreeClusterWateringStatus `validate:"omitempty,oneof=good moderate bad unknown"`
+	SpatialFilter
+}
@@ -7,4 +7,15 @@ type Region struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Name      string
+	Geometry  MultiPolygon
+}
+
+type RegionCreate struct {
+	Name     string       `validate:"required,max=255"`
+	Geometry MultiPolygon `validate:"required"`
+}
+
+type RegionUpdate struct {
+	Name     string       `validate:"required,max=255"`
+	Geometry MultiPolygon `validate:"required"`
 }
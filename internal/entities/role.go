@@ -1,5 +1,19 @@
 package entities
 
+// RoleName is the name of a Keycloak realm role
+type RoleName string
+
+const (
+	// RoleAdministrator may manage users and change all data
+	RoleAdministrator RoleName = "administrator"
+	// RoleTbz is the staff of the TBZ who may edit trees, clusters and other master data
+	RoleTbz RoleName = "tbz"
+	// RoleDriver has read-only access to the data needed on a watering tour
+	RoleDriver RoleName = "driver"
+)
+
 type Role struct {
-	Name string
+	ID          string
+	Name        string
+	Description string
 }
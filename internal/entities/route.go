@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+type RouteStopType string
+
+const (
+	RouteStopTypeDepot       RouteStopType = "depot"
+	RouteStopTypeTreeCluster RouteStopType = "tree_cluster"
+	RouteStopTypeRefill      RouteStopType = "refill"
+)
+
+// WaterPoint is a place where the tank of a vehicle can be refilled
+type WaterPoint struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// RouteStop is a stop of a watering tour. A tree cluster needing more water than the
+// vehicle can carry is visited more than once with refill stops in between.
+type RouteStop struct {
+	Type          RouteStopType
+	TreeCluster   *TreeCluster
+	WaterPoint    *WaterPoint
+	Latitude      float64
+	Longitude     float64
+	WaterRequired float64 // liters delivered at this stop
+	Distance      float64 // meters from the previous stop
+}
+
+// Route is a watering tour starting and ending at the depot
+type Route struct {
+	Vehicle            *Vehicle
+	Stops              []*RouteStop
+	Distance           float64 // meters
+	Duration           time.Duration
+	TotalWaterRequired float64
+	Refills            int32
+}
+
+type RouteCreate struct {
+	VehicleID      int32   `validate:"required"`
+	TreeClusterIDs []int32 `validate:"required,min=1,unique"`
+}
@@ -11,11 +11,15 @@ const (
 )
 
 type Sensor struct {
-	ID        int32
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Status    SensorStatus
-	Data      []*SensorData
+	ID            int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Status        SensorStatus
+	DeviceID      *string
+	DevEUI        *string
+	JoinEUI       *string
+	ApplicationID *string
+	Data          []*SensorData
 }
 
 type SensorData struct {
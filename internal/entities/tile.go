@@ -0,0 +1,20 @@
+package entities
+
+type TileLayer string
+
+const (
+	TileLayerTrees      TileLayer = "trees"
+	TileLayerClusters   TileLayer = "clusters"
+	TileLayerFlowerbeds TileLayer = "flowerbeds"
+	TileLayerRegions    TileLayer = "regions"
+)
+
+// TileQuery addresses a Mapbox vector tile of a layer with the zoom level and the x and y
+// coordinates of the tile in the Web Mercator tile grid (XYZ scheme)
+type TileQuery struct {
+	Layer           TileLayer `validate:"oneof=trees clusters flowerbeds regions"`
+	Z               int32     `validate:"gte=0,lte=22"`
+	X               int32     `validate:"gte=0"`
+	Y               int32     `validate:"gte=0"`
+	ExcludeArchived bool
+}
@@ -19,3 +19,38 @@ type Tree struct {
 	Latitude            float64
 	Longitude           float64
 }
+
+type TreeCreate struct {
+	TreeClusterID       *int32
+	Age                 int32 `validate:"gte=0"`
+	HeightAboveSeaLevel float64
+	PlantingYear        int32 `validate:"gte=0"`
+	Species             string
+	Number              int32
+	Latitude            float64 `validate:"min=-90,max=90"`
+	Longitude           float64 `validate:"min=-180,max=180"`
+}
+
+type TreeUpdate struct {
+	TreeClusterID       *int32
+	Age                 int32 `validate:"gte=0"`
+	HeightAboveSeaLevel float64
+	PlantingYear        int32 `validate:"gte=0"`
+	Species             string
+	Number              int32
+	Latitude            float64 `validate:"min=-90,max=90"`
+	Longitude           float64 `validate:"min=-180,max=180"`
+}
+
+// NearbyTreeQuery asks for the trees closest to a point, e.g. the position of a field worker
+type NearbyTreeQuery struct {
+	Latitude  float64 `validate:"min=-90,max=90"`
+	Longitude float64 `validate:"min=-180,max=180"`
+	Limit     int32   `validate:"min=1,max=100"`
+}
+
+// NearbyTree is a tree with its distance in meters to the point of a NearbyTreeQuery
+type NearbyTree struct {
+	Tree     *Tree
+	Distance float64
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+type TreeImportFormat string
+
+const (
+	TreeImportFormatCSV     TreeImportFormat = "csv"
+	TreeImportFormatGeoJSON TreeImportFormat = "geojson"
+)
+
+// TreeImportFormatFromFilename returns the format of a tree inventory file by its extension
+// or an empty format if the extension is unknown
+func TreeImportFormatFromFilename(filename string) TreeImportFormat {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt":
+		return TreeImportFormatCSV
+	case ".geojson", ".json":
+		return TreeImportFormatGeoJSON
+	default:
+		return ""
+	}
+}
+
+// TreeImport is a tree inventory, e.g. the tree cadastre of a city, as CSV file or GeoJSON feature
+// collection of points. Trees are matched by their tree number, existing trees are updated and
+// unknown trees are created. With DryRun the file is checked without writing anything.
+type TreeImport struct {
+	Format TreeImportFormat `validate:"oneof=csv geojson"`
+	File   io.Reader        `validate:"required"`
+	DryRun bool
+}
+
+// TreeImportError describes why a row of the imported file was skipped. Row is the line in a CSV file
+// (the header is line 1) or the position of the feature in a GeoJSON file starting at 1.
+type TreeImportError struct {
+	Row        int
+	TreeNumber *int32
+	Message    string
+}
+
+type TreeImportResult struct {
+	DryRun  bool
+	Total   int32
+	Created int32
+	Updated int32
+	Skipped int32
+	Errors  []*TreeImportError
+}
@@ -37,6 +37,8 @@ type TreeCluster struct {
 	Trees          []*Tree
 	SoilCondition  TreeSoilCondition
 	Name           string
+	// Hull is the area covered by the trees of the cluster, e.g. a street section
+	Hull Polygon
 }
 
 type TreeClusterCreate struct {
@@ -57,3 +59,24 @@ type TreeClusterUpdate struct {
 	TreeIDs       []*int32
 	Name          string
 }
+
+// TreeClusterSuggestionQuery configures the spatial clustering of the trees without tree cluster.
+// Trees end up in the same suggestion if they are connected by neighbours at most Distance meters apart,
+// groups with less than MinSize trees are left out. Trees have no soil condition of their own,
+// the SoilCondition is assigned to all suggestions.
+type TreeClusterSuggestionQuery struct {
+	Distance      float64           `validate:"gt=0,lte=500"`
+	MinSize       int32             `validate:"gte=1"`
+	RegionID      *int32            `validate:"omitempty,gt=0"`
+	SoilCondition TreeSoilCondition `validate:"omitempty,oneof=schluffig sandig lehmig tonig unknown"`
+}
+
+// TreeClusterSuggestion is a draft of a tree cluster, which is created once an operator accepts it
+type TreeClusterSuggestion struct {
+	Name          string
+	Region        *Region
+	Latitude      float64
+	Longitude     float64
+	SoilCondition TreeSoilCondition
+	TreeIDs       []int32
+}
@@ -7,7 +7,9 @@ type Entities interface {
 		Vehicle |
 		TreeCluster |
 		Tree |
-		Region
+		Region |
+		WateringPlan |
+		WateringEvent
 }
 
 type EntityFunc[T Entities] func(*T)
@@ -17,9 +17,27 @@ type User struct {
 	EmployeeID    string
 	PhoneNumber   string
 	EmailVerified bool
+	Enabled       bool
 	Avatar        *url.URL
 }
 
+// UserUpdate contains the changes of a user. Empty fields are left unchanged.
+type UserUpdate struct {
+	Username    string `validate:"omitempty,min=3,max=15"`
+	FirstName   string `validate:"omitempty,min=3,max=30"`
+	LastName    string `validate:"omitempty,min=3,max=30"`
+	Email       string `validate:"omitempty,email"`
+	EmployeeID  string
+	PhoneNumber string
+	Enabled     *bool
+}
+
+// UserQuery is a query for users. Search matches the username, name and email of a user.
+type UserQuery struct {
+	Query
+	Search *string
+}
+
 type RegisterUser struct {
 	User     User
 	Password string `validate:"required"`
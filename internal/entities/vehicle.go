@@ -12,3 +12,15 @@ type Vehicle struct {
 	Description   string
 	WaterCapacity float64
 }
+
+type VehicleCreate struct {
+	NumberPlate   string `validate:"required"`
+	Description   string
+	WaterCapacity float64 `validate:"gt=0"`
+}
+
+type VehicleUpdate struct {
+	NumberPlate   string `validate:"required"`
+	Description   string
+	WaterCapacity float64 `validate:"gt=0"`
+}
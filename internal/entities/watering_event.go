@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WateringEvent records that a tree cluster was watered. Vehicle and user are optional
+// because waterings may also be done by hand or reported afterwards.
+type WateringEvent struct {
+	ID            int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	TreeClusterID int32
+	VehicleID     *int32
+	UserID        *uuid.UUID
+	WateredAt     time.Time
+	WaterAmount   float64 // liters
+}
+
+type WateringEventCreate struct {
+	TreeClusterID int32 `validate:"required"`
+	VehicleID     *int32
+	UserID        *uuid.UUID
+	// WateredAt defaults to the current time
+	WateredAt   time.Time
+	WaterAmount float64 `validate:"gt=0"`
+}
+
+// WateringEventQuery is a query for watering events with additional filters. Filters which are nil are not applied.
+// The time range includes From and excludes To.
+type WateringEventQuery struct {
+	Query
+	TreeClusterID *int32
+	VehicleID     *int32
+	From          *time.Time
+	To            *time.Time
+}
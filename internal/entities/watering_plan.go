@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WateringPlanStatus string
+
+const (
+	WateringPlanStatusPlanned   WateringPlanStatus = "planned"
+	WateringPlanStatusActive    WateringPlanStatus = "active"
+	WateringPlanStatusFinished  WateringPlanStatus = "finished"
+	WateringPlanStatusCancelled WateringPlanStatus = "cancelled"
+)
+
+// WateringPlan is a watering tour of a vehicle and its crew on one day.
+// The tree clusters are watered in the order of the list.
+type WateringPlan struct {
+	ID                 int32
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Date               time.Time
+	Description        string
+	Status             WateringPlanStatus
+	TotalWaterRequired float64
+	Vehicle            *Vehicle
+	UserIDs            []uuid.UUID
+	TreeClusters       []*TreeCluster
+}
+
+type WateringPlanCreate struct {
+	Date           time.Time `validate:"required"`
+	Description    string
+	VehicleID      int32       `validate:"required"`
+	UserIDs        []uuid.UUID `validate:"required,min=1,unique"`
+	TreeClusterIDs []int32     `validate:"required,min=1,unique"`
+}
+
+type WateringPlanUpdate struct {
+	Date           time.Time `validate:"required"`
+	Description    string
+	Status         WateringPlanStatus `validate:"required,oneof=planned active finished cancelled"`
+	VehicleID      int32              `validate:"required"`
+	UserIDs        []uuid.UUID        `validate:"required,min=1,unique"`
+	TreeClusterIDs []int32            `validate:"required,min=1,unique"`
+}
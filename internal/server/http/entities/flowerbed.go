@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"time"
+)
+
+type FlowerbedResponse struct {
+	ID             int32            `json:"id"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+	Size           float64          `json:"size"`
+	Description    string           `json:"description"`
+	NumberOfPlants int32            `json:"number_of_plants"`
+	MoistureLevel  float64          `json:"moisture_level"`
+	Region         *RegionResponse  `json:"region,omitempty"`
+	Address        string           `json:"address"`
+	Sensor         *SensorResponse  `json:"sensor,omitempty"`
+	Images         []*ImageResponse `json:"images,omitempty"`
+	Archived       bool             `json:"archived"`
+	Latitude       float64          `json:"latitude"`
+	Longitude      float64          `json:"longitude"`
+	Geometry       *GeoJSONPolygon  `json:"geometry,omitempty"`
+} // @Name Flowerbed
+
+type FlowerbedListResponse struct {
+	Data       []*FlowerbedResponse `json:"data"`
+	Pagination Pagination           `json:"pagination"`
+} // @Name FlowerbedList
+
+type FlowerbedCreateRequest struct {
+	Size           float64         `json:"size"`
+	Description    string          `json:"description"`
+	NumberOfPlants int32           `json:"number_of_plants"`
+	MoistureLevel  float64         `json:"moisture_level"`
+	Address        string          `json:"address"`
+	Geometry       *GeoJSONPolygon `json:"geometry"`
+	SensorID       *int32          `json:"sensor_id,omitempty"`
+	ImageIDs       []*int32        `json:"image_ids,omitempty"`
+} // @Name FlowerbedCreate
+
+type FlowerbedUpdateRequest struct {
+	Size           float64         `json:"size"`
+	Description    string          `json:"description"`
+	NumberOfPlants int32           `json:"number_of_plants"`
+	MoistureLevel  float64         `json:"moisture_level"`
+	Address        string          `json:"address"`
+	Geometry       *GeoJSONPolygon `json:"geometry"`
+	SensorID       *int32          `json:"sensor_id,omitempty"`
+	ImageIDs       []*int32        `json:"image_ids,omitempty"`
+} // @Name FlowerbedUpdate
+
+type FlowerbedAddImagesRequest struct {
+	ImageIDs []*int32 `json:"image_ids,omitempty"`
+} // @Name FlowerbedAddImages
+
+type FlowerbedAddSensorRequest struct {
+	SensorID *int32 `json:"sensor_id,omitempty"`
+} // @Name FlowerbedAddSensor
@@ -0,0 +1,39 @@
+package entities
+
+type GeoJSONPolygon struct {
+	Type        string        `json:"type" example:"Polygon"`
+	Coordinates [][][]float64 `json:"coordinates"`
+} // @Name GeoJSONPolygon
+
+// GeoJSON objects as defined in RFC 7946. Positions are [longitude, latitude] pairs.
+
+type GeoJSONType string // @Name GeoJSONType
+
+const (
+	GeoJSONTypeFeatureCollection GeoJSONType = "FeatureCollection"
+	GeoJSONTypeFeature           GeoJSONType = "Feature"
+	GeoJSONTypePoint             GeoJSONType = "Point"
+	GeoJSONTypeLineString        GeoJSONType = "LineString"
+	GeoJSONTypePolygon           GeoJSONType = "Polygon"
+	GeoJSONTypeMultiPolygon      GeoJSONType = "MultiPolygon"
+)
+
+type GeoJSONGeometry struct {
+	Type        GeoJSONType `json:"type"`
+	Coordinates any         `json:"coordinates"`
+} // @Name GeoJSONGeometry
+
+// GeoJSONFeature has no geometry if the location of the entity is unknown
+type GeoJSONFeature struct {
+	Type       GeoJSONType      `json:"type"`
+	ID         *int32           `json:"id,omitempty"`
+	Geometry   *GeoJSONGeometry `json:"geometry"`
+	Properties map[string]any   `json:"properties"`
+} // @Name GeoJSONFeature
+
+// GeoJSONFeatureCollection carries the pagination of list endpoints as foreign member
+type GeoJSONFeatureCollection struct {
+	Type       GeoJSONType       `json:"type"`
+	Features   []*GeoJSONFeature `json:"features"`
+	Pagination *Pagination       `json:"pagination,omitempty"`
+} // @Name GeoJSONFeatureCollection
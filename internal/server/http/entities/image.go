@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+)
+
+type ImageResponse struct {
+	ID        int32     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"url"`
+	Filename  *string   `json:"filename,omitempty"`
+	MimeType  *string   `json:"mime_type,omitempty"`
+	// CapturedAt, Latitude and Longitude are read from the EXIF data of the photo if available
+	CapturedAt       *time.Time                `json:"captured_at,omitempty"`
+	Latitude         *float64                  `json:"latitude,omitempty"`
+	Longitude        *float64                  `json:"longitude,omitempty"`
+	LocationMismatch bool                      `json:"location_mismatch"`
+	Thumbnails       []*ImageThumbnailResponse `json:"thumbnails"`
+} // @Name Image
+
+type ImageThumbnailResponse struct {
+	Size   string `json:"size"`
+	Width  int32  `json:"width"`
+	Height int32  `json:"height"`
+	URL    string `json:"url"`
+} // @Name ImageThumbnail
+
+type ImageListResponse struct {
+	Data       []*ImageResponse `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+} // @Name ImageList
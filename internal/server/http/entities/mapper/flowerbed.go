@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapPolygonToGeoJSON MapGeoJSONToPolygon
+type FlowerbedHTTPMapper interface {
+	// goverter:ignore Region Sensor
+	FromResponse(*domain.Flowerbed) *entities.FlowerbedResponse
+	FromResponseList([]*domain.Flowerbed) []*entities.FlowerbedResponse
+
+	FromCreateRequest(*entities.FlowerbedCreateRequest) *domain.FlowerbedCreate
+	FromUpdateRequest(*entities.FlowerbedUpdateRequest) *domain.FlowerbedUpdate
+}
+
+func MapPolygonToGeoJSON(p domain.Polygon) *entities.GeoJSONPolygon {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return &entities.GeoJSONPolygon{
+		Type:        "Polygon",
+		Coordinates: p,
+	}
+}
+
+func MapGeoJSONToPolygon(p *entities.GeoJSONPolygon) domain.Polygon {
+	if p == nil {
+		return nil
+	}
+
+	return p.Coordinates
+}
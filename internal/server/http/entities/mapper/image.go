@@ -0,0 +1,13 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+type ImageHTTPMapper interface {
+	FromResponse(*domain.Image) *entities.ImageResponse
+	FromResponseList([]*domain.Image) []*entities.ImageResponse
+}
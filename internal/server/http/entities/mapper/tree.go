@@ -13,6 +13,11 @@ type TreeHTTPMapper interface {
 	// goverter:ignore Sensor
 	FromResponse(*domain.Tree) *entities.TreeResponse
 	FromResponseList([]*domain.Tree) []*entities.TreeResponse
+
+	FromCreateRequest(*entities.TreeCreateRequest) *domain.TreeCreate
+	FromUpdateRequest(*entities.TreeUpdateRequest) *domain.TreeUpdate
+
+	FromImportResponse(*domain.TreeImportResult) *entities.TreeImportResponse
 }
 
 func MapTreeClusterToID(treeCluster *domain.TreeCluster) *int32 {
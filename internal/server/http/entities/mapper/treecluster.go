@@ -8,7 +8,7 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
-// goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq
+// goverter:extend MapWateringStatus MapSoilCondition MapSoilConditionReq MapPolygonToGeoJSON
 type TreeClusterHTTPMapper interface {
 	// goverter:ignore Region Trees
 	FormResponse(*domain.TreeCluster) *entities.TreeClusterResponse
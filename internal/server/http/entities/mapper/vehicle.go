@@ -0,0 +1,16 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+type VehicleHTTPMapper interface {
+	FromResponse(*domain.Vehicle) *entities.VehicleResponse
+	FromResponseList([]*domain.Vehicle) []*entities.VehicleResponse
+
+	FromCreateRequest(*entities.VehicleCreateRequest) *domain.VehicleCreate
+	FromUpdateRequest(*entities.VehicleUpdateRequest) *domain.VehicleUpdate
+}
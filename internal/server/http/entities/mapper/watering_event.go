@@ -0,0 +1,14 @@
+package mapper
+
+import (
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend MapUUID
+type WateringEventHTTPMapper interface {
+	FromResponse(*domain.WateringEvent) *entities.WateringEventResponse
+	FromResponseList([]*domain.WateringEvent) []*entities.WateringEventResponse
+}
@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:TimeToTimePtr
+// goverter:extend MapWateringPlanStatus MapWateringStatus MapSoilCondition MapUUID MapPolygonToGeoJSON
+type WateringPlanHTTPMapper interface {
+	FromResponse(*domain.WateringPlan) *entities.WateringPlanResponse
+	FromResponseList([]*domain.WateringPlan) []*entities.WateringPlanResponse
+
+	// goverter:ignore Region Trees
+	FromTreeClusterResponse(*domain.TreeCluster) *entities.TreeClusterResponse
+}
+
+func MapWateringPlanStatus(status domain.WateringPlanStatus) entities.WateringPlanStatus {
+	return entities.WateringPlanStatus(status)
+}
+
+func MapUUID(id uuid.UUID) string {
+	return id.String()
+}
@@ -1,11 +1,26 @@
 package entities
 
 type RegionResponse struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID       int32            `json:"id"`
+	Name     string           `json:"name"`
+	Geometry *GeoJSONGeometry `json:"geometry,omitempty"`
 } // @Name Region
 
 type RegionListResponse struct {
 	Regions    []*RegionResponse `json:"regions"`
 	Pagination Pagination        `json:"pagination"`
 } // @Name RegionList
+
+// RegionCreateRequest takes the boundary either as GeoJSON polygon or multi polygon
+// or as WKT, e.g. "MULTIPOLYGON(((9.40 54.78, 9.45 54.78, 9.45 54.80, 9.40 54.78)))"
+type RegionCreateRequest struct {
+	Name     string           `json:"name"`
+	Geometry *GeoJSONGeometry `json:"geometry,omitempty"`
+	WKT      string           `json:"wkt,omitempty"`
+} // @Name RegionCreate
+
+type RegionUpdateRequest struct {
+	Name     string           `json:"name"`
+	Geometry *GeoJSONGeometry `json:"geometry,omitempty"`
+	WKT      string           `json:"wkt,omitempty"`
+} // @Name RegionUpdate
@@ -0,0 +1,33 @@
+package entities
+
+type RouteStopType string // @Name RouteStopType
+
+const (
+	RouteStopTypeDepot       RouteStopType = "depot"
+	RouteStopTypeTreeCluster RouteStopType = "tree_cluster"
+	RouteStopTypeRefill      RouteStopType = "refill"
+)
+
+type RouteStopResponse struct {
+	Type          RouteStopType `json:"type"`
+	TreeClusterID *int32        `json:"tree_cluster_id,omitempty"`
+	Name          string        `json:"name"`
+	Latitude      float64       `json:"latitude"`
+	Longitude     float64       `json:"longitude"`
+	WaterRequired float64       `json:"water_required"`
+	Distance      float64       `json:"distance"`
+} // @Name RouteStop
+
+type RouteResponse struct {
+	Vehicle            *VehicleResponse     `json:"vehicle"`
+	Stops              []*RouteStopResponse `json:"stops"`
+	Distance           float64              `json:"distance"`
+	Duration           int64                `json:"duration"` // seconds
+	TotalWaterRequired float64              `json:"total_water_required"`
+	Refills            int32                `json:"refills"`
+} // @Name Route
+
+type RouteRequest struct {
+	VehicleID      int32   `json:"vehicle_id"`
+	TreeClusterIDs []int32 `json:"tree_cluster_ids"`
+} // @Name RouteRequest
@@ -13,11 +13,15 @@ const (
 )
 
 type SensorResponse struct {
-	ID        int32        `json:"id"`
-	CreatedAt time.Time    `json:"created_at"`
-	UpdatedAt time.Time    `json:"updated_at"`
-	Status    SensorStatus `json:"status"`
-	Type      string       `json:"type"`
+	ID            int32        `json:"id"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	Status        SensorStatus `json:"status"`
+	Type          string       `json:"type"`
+	DeviceID      *string      `json:"device_id,omitempty"`
+	DevEUI        *string      `json:"dev_eui,omitempty"`
+	JoinEUI       *string      `json:"join_eui,omitempty"`
+	ApplicationID *string      `json:"application_id,omitempty"`
 } // @Name Sensor
 
 type SensorListResponse struct {
@@ -25,6 +25,15 @@ type TreeListResponse struct {
 	Pagination Pagination      `json:"pagination,omitempty"`
 } // @Name TreeList
 
+type NearbyTreeResponse struct {
+	Tree     *TreeResponse `json:"tree"`
+	Distance float64       `json:"distance"` // meters
+} // @Name NearbyTree
+
+type NearbyTreeListResponse struct {
+	Data []*NearbyTreeResponse `json:"data"`
+} // @Name NearbyTreeList
+
 type TreeCreateRequest struct {
 	TreeClusterID       *int32  `json:"tree_cluster_id,omitempty"`
 	Age                 int32   `json:"age,omitempty"`
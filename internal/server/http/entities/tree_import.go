@@ -0,0 +1,16 @@
+package entities
+
+type TreeImportErrorResponse struct {
+	Row        int    `json:"row"`
+	TreeNumber *int32 `json:"tree_number,omitempty"`
+	Message    string `json:"message"`
+} // @Name TreeImportError
+
+type TreeImportResponse struct {
+	DryRun  bool                       `json:"dry_run"`
+	Total   int32                      `json:"total"`
+	Created int32                      `json:"created"`
+	Updated int32                      `json:"updated"`
+	Skipped int32                      `json:"skipped"`
+	Errors  []*TreeImportErrorResponse `json:"errors"`
+} // @Name TreeImport
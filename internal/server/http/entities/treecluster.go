@@ -35,6 +35,7 @@ type TreeClusterResponse struct {
 	Trees          []*TreeResponse           `json:"trees,omitempty"`
 	SoilCondition  TreeSoilCondition         `json:"soil_condition,omitempty"`
 	Name           string                    `json:"name,omitempty"`
+	Hull           *GeoJSONPolygon           `json:"hull,omitempty"`
 } // @Name TreeCluster
 
 type TreeClusterListResponse struct {
@@ -64,3 +65,21 @@ type TreeClusterUpdateRequest struct {
 type TreeClusterAddTreesRequest struct {
 	TreeIDs []*int32 `json:"tree_ids,omitempty"`
 } // @Name TreeClusterAddTrees
+
+type TreeClusterSuggestionResponse struct {
+	Name          string            `json:"name"`
+	Region        *RegionResponse   `json:"region,omitempty"`
+	Latitude      float64           `json:"latitude"`
+	Longitude     float64           `json:"longitude"`
+	SoilCondition TreeSoilCondition `json:"soil_condition"`
+	TreeIDs       []int32           `json:"tree_ids"`
+} // @Name TreeClusterSuggestion
+
+type TreeClusterSuggestionListResponse struct {
+	Data []*TreeClusterSuggestionResponse `json:"data"`
+} // @Name TreeClusterSuggestionList
+
+// TreeClusterAcceptSuggestionsRequest contains the suggestions to create, possibly edited by the operator
+type TreeClusterAcceptSuggestionsRequest struct {
+	Clusters []*TreeClusterCreateRequest `json:"clusters"`
+} // @Name TreeClusterAcceptSuggestions
@@ -14,6 +14,7 @@ type UserResponse struct {
 	EmployeeID    string    `json:"employee_id"`
 	PhoneNumber   string    `json:"phone_number"`
 	EmailVerified bool      `json:"email_verified"`
+	Enabled       bool      `json:"enabled"`
 	Avatar        string    `json:"avatar_url"`
 } // @Name User
 
@@ -42,4 +43,9 @@ type UserUpdateRequest struct {
 	EmployeeID  string `json:"employee_id,omitempty"`
 	PhoneNumber string `json:"phone_number,omitempty"`
 	Avatar      string `json:"avatar_url,omitempty"`
+	Enabled     *bool  `json:"enabled,omitempty"`
 } // @Name UserUpdate
+
+type UserRolesRequest struct {
+	Roles []string `json:"roles"`
+} // @Name UserRoles
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+)
+
+type VehicleResponse struct {
+	ID            int32     `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	NumberPlate   string    `json:"number_plate"`
+	Description   string    `json:"description"`
+	WaterCapacity float64   `json:"water_capacity"`
+} // @Name Vehicle
+
+type VehicleListResponse struct {
+	Data       []*VehicleResponse `json:"data"`
+	Pagination Pagination         `json:"pagination"`
+} // @Name VehicleList
+
+type VehicleCreateRequest struct {
+	NumberPlate   string  `json:"number_plate"`
+	Description   string  `json:"description"`
+	WaterCapacity float64 `json:"water_capacity"`
+} // @Name VehicleCreate
+
+type VehicleUpdateRequest struct {
+	NumberPlate   string  `json:"number_plate"`
+	Description   string  `json:"description"`
+	WaterCapacity float64 `json:"water_capacity"`
+} // @Name VehicleUpdate
+
+type VehicleUserAssignRequest struct {
+	UserID string `json:"user_id"`
+} // @Name VehicleUserAssign
+
+type VehicleUserListResponse struct {
+	Data []string `json:"data"`
+} // @Name VehicleUserList
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+)
+
+type WateringEventResponse struct {
+	ID            int32     `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	TreeClusterID int32     `json:"tree_cluster_id"`
+	VehicleID     *int32    `json:"vehicle_id"`
+	UserID        *string   `json:"user_id"`
+	WateredAt     time.Time `json:"watered_at"`
+	WaterAmount   float64   `json:"water_amount"`
+} // @Name WateringEvent
+
+type WateringEventListResponse struct {
+	Data             []*WateringEventResponse `json:"data"`
+	Pagination       Pagination               `json:"pagination"`
+	TotalWaterAmount float64                  `json:"total_water_amount"`
+} // @Name WateringEventList
+
+type WateringEventCreateRequest struct {
+	TreeClusterID int32      `json:"tree_cluster_id"`
+	VehicleID     *int32     `json:"vehicle_id"`
+	UserID        *string    `json:"user_id"`
+	WateredAt     *time.Time `json:"watered_at"`
+	WaterAmount   float64    `json:"water_amount"`
+} // @Name WateringEventCreate
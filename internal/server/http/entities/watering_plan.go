@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"time"
+)
+
+type WateringPlanStatus string // @Name WateringPlanStatus
+
+const (
+	WateringPlanStatusPlanned   WateringPlanStatus = "planned"
+	WateringPlanStatusActive    WateringPlanStatus = "active"
+	WateringPlanStatusFinished  WateringPlanStatus = "finished"
+	WateringPlanStatusCancelled WateringPlanStatus = "cancelled"
+)
+
+type WateringPlanResponse struct {
+	ID                 int32                  `json:"id"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	Date               time.Time              `json:"date"`
+	Description        string                 `json:"description"`
+	Status             WateringPlanStatus     `json:"status"`
+	TotalWaterRequired float64                `json:"total_water_required"`
+	Vehicle            *VehicleResponse       `json:"vehicle"`
+	UserIDs            []string               `json:"user_ids"`
+	TreeClusters       []*TreeClusterResponse `json:"tree_clusters"`
+} // @Name WateringPlan
+
+type WateringPlanListResponse struct {
+	Data       []*WateringPlanResponse `json:"data"`
+	Pagination Pagination              `json:"pagination"`
+} // @Name WateringPlanList
+
+type WateringPlanCreateRequest struct {
+	Date           time.Time `json:"date"`
+	Description    string    `json:"description"`
+	VehicleID      int32     `json:"vehicle_id"`
+	UserIDs        []string  `json:"user_ids"`
+	TreeClusterIDs []int32   `json:"tree_cluster_ids"`
+} // @Name WateringPlanCreate
+
+type WateringPlanUpdateRequest struct {
+	Date           time.Time          `json:"date"`
+	Description    string             `json:"description"`
+	Status         WateringPlanStatus `json:"status"`
+	VehicleID      int32              `json:"vehicle_id"`
+	UserIDs        []string           `json:"user_ids"`
+	TreeClusterIDs []int32            `json:"tree_cluster_ids"`
+} // @Name WateringPlanUpdate
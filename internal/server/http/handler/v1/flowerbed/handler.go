@@ -0,0 +1,488 @@
+package flowerbed
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/geojson"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	flowerbedMapper = generated.FlowerbedHTTPMapperImpl{}
+	sensorMapper    = generated.SensorHTTPMapperImpl{}
+	regionMapper    = generated.RegionHTTPMapperImpl{}
+	imageMapper     = generated.ImageHTTPMapperImpl{}
+)
+
+// @Summary		Get all flowerbeds
+// @Description	Get all flowerbeds. With format=geojson or the Accept header application/geo+json the flowerbeds are returned as GeoJSON feature collection of polygons, flowerbeds without geometry are returned as points.
+// @Id				get-all-flowerbeds
+// @Tags			Flowerbed
+// @Produce		json,application/geo+json
+// @Success		200	{object}	entities.FlowerbedListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed [get]
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			sort			query	string	false	"Sort field (id, size, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			format			query	string	false	"Response format (json or geojson)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetAllFlowerbeds(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if geojson.Requested(c) {
+			features := make([]*entities.GeoJSONFeature, len(domainData))
+			for i, f := range domainData {
+				geometry := geojson.Polygon(f.Geometry)
+				if geometry == nil {
+					geometry = geojson.Point(f.Latitude, f.Longitude)
+				}
+				if features[i], err = geojson.NewFeature(f.ID, geometry, mapFlowerbedToDto(f)); err != nil {
+					return err
+				}
+			}
+			return geojson.Send(c, features, pagination.Create(query, total))
+		}
+
+		data := make([]*entities.FlowerbedResponse, len(domainData))
+		for i, domain := range domainData {
+			data[i] = mapFlowerbedToDto(domain)
+		}
+
+		return c.JSON(entities.FlowerbedListResponse{
+			Data:       data,
+			Pagination: pagination.Create(query, total),
+		})
+	}
+}
+
+// @Summary		Get flowerbed by ID
+// @Description	Get flowerbed by ID
+// @Id				get-flowerbed
+// @Tags			Flowerbed
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id} [get]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetFlowerbedByID(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Create flowerbed
+// @Description	Create flowerbed
+// @Id				create-flowerbed
+// @Tags			Flowerbed
+// @Produce		json
+// @Success		201	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed [post]
+// @Param			body			body	entities.FlowerbedCreateRequest	true	"Flowerbed to create"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func CreateFlowerbed(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.FlowerbedCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := flowerbedMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Update flowerbed
+// @Description	Update flowerbed
+// @Id				update-flowerbed
+// @Tags			Flowerbed
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id} [put]
+// @Param			flowerbed_id	path	string							true	"Flowerbed ID"
+// @Param			body			body	entities.FlowerbedUpdateRequest	true	"Flowerbed to update"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func UpdateFlowerbed(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.FlowerbedUpdateRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := flowerbedMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, id, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Archive flowerbed
+// @Description	Archive flowerbed
+// @Id				archive-flowerbed
+// @Tags			Flowerbed
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/archive [post]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func ArchiveFlowerbed(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Archive(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Delete flowerbed
+// @Description	Delete flowerbed
+// @Id				delete-flowerbed
+// @Tags			Flowerbed
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id} [delete]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteFlowerbed(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Delete(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Get sensor of a flowerbed
+// @Description	Get sensor of a flowerbed
+// @Id				get-flowerbed-sensor
+// @Tags			Flowerbed Sensor
+// @Produce		json
+// @Success		200	{object}	entities.SensorResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/sensor [get]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetFlowerbedSensor(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetSensor(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(sensorMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Add sensor to a flowerbed
+// @Description	Add sensor to a flowerbed
+// @Id				add-sensor-to-flowerbed
+// @Tags			Flowerbed Sensor
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/sensor [post]
+// @Param			flowerbed_id	path	string								true	"Flowerbed ID"
+// @Param			body			body	entities.FlowerbedAddSensorRequest	true	"Sensor to add"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func AddFlowerbedSensor(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.FlowerbedAddSensorRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		if req.SensorID == nil {
+			return fiber.NewError(fiber.StatusBadRequest, "sensor_id is required")
+		}
+
+		domainData, err := svc.AddSensor(ctx, id, *req.SensorID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Remove sensor from a flowerbed
+// @Description	Remove sensor from a flowerbed
+// @Id				remove-sensor-from-flowerbed
+// @Tags			Flowerbed Sensor
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/sensor/{sensor_id} [delete]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			sensor_id		path	string	true	"Sensor ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func RemoveFlowerbedSensor(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		sensorID, err := parseID(c, "sensor_id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.RemoveSensor(ctx, id, sensorID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Get images of a flowerbed
+// @Description	Get images of a flowerbed
+// @Id				get-flowerbed-images
+// @Tags			Flowerbed Images
+// @Produce		json
+// @Success		200	{object}	entities.ImageListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/images [get]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetFlowerbedImages(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetImages(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.ImageListResponse{
+			Data:       imageMapper.FromResponseList(pagination.Slice(domainData, query)),
+			Pagination: pagination.Create(query, int64(len(domainData))),
+		})
+	}
+}
+
+// @Summary		Add images to a flowerbed
+// @Description	Add images to a flowerbed
+// @Id				add-images-to-flowerbed
+// @Tags			Flowerbed Images
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/images [post]
+// @Param			flowerbed_id	path	string								true	"Flowerbed ID"
+// @Param			body			body	entities.FlowerbedAddImagesRequest	true	"Images to add"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func AddFlowerbedImages(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.FlowerbedAddImagesRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		imageIDs := make([]int32, 0, len(req.ImageIDs))
+		for _, imageID := range req.ImageIDs {
+			if imageID != nil {
+				imageIDs = append(imageIDs, *imageID)
+			}
+		}
+
+		domainData, err := svc.AddImages(ctx, id, imageIDs)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+// @Summary		Remove image from a flowerbed
+// @Description	Remove image from a flowerbed
+// @Id				remove-image-from-flowerbed
+// @Tags			Flowerbed Images
+// @Produce		json
+// @Success		200	{object}	entities.FlowerbedResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/flowerbed/{flowerbed_id}/images/{image_id} [delete]
+// @Param			flowerbed_id	path	string	true	"Flowerbed ID"
+// @Param			image_id		path	string	true	"Image ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func RemoveFlowerbedImage(svc service.FlowerbedService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		imageID, err := parseID(c, "image_id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.RemoveImage(ctx, id, imageID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapFlowerbedToDto(domainData))
+	}
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
+}
+
+func mapFlowerbedToDto(f *domain.Flowerbed) *entities.FlowerbedResponse {
+	dto := flowerbedMapper.FromResponse(f)
+	dto.Sensor = sensorMapper.FromResponse(f.Sensor)
+	dto.Region = regionMapper.FromResponse(f.Region)
+
+	return dto
+}
@@ -0,0 +1,30 @@
+package flowerbed
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.FlowerbedService) *fiber.App {
+	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
+
+	app.Get("/", GetAllFlowerbeds(svc))
+	app.Get("/:id", GetFlowerbedByID(svc))
+	app.Post("/", canEdit, CreateFlowerbed(svc))
+	app.Put("/:id", canEdit, UpdateFlowerbed(svc))
+	app.Post("/:id/archive", canEdit, ArchiveFlowerbed(svc))
+	app.Delete("/:id", canEdit, DeleteFlowerbed(svc))
+
+	app.Get("/:id/images", GetFlowerbedImages(svc))
+	app.Post("/:id/images", canEdit, AddFlowerbedImages(svc))
+	app.Delete("/:id/images/:image_id", canEdit, RemoveFlowerbedImage(svc))
+
+	app.Get("/:id/sensor", GetFlowerbedSensor(svc))
+	app.Post("/:id/sensor", canEdit, AddFlowerbedSensor(svc))
+	app.Delete("/:id/sensor/:sensor_id", canEdit, RemoveFlowerbedSensor(svc))
+
+	return app
+}
@@ -0,0 +1,115 @@
+package geojson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+const MimeType = "application/geo+json"
+
+// Requested reports whether the client asked for GeoJSON, either with the query param format=geojson
+// or by preferring application/geo+json in the Accept header
+func Requested(c *fiber.Ctx) bool {
+	if format := c.Query("format"); format != "" {
+		return format == "geojson"
+	}
+
+	return c.Accepts(fiber.MIMEApplicationJSON, MimeType) == MimeType
+}
+
+// NewFeature returns a feature with the attributes of the response dto as properties.
+// Geometries of the dto are left out of the properties as they are already part of the feature.
+func NewFeature(id int32, geometry *entities.GeoJSONGeometry, dto any) (*entities.GeoJSONFeature, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]any)
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, err
+	}
+	delete(properties, "geometry")
+	delete(properties, "hull")
+
+	return &entities.GeoJSONFeature{
+		Type:       entities.GeoJSONTypeFeature,
+		ID:         &id,
+		Geometry:   geometry,
+		Properties: properties,
+	}, nil
+}
+
+// Send writes the features as feature collection with the pagination of the list
+func Send(c *fiber.Ctx, features []*entities.GeoJSONFeature, pagination entities.Pagination) error {
+	return c.JSON(entities.GeoJSONFeatureCollection{
+		Type:       entities.GeoJSONTypeFeatureCollection,
+		Features:   features,
+		Pagination: &pagination,
+	}, MimeType)
+}
+
+func Point(latitude, longitude float64) *entities.GeoJSONGeometry {
+	return &entities.GeoJSONGeometry{
+		Type:        entities.GeoJSONTypePoint,
+		Coordinates: []float64{longitude, latitude},
+	}
+}
+
+func Polygon(p domain.Polygon) *entities.GeoJSONGeometry {
+	if len(p) == 0 {
+		return nil
+	}
+
+	return &entities.GeoJSONGeometry{
+		Type:        entities.GeoJSONTypePolygon,
+		Coordinates: p,
+	}
+}
+
+func MultiPolygon(mp domain.MultiPolygon) *entities.GeoJSONGeometry {
+	if len(mp) == 0 {
+		return nil
+	}
+
+	return &entities.GeoJSONGeometry{
+		Type:        entities.GeoJSONTypeMultiPolygon,
+		Coordinates: mp,
+	}
+}
+
+// ToMultiPolygon converts a GeoJSON polygon or multi polygon of a request body. A polygon
+// becomes a multi polygon with a single polygon.
+func ToMultiPolygon(g *entities.GeoJSONGeometry) (domain.MultiPolygon, error) {
+	if g == nil {
+		return nil, errors.New("geometry is missing")
+	}
+
+	// the coordinates of a parsed request body are nested []any
+	data, err := json.Marshal(g.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	switch g.Type {
+	case entities.GeoJSONTypePolygon:
+		var p domain.Polygon
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("invalid polygon coordinates: %w", err)
+		}
+		return domain.MultiPolygon{p}, nil
+	case entities.GeoJSONTypeMultiPolygon:
+		var mp domain.MultiPolygon
+		if err := json.Unmarshal(data, &mp); err != nil {
+			return nil, fmt.Errorf("invalid multi polygon coordinates: %w", err)
+		}
+		return mp, nil
+	default:
+		return nil, fmt.Errorf("geometry must be a Polygon or MultiPolygon, got %q", g.Type)
+	}
+}
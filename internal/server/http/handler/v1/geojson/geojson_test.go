@@ -0,0 +1,115 @@
+package geojson
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequested(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		accept   string
+		expected bool
+	}{
+		{name: "should default to json", url: "/", expected: false},
+		{name: "should use format param", url: "/?format=geojson", expected: true},
+		{name: "should prefer format param over accept header", url: "/?format=json", accept: MimeType, expected: false},
+		{name: "should use accept header", url: "/", accept: MimeType, expected: true},
+		{name: "should use json for any content type", url: "/", accept: "*/*", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				return c.JSON(Requested(c))
+			})
+			req := httptest.NewRequest(fiber.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set(fiber.HeaderAccept, tt.accept)
+			}
+
+			// when
+			resp, err := app.Test(req)
+
+			// then
+			assert.NoError(t, err)
+			body := make([]byte, 5)
+			n, _ := resp.Body.Read(body)
+			assert.Equal(t, tt.expected, string(body[:n]) == "true")
+		})
+	}
+}
+
+func TestNewFeature(t *testing.T) {
+	t.Run("should use attributes of dto as properties without geometry", func(t *testing.T) {
+		// given
+		dto := struct {
+			Name     string `json:"name"`
+			Geometry any    `json:"geometry"`
+		}{Name: "Cluster", Geometry: "POINT(1 2)"}
+
+		// when
+		feature, err := NewFeature(1, Point(54.8, 9.4), dto)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), *feature.ID)
+		assert.Equal(t, map[string]any{"name": "Cluster"}, feature.Properties)
+		assert.Equal(t, []float64{9.4, 54.8}, feature.Geometry.Coordinates)
+	})
+
+	t.Run("should leave out the hull of tree clusters", func(t *testing.T) {
+		// given
+		hull := domain.Polygon{{{9.4, 54.8}, {9.5, 54.8}, {9.5, 54.9}, {9.4, 54.8}}}
+		dto := &entities.TreeClusterResponse{
+			Name: "Cluster",
+			Hull: &entities.GeoJSONPolygon{Type: "Polygon", Coordinates: hull},
+		}
+
+		// when
+		feature, err := NewFeature(1, Polygon(hull), dto)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotContains(t, feature.Properties, "hull")
+		assert.Equal(t, "Cluster", feature.Properties["name"])
+		assert.Equal(t, entities.GeoJSONTypePolygon, feature.Geometry.Type)
+	})
+}
+
+func TestToMultiPolygon(t *testing.T) {
+	t.Run("should wrap polygon into multi polygon", func(t *testing.T) {
+		// given
+		var geometry entities.GeoJSONGeometry
+		body := `{"type": "Polygon", "coordinates": [[[9.40, 54.78], [9.45, 54.78], [9.45, 54.80], [9.40, 54.78]]]}`
+		assert.NoError(t, json.Unmarshal([]byte(body), &geometry))
+
+		// when
+		mp, err := ToMultiPolygon(&geometry)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.MultiPolygon{{{{9.40, 54.78}, {9.45, 54.78}, {9.45, 54.80}, {9.40, 54.78}}}}, mp)
+	})
+
+	t.Run("should return error for other geometry types", func(t *testing.T) {
+		// given
+		geometry := Point(54.8, 9.4)
+
+		// when
+		mp, err := ToMultiPolygon(geometry)
+
+		// then
+		assert.Nil(t, mp)
+		assert.Error(t, err)
+	})
+}
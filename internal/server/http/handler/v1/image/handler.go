@@ -0,0 +1,201 @@
+package image
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	imageMapper = generated.ImageHTTPMapperImpl{}
+)
+
+// @Summary		Get all images
+// @Description	Get all images
+// @Id				get-all-images
+// @Tags			Image
+// @Produce		json
+// @Success		200	{object}	entities.ImageListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/image [get]
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			sort			query	string	false	"Sort field (id, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetAllImages(svc service.ImageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.ImageListResponse{
+			Data:       imageMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(query, total),
+		})
+	}
+}
+
+// @Summary		Get image by ID
+// @Description	Get image by ID
+// @Id				get-image
+// @Tags			Image
+// @Produce		json
+// @Success		200	{object}	entities.ImageResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/image/{image_id} [get]
+// @Param			image_id		path	string	true	"Image ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetImageByID(svc service.ImageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(imageMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Upload image
+// @Description	Upload an image file. Supported types are JPEG, PNG, GIF and WebP.
+// @Id				upload-image
+// @Tags			Image
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		201	{object}	entities.ImageResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		413	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/image [post]
+// @Param			file			formData	file	true	"Image file"
+// @Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func UploadImage(svc service.ImageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "file is required")
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		defer file.Close()
+
+		domainData, err := svc.Upload(ctx, &domain.ImageUpload{
+			Filename: fileHeader.Filename,
+			Size:     fileHeader.Size,
+			File:     file,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(imageMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete image
+// @Description	Delete image, its links to trees and flowerbeds and the stored file
+// @Id				delete-image
+// @Tags			Image
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/image/{image_id} [delete]
+// @Param			image_id		path	string	true	"Image ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteImage(svc service.ImageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Delete(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Get image file
+// @Description	Get the content of a stored image file
+// @Id				get-image-file
+// @Tags			Image
+// @Produce		image/jpeg,image/png,image/gif,image/webp
+// @Success		200
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/image/file/{key} [get]
+// @Param			key				path	string	true	"Object key of the image"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetImageFile(svc service.ImageService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		img, body, err := svc.Download(ctx, c.Params("key"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		if img.MimeType != nil {
+			c.Set(fiber.HeaderContentType, *img.MimeType)
+		}
+		// object keys are never reused, so the content of a key never changes
+		c.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+
+		return c.SendStream(body)
+	}
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
+}
@@ -0,0 +1,22 @@
+package image
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.ImageService) *fiber.App {
+	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
+
+	app.Get("/", GetAllImages(svc))
+	// the original files contain the EXIF data of the photos including the GPS position
+	app.Get("/file/:key", GetImageFile(svc))
+	app.Get("/:id", GetImageByID(svc))
+	app.Post("/", canEdit, UploadImage(svc))
+	app.Delete("/:id", canEdit, DeleteImage(svc))
+
+	return app
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+)
+
+// MaxPage and MaxLimit keep the offset of a page within int32
+const (
+	DefaultLimit int32 = 25
+	MaxLimit     int32 = 1000
+	MaxPage      int32 = 1_000_000
+)
+
+// ParseQuery reads the query params page, limit, sort and order of a list request.
+// Missing params fall back to the first page with the default limit sorted by id.
+func ParseQuery(c *fiber.Ctx) (domain.Query, error) {
+	query := domain.Query{
+		Page:          1,
+		Limit:         DefaultLimit,
+		SortBy:        c.Query("sort"),
+		SortDirection: domain.SortDirection(c.Query("order", string(domain.SortAsc))),
+	}
+
+	if page := c.Query("page"); page != "" {
+		p, err := strconv.ParseInt(page, 10, 32)
+		if err != nil || p < 1 || p > int64(MaxPage) {
+			return domain.Query{}, fiber.NewError(fiber.StatusBadRequest, "invalid page, must be between 1 and "+strconv.Itoa(int(MaxPage)))
+		}
+		query.Page = int32(p)
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		l, err := strconv.ParseInt(limit, 10, 32)
+		if err != nil || l < 1 || l > int64(MaxLimit) {
+			return domain.Query{}, fiber.NewError(fiber.StatusBadRequest, "invalid limit, must be between 1 and "+strconv.Itoa(int(MaxLimit)))
+		}
+		query.Limit = int32(l)
+	}
+
+	if query.SortDirection != domain.SortAsc && query.SortDirection != domain.SortDesc {
+		return domain.Query{}, fiber.NewError(fiber.StatusBadRequest, "invalid order, must be asc or desc")
+	}
+
+	return query, nil
+}
+
+// Create returns the pagination of the requested page of a list with total entries
+func Create(query domain.Query, total int64) entities.Pagination {
+	pagination := entities.Pagination{
+		Total:       int32(total),
+		CurrentPage: max(query.Page, 1),
+		TotalPages:  1,
+	}
+
+	if query.Limit > 0 {
+		pagination.TotalPages = int32((total + int64(query.Limit) - 1) / int64(query.Limit))
+	}
+
+	if pagination.CurrentPage < pagination.TotalPages {
+		next := pagination.CurrentPage + 1
+		pagination.NextPage = &next
+	}
+
+	if pagination.CurrentPage > 1 {
+		prev := min(pagination.CurrentPage-1, max(pagination.TotalPages, 1))
+		pagination.PrevPage = &prev
+	}
+
+	return pagination
+}
+
+// Slice returns the requested page of a list which is loaded at once, e.g. the images of a tree
+func Slice[T any](items []T, query domain.Query) []T {
+	start := min(int64(max(query.Page, 1)-1)*int64(query.Limit), int64(len(items)))
+	end := min(start+int64(query.Limit), int64(len(items)))
+
+	return items[start:end]
+}
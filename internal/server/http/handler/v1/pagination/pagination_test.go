@@ -0,0 +1,121 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	t.Run("should link previous and next page", func(t *testing.T) {
+		// when
+		result := Create(domain.Query{Page: 2, Limit: 10}, 35)
+
+		// then
+		assert.Equal(t, int32(35), result.Total)
+		assert.Equal(t, int32(2), result.CurrentPage)
+		assert.Equal(t, int32(4), result.TotalPages)
+		assert.Equal(t, int32(3), *result.NextPage)
+		assert.Equal(t, int32(1), *result.PrevPage)
+	})
+
+	t.Run("should have no next page on last page", func(t *testing.T) {
+		// when
+		result := Create(domain.Query{Page: 4, Limit: 10}, 35)
+
+		// then
+		assert.Nil(t, result.NextPage)
+		assert.Equal(t, int32(3), *result.PrevPage)
+	})
+
+	t.Run("should have a single page without limit", func(t *testing.T) {
+		// when
+		result := Create(domain.Query{}, 35)
+
+		// then
+		assert.Equal(t, int32(1), result.CurrentPage)
+		assert.Equal(t, int32(1), result.TotalPages)
+		assert.Nil(t, result.NextPage)
+		assert.Nil(t, result.PrevPage)
+	})
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected domain.Query
+		wantErr  bool
+	}{
+		{name: "should use defaults", url: "/", expected: domain.Query{Page: 1, Limit: DefaultLimit, SortDirection: domain.SortAsc}},
+		{name: "should read page, limit and sort", url: "/?page=3&limit=50&sort=name&order=desc", expected: domain.Query{Page: 3, Limit: 50, SortBy: "name", SortDirection: domain.SortDesc}},
+		{name: "should reject page below 1", url: "/?page=0", wantErr: true},
+		{name: "should reject page above maximum", url: "/?page=1000001", wantErr: true},
+		{name: "should reject page exceeding int32", url: "/?page=4294967297", wantErr: true},
+		{name: "should reject limit above maximum", url: "/?limit=1001", wantErr: true},
+		{name: "should reject unknown order", url: "/?order=up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// given
+			var query domain.Query
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				var err error
+				query, err = ParseQuery(c)
+				return err
+			})
+
+			// when
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.url, nil))
+
+			// then
+			assert.NoError(t, err)
+			if tt.wantErr {
+				assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+				return
+			}
+			assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+			assert.Equal(t, tt.expected, query)
+		})
+	}
+}
+
+func TestMaxOffset(t *testing.T) {
+	t.Run("should fit the offset of the last page into int32", func(t *testing.T) {
+		// when
+		offset := domain.Query{Page: MaxPage, Limit: MaxLimit}.Offset()
+
+		// then
+		assert.Positive(t, offset)
+	})
+}
+
+func TestSlice(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name     string
+		query    domain.Query
+		expected []int
+	}{
+		{"should return first page", domain.Query{Page: 1, Limit: 2}, []int{1, 2}},
+		{"should return last page with remaining items", domain.Query{Page: 3, Limit: 2}, []int{5}},
+		{"should return no items after last page", domain.Query{Page: 4, Limit: 2}, []int{}},
+		{"should return no items for page near max page", domain.Query{Page: MaxPage, Limit: MaxLimit}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// when
+			result := Slice(items, tt.query)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
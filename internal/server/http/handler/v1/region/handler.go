@@ -1,45 +1,61 @@
 package region
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/geojson"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 // @Summary		Get all regions
-// @Description	Get all regions
+// @Description	Get all regions. With format=geojson or the Accept header application/geo+json the regions are returned as GeoJSON feature collection of multi polygons.
 // @Tags			Region
-// @Produce		json
+// @Produce		json,application/geo+json
 // @Success		200		{object}	entities.RegionListResponse
 // @Failure		400		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			page	query		string	false	"Page"
 // @Param			limit	query		string	false	"Limit"
+// @Param			sort	query		string	false	"Sort field (id, name, created_at, updated_at)"
+// @Param			order	query		string	false	"Sort order (asc or desc)"
+// @Param			format	query		string	false	"Response format (json or geojson)"
 // @Router			/v1/region [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 func GetAllRegions(svc service.RegionService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
-		r, err := svc.GetAll(ctx)
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		r, total, err := svc.GetAll(ctx, query)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
-		dto := utils.Map(r, func(region *domain.Region) *entities.RegionResponse {
-			return &entities.RegionResponse{
-				ID:   region.ID,
-				Name: region.Name,
+		if geojson.Requested(c) {
+			features := make([]*entities.GeoJSONFeature, len(r))
+			for i, region := range r {
+				if features[i], err = geojson.NewFeature(region.ID, geojson.MultiPolygon(region.Geometry), mapRegionToDto(region)); err != nil {
+					return err
+				}
 			}
-		})
+			return geojson.Send(c, features, pagination.Create(query, total))
+		}
+
+		dto := utils.Map(r, mapRegionToDto)
 
 		return c.JSON(entities.RegionListResponse{
 			Regions:    dto,
-			Pagination: entities.Pagination{}, // TODO: Handle pagination
+			Pagination: pagination.Create(query, total),
 		})
 	}
 }
@@ -69,6 +85,139 @@ func GetRegionByID(svc service.RegionService) fiber.Handler {
 			return errorhandler.HandleError(err)
 		}
 
-		return c.JSON(r)
+		return c.JSON(mapRegionToDto(r))
+	}
+}
+
+// @Summary		Create region
+// @Description	Create a region. The boundary is given either as GeoJSON polygon or multi polygon in geometry or as WKT in wkt. It must be valid and must not overlap other regions. Tree clusters and flowerbeds inside the boundary are assigned to the region.
+// @Id				create-region
+// @Tags			Region
+// @Produce		json
+// @Success		201	{object}	entities.RegionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region [post]
+// @Param			body			body	entities.RegionCreateRequest	true	"Region Create Request"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func CreateRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req entities.RegionCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		geometry, err := parseGeometry(req.Geometry, req.WKT)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		r, err := svc.Create(c.Context(), &domain.RegionCreate{
+			Name:     req.Name,
+			Geometry: geometry,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(mapRegionToDto(r))
+	}
+}
+
+// @Summary		Update region
+// @Description	Update a region. Tree clusters and flowerbeds are assigned to the region containing them after the boundary changed.
+// @Id				update-region
+// @Tags			Region
+// @Produce		json
+// @Success		200	{object}	entities.RegionResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region/{id} [put]
+// @Param			id				path	string							true	"Region ID"
+// @Param			body			body	entities.RegionUpdateRequest	true	"Region Update Request"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func UpdateRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		var req entities.RegionUpdateRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		geometry, err := parseGeometry(req.Geometry, req.WKT)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		//nolint: gosec
+		r, err := svc.Update(c.Context(), int32(id), &domain.RegionUpdate{
+			Name:     req.Name,
+			Geometry: geometry,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapRegionToDto(r))
+	}
+}
+
+// @Summary		Delete region
+// @Description	Delete a region. Its tree clusters and flowerbeds are kept without region.
+// @Id				delete-region
+// @Tags			Region
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/region/{id} [delete]
+// @Param			id				path	string	true	"Region ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteRegion(svc service.RegionService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		//nolint: gosec
+		if err := svc.Delete(c.Context(), int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// parseGeometry returns the boundary of a request, given either as GeoJSON or as WKT
+func parseGeometry(geometry *entities.GeoJSONGeometry, wkt string) (domain.MultiPolygon, error) {
+	switch {
+	case geometry != nil && wkt != "":
+		return nil, errors.New("either geometry or wkt must be set, not both")
+	case geometry != nil:
+		return geojson.ToMultiPolygon(geometry)
+	case wkt != "":
+		return domain.ParseMultiPolygonWKT(wkt)
+	default:
+		return nil, errors.New("geometry or wkt is required")
+	}
+}
+
+func mapRegionToDto(region *domain.Region) *entities.RegionResponse {
+	return &entities.RegionResponse{
+		ID:       region.ID,
+		Name:     region.Name,
+		Geometry: geojson.MultiPolygon(region.Geometry),
 	}
 }
@@ -2,14 +2,20 @@ package region
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 func RegisterRoutes(svc service.RegionService) *fiber.App {
 	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
 
 	app.Get("/", GetAllRegions(svc))
 	app.Get("/:id", GetRegionByID(svc))
+	app.Post("/", canEdit, CreateRegion(svc))
+	app.Put("/:id", canEdit, UpdateRegion(svc))
+	app.Delete("/:id", canEdit, DeleteRegion(svc))
 
 	return app
 }
@@ -2,40 +2,107 @@ package role
 
 import (
 	"github.com/gofiber/fiber/v2"
-	_ "github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/user"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 // @Summary		Get all user roles
-// @Description	Get all user roles
+// @Description	Get all realm roles which can be assigned to users
 // @Tags			Role
 // @Produce		json
-// @Success		200		{object}	entities.RoleListResponse
-// @Failure		400		{object}	HTTPError
-// @Failure		500		{object}	HTTPError
-// @Param			page	query		string	false	"Page"
-// @Param			limit	query		string	false	"Limit"
+// @Success		200	{object}	entities.RoleListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
 // @Router			/v1/role [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetAllUserRoles(_ service.AuthService) fiber.Handler {
+func GetAllUserRoles(svc service.RoleService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		roles, err := svc.GetAll(ctx)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]entities.RoleResponse, len(roles))
+		for i, r := range roles {
+			data[i] = mapRoleResponse(r)
+		}
+
+		return c.JSON(entities.RoleListResponse{
+			Data:       data,
+			Pagination: pagination.Create(domain.Query{}, int64(len(data))),
+		})
 	}
 }
 
-// @Summary		Get a role by ID
-// @Description	Get a role by ID
+// @Summary		Get a role by name
+// @Description	Get a role by name
 // @Tags			Role
 // @Produce		json
-// @Success		200	{object}	entities.RoleResponse
-// @Failure		400	{object}	HTTPError
-// @Failure		404	{object}	HTTPError
-// @Failure		500	{object}	HTTPError
-// @Param			id	path		string	true	"Role ID"
-// @Router			/v1/role/{id} [get]
+// @Success		200			{object}	entities.RoleResponse
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Param			role_name	path		string	true	"Role name"
+// @Router			/v1/role/{role_name} [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetRoleByID(_ service.AuthService) fiber.Handler {
+func GetRoleByName(svc service.RoleService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		role, err := svc.GetByName(ctx, c.Params("role_name"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapRoleResponse(role))
+	}
+}
+
+// @Summary		Get users of a role
+// @Description	Get all users holding a role
+// @Tags			Role
+// @Produce		json
+// @Success		200			{object}	entities.UserListResponse
+// @Failure		400			{object}	HTTPError
+// @Failure		401			{object}	HTTPError
+// @Failure		403			{object}	HTTPError
+// @Failure		404			{object}	HTTPError
+// @Failure		500			{object}	HTTPError
+// @Param			role_name	path		string	true	"Role name"
+// @Router			/v1/role/{role_name}/users [get]
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetRoleUsers(svc service.RoleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		users, err := svc.GetUsers(ctx, c.Params("role_name"))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]entities.UserResponse, len(users))
+		for i, u := range users {
+			data[i] = user.MapUserResponse(u)
+		}
+
+		return c.JSON(entities.UserListResponse{
+			Data:       data,
+			Pagination: pagination.Create(domain.Query{}, int64(len(data))),
+		})
+	}
+}
+
+func mapRoleResponse(r *domain.Role) entities.RoleResponse {
+	return entities.RoleResponse{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
 	}
 }
@@ -5,11 +5,12 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
-func RegisterRoutes(svc service.AuthService) *fiber.App {
+func RegisterRoutes(svc service.RoleService) *fiber.App {
 	app := fiber.New()
 
-	app.Post("/", GetAllUserRoles(svc))
-	app.Get("/:id", GetRoleByID(svc))
+	app.Get("/", GetAllUserRoles(svc))
+	app.Get("/:role_name", GetRoleByName(svc))
+	app.Get("/:role_name/users", GetRoleUsers(svc))
 
 	return app
 }
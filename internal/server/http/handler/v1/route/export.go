@@ -0,0 +1,128 @@
+package route
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/geojson"
+)
+
+type gpx struct {
+	XMLName xml.Name `xml:"gpx"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Version string   `xml:"version,attr"`
+	Creator string   `xml:"creator,attr"`
+	Route   gpxRoute `xml:"rte"`
+}
+
+type gpxRoute struct {
+	Name   string     `xml:"name"`
+	Points []gpxPoint `xml:"rtept"`
+}
+
+type gpxPoint struct {
+	Lat         float64 `xml:"lat,attr"`
+	Lon         float64 `xml:"lon,attr"`
+	Name        string  `xml:"name,omitempty"`
+	Description string  `xml:"desc,omitempty"`
+	Type        string  `xml:"type"`
+}
+
+// sendRoute writes the route in the format requested by the query param format
+func sendRoute(c *fiber.Ctx, route *entities.RouteResponse) error {
+	switch c.Query("format", "json") {
+	case "json":
+		return c.JSON(route)
+	case "gpx":
+		data, err := toGPX(route)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, "application/gpx+xml")
+		c.Attachment("route.gpx")
+		return c.Send(data)
+	case "geojson":
+		c.Attachment("route.geojson")
+		return c.JSON(toGeoJSON(route), geojson.MimeType)
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "invalid format, must be json, gpx or geojson")
+	}
+}
+
+// toGPX returns the route as GPX route with a route point for every stop
+func toGPX(route *entities.RouteResponse) ([]byte, error) {
+	doc := gpx{
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Version: "1.1",
+		Creator: "green-ecolution",
+		Route: gpxRoute{
+			Name:   routeName(route),
+			Points: make([]gpxPoint, len(route.Stops)),
+		},
+	}
+
+	for i, stop := range route.Stops {
+		doc.Route.Points[i] = gpxPoint{
+			Lat:         stop.Latitude,
+			Lon:         stop.Longitude,
+			Name:        stop.Name,
+			Description: fmt.Sprintf("%.0f l", stop.WaterRequired),
+			Type:        string(stop.Type),
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// toGeoJSON returns the route as feature collection with the driven line and a point for every stop
+func toGeoJSON(route *entities.RouteResponse) *entities.GeoJSONFeatureCollection {
+	line := make([][]float64, len(route.Stops))
+	features := make([]*entities.GeoJSONFeature, 0, len(route.Stops)+1)
+	features = append(features, &entities.GeoJSONFeature{
+		Type:     entities.GeoJSONTypeFeature,
+		Geometry: &entities.GeoJSONGeometry{Type: entities.GeoJSONTypeLineString, Coordinates: line},
+		Properties: map[string]any{
+			"name":                 routeName(route),
+			"distance":             route.Distance,
+			"duration":             route.Duration,
+			"total_water_required": route.TotalWaterRequired,
+			"refills":              route.Refills,
+		},
+	})
+
+	for i, stop := range route.Stops {
+		line[i] = []float64{stop.Longitude, stop.Latitude}
+		features = append(features, &entities.GeoJSONFeature{
+			Type:     entities.GeoJSONTypeFeature,
+			Geometry: &entities.GeoJSONGeometry{Type: entities.GeoJSONTypePoint, Coordinates: line[i]},
+			Properties: map[string]any{
+				"position":        i,
+				"type":            stop.Type,
+				"name":            stop.Name,
+				"tree_cluster_id": stop.TreeClusterID,
+				"water_required":  stop.WaterRequired,
+				"distance":        stop.Distance,
+			},
+		})
+	}
+
+	return &entities.GeoJSONFeatureCollection{
+		Type:     entities.GeoJSONTypeFeatureCollection,
+		Features: features,
+	}
+}
+
+func routeName(route *entities.RouteResponse) string {
+	if route.Vehicle == nil {
+		return "Watering tour"
+	}
+
+	return "Watering tour " + route.Vehicle.NumberPlate
+}
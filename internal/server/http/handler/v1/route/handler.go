@@ -0,0 +1,113 @@
+package route
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	vehicleMapper = generated.VehicleHTTPMapperImpl{}
+)
+
+// @Summary		Calculate route
+// @Description	Calculate a watering tour visiting the tree clusters with the vehicle. The tour starts and ends at the depot, refill stops are inserted when the tank runs empty. The route can be exported as GPX or GeoJSON.
+// @Id				calculate-route
+// @Tags			Route
+// @Produce		json,application/gpx+xml,application/geo+json
+// @Success		200	{object}	entities.RouteResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/route [post]
+// @Param			format			query	string					false	"Response format (json, gpx, geojson)"
+// @Param			body			body	entities.RouteRequest	true	"Vehicle and tree clusters of the tour"
+// @Param			Authorization	header	string					true	"Insert your access token"	default(Bearer <Add access token here>)
+func CalculateRoute(svc service.RouteService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.RouteRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainData, err := svc.Calculate(ctx, &domain.RouteCreate{
+			VehicleID:      req.VehicleID,
+			TreeClusterIDs: req.TreeClusterIDs,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return sendRoute(c, mapRouteResponse(domainData))
+	}
+}
+
+// @Summary		Get route of watering plan
+// @Description	Calculate the watering tour for the vehicle and tree clusters of a watering plan. The route can be exported as GPX or GeoJSON.
+// @Id				get-watering-plan-route
+// @Tags			Route
+// @Produce		json,application/gpx+xml,application/geo+json
+// @Success		200	{object}	entities.RouteResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/route/watering-plan/{id} [get]
+// @Param			id				path	string	true	"Watering plan ID"
+// @Param			format			query	string	false	"Response format (json, gpx, geojson)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetWateringPlanRoute(svc service.RouteService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid id")
+		}
+
+		domainData, err := svc.CalculateForWateringPlan(ctx, int32(id))
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return sendRoute(c, mapRouteResponse(domainData))
+	}
+}
+
+func mapRouteResponse(route *domain.Route) *entities.RouteResponse {
+	stops := make([]*entities.RouteStopResponse, len(route.Stops))
+	for i, stop := range route.Stops {
+		stops[i] = &entities.RouteStopResponse{
+			Type:          entities.RouteStopType(stop.Type),
+			Latitude:      stop.Latitude,
+			Longitude:     stop.Longitude,
+			WaterRequired: stop.WaterRequired,
+			Distance:      stop.Distance,
+		}
+
+		if stop.TreeCluster != nil {
+			stops[i].TreeClusterID = &stop.TreeCluster.ID
+			stops[i].Name = stop.TreeCluster.Name
+		} else if stop.WaterPoint != nil {
+			stops[i].Name = stop.WaterPoint.Name
+		}
+	}
+
+	return &entities.RouteResponse{
+		Vehicle:            vehicleMapper.FromResponse(route.Vehicle),
+		Stops:              stops,
+		Distance:           route.Distance,
+		Duration:           int64(route.Duration.Seconds()),
+		TotalWaterRequired: route.TotalWaterRequired,
+		Refills:            route.Refills,
+	}
+}
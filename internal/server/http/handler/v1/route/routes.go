@@ -0,0 +1,15 @@
+package route
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.RouteService) *fiber.App {
+	app := fiber.New()
+
+	app.Post("/", CalculateRoute(svc))
+	app.Get("/watering-plan/:id", GetWateringPlanRoute(svc))
+
+	return app
+}
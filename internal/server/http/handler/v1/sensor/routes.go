@@ -2,19 +2,22 @@ package sensor
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 func RegisterRoutes(svc service.Service) *fiber.App {
 	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
 
 	app.Get("/", GetAllSensor(svc))
 	app.Get("/:id", GetSensorByID(svc))
 	app.Get("/:id/data", GetSensorDataByID(svc))
 
-	app.Post("/", CreateSensor(svc))
-	app.Put("/:id", UpdateSensor(svc))
-	app.Delete("/:id", DeleteSensor(svc))
+	app.Post("/", canEdit, CreateSensor(svc))
+	app.Put("/:id", canEdit, UpdateSensor(svc))
+	app.Delete("/:id", canEdit, DeleteSensor(svc))
 
 	return app
 }
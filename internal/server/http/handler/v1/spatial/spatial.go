@@ -0,0 +1,68 @@
+package spatial
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// ParseFilter reads the spatial query params of a list request:
+//
+//	bbox=min_lng,min_lat,max_lng,max_lat  entities inside the bounding box
+//	lat=..&lng=..&radius=..               entities within radius meters around the point
+//	polygon=POLYGON((...))                entities intersecting the polygon in WKT
+//
+// Missing params are not applied. The ranges of the values are validated by the services.
+func ParseFilter(c *fiber.Ctx) (domain.SpatialFilter, error) {
+	var filter domain.SpatialFilter
+
+	if bbox := c.Query("bbox"); bbox != "" {
+		values, err := parseFloats(bbox)
+		if err != nil || len(values) != 4 {
+			return domain.SpatialFilter{}, fiber.NewError(fiber.StatusBadRequest, "invalid bbox, must be min_lng,min_lat,max_lng,max_lat")
+		}
+		filter.BoundingBox = &domain.BoundingBox{
+			Min: domain.Coordinate{Latitude: values[1], Longitude: values[0]},
+			Max: domain.Coordinate{Latitude: values[3], Longitude: values[2]},
+		}
+	}
+
+	if radius := c.Query("radius"); radius != "" {
+		values, err := parseFloats(c.Query("lat"), c.Query("lng"), radius)
+		if err != nil {
+			return domain.SpatialFilter{}, fiber.NewError(fiber.StatusBadRequest, "invalid radius, lat, lng and radius in meters are required")
+		}
+		filter.Radius = &domain.Radius{
+			Center: domain.Coordinate{Latitude: values[0], Longitude: values[1]},
+			Meters: values[2],
+		}
+	}
+
+	if polygon := c.Query("polygon"); polygon != "" {
+		area, err := domain.ParseMultiPolygonWKT(polygon)
+		if err != nil {
+			return domain.SpatialFilter{}, fiber.NewError(fiber.StatusBadRequest, "invalid polygon: "+err.Error())
+		}
+		filter.Area = area
+	}
+
+	return filter, nil
+}
+
+// parseFloats parses the values, a single value may contain a comma separated list
+func parseFloats(values ...string) ([]float64, error) {
+	var result []float64
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, f)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package spatial
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(t *testing.T, query string) (domain.SpatialFilter, error) {
+	t.Helper()
+
+	var filter domain.SpatialFilter
+	var parseErr error
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		filter, parseErr = ParseFilter(c)
+		return nil
+	})
+
+	_, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/?"+query, nil))
+	assert.NoError(t, err)
+
+	return filter, parseErr
+}
+
+func TestParseFilter(t *testing.T) {
+	t.Run("should apply no filter without params", func(t *testing.T) {
+		// when
+		filter, err := parse(t, "page=1")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SpatialFilter{}, filter)
+	})
+
+	t.Run("should parse bounding box", func(t *testing.T) {
+		// when
+		filter, err := parse(t, "bbox=9.40,54.78,9.45,54.80")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.BoundingBox{
+			Min: domain.Coordinate{Latitude: 54.78, Longitude: 9.40},
+			Max: domain.Coordinate{Latitude: 54.80, Longitude: 9.45},
+		}, filter.BoundingBox)
+	})
+
+	t.Run("should parse radius around point", func(t *testing.T) {
+		// when
+		filter, err := parse(t, "lat=54.79&lng=9.43&radius=250")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Radius{Center: domain.Coordinate{Latitude: 54.79, Longitude: 9.43}, Meters: 250}, filter.Radius)
+	})
+
+	t.Run("should parse polygon as wkt", func(t *testing.T) {
+		// when
+		filter, err := parse(t, "polygon="+url.QueryEscape("POLYGON((9.40 54.78, 9.45 54.78, 9.45 54.80, 9.40 54.78))"))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, domain.MultiPolygon{{{{9.40, 54.78}, {9.45, 54.78}, {9.45, 54.80}, {9.40, 54.78}}}}, filter.Area)
+	})
+
+	t.Run("should return error for incomplete params", func(t *testing.T) {
+		for _, query := range []string{"bbox=9.40,54.78,9.45", "radius=250&lat=54.79", "bbox=a,b,c,d", "polygon=" + url.QueryEscape("POINT(1 2)")} {
+			// when
+			_, err := parse(t, query)
+
+			// then
+			assert.Error(t, err, query)
+		}
+	})
+}
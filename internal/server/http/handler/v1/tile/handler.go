@@ -0,0 +1,87 @@
+package tile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+const (
+	mimeType = "application/vnd.mapbox-vector-tile"
+	// watering status and other attributes change during the day, so clients revalidate
+	// tiles after a minute using the ETag
+	cacheControl = "private, max-age=60"
+)
+
+// @Summary		Get vector tile
+// @Description	Get a Mapbox vector tile of a map layer in the Web Mercator tile grid (XYZ scheme). Features carry attributes like species or watering status. Tiles without features are answered with 204.
+// @Id				get-tile
+// @Tags			Tile
+// @Produce		application/vnd.mapbox-vector-tile
+// @Success		200
+// @Success		204
+// @Success		304
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tiles/{layer}/{z}/{x}/{y}.mvt [get]
+// @Param			layer				path	string	true	"Layer (trees, clusters, flowerbeds or regions)"
+// @Param			z					path	int		true	"Zoom level"
+// @Param			x					path	int		true	"Tile column"
+// @Param			y					path	int		true	"Tile row"
+// @Param			exclude_archived	query	bool	false	"Exclude archived tree clusters, their trees and archived flowerbeds"
+// @Param			Authorization		header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetTile(svc service.TileService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		query, err := parseTileQuery(c)
+		if err != nil {
+			return err
+		}
+
+		tile, err := svc.GetTile(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		c.Set(fiber.HeaderCacheControl, cacheControl)
+		if len(tile) == 0 {
+			return c.SendStatus(fiber.StatusNoContent)
+		}
+
+		hash := sha256.Sum256(tile)
+		c.Set(fiber.HeaderETag, `"`+hex.EncodeToString(hash[:16])+`"`)
+		if c.Fresh() {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		c.Set(fiber.HeaderContentType, mimeType)
+		return c.Send(tile)
+	}
+}
+
+func parseTileQuery(c *fiber.Ctx) (*domain.TileQuery, error) {
+	coords := make([]int32, 3)
+	for i, param := range []string{"z", "x", "y"} {
+		v, err := strconv.ParseInt(c.Params(param), 10, 32)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+		}
+		coords[i] = int32(v)
+	}
+
+	return &domain.TileQuery{
+		Layer:           domain.TileLayer(c.Params("layer")),
+		Z:               coords[0],
+		X:               coords[1],
+		Y:               coords[2],
+		ExcludeArchived: c.QueryBool("exclude_archived"),
+	}, nil
+}
@@ -0,0 +1,14 @@
+package tile
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.TileService) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/:layer/:z/:x/:y.mvt", GetTile(svc))
+
+	return app
+}
@@ -8,19 +8,26 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/geojson"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
+const defaultNearbyLimit = 10
+
 var (
 	treeMapper   = generated.TreeHTTPMapperImpl{}
 	sensorMapper = generated.SensorHTTPMapperImpl{}
+	imageMapper  = generated.ImageHTTPMapperImpl{}
 )
 
 // @Summary		Get all trees
-// @Description	Get all trees
+// @Description	Get all trees. With format=geojson or the Accept header application/geo+json the trees are returned as GeoJSON feature collection of points.
 // @Id				get-all-trees
 // @Tags			Tree
-// @Produce		json
+// @Produce		json,application/geo+json
 // @Success		200	{object}	entities.TreeListResponse
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
@@ -30,17 +37,42 @@ var (
 // @Router			/v1/tree [get]
 // @Param			page			query	string	false	"Page"
 // @Param			limit			query	string	false	"Limit"
-// @Param			age				query	string	false	"Age"
+// @Param			sort			query	string	false	"Sort field (id, number, species, planting_year, age, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			species			query	string	false	"Species"
+// @Param			min_age			query	string	false	"Minimum age"
+// @Param			max_age			query	string	false	"Maximum age"
 // @Param			treecluster_id	query	string	false	"Tree Cluster ID"
+// @Param			bbox			query	string	false	"Bounding box (min_lng,min_lat,max_lng,max_lat)"
+// @Param			lat				query	number	false	"Latitude of the center of radius"
+// @Param			lng				query	number	false	"Longitude of the center of radius"
+// @Param			radius			query	number	false	"Radius in meters around lat and lng"
+// @Param			polygon			query	string	false	"Polygon or multi polygon as WKT which the results intersect"
+// @Param			format			query	string	false	"Response format (json or geojson)"
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 func GetAllTrees(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
-		domainData, err := svc.GetAll(ctx)
+		query, err := parseTreeQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
+		if geojson.Requested(c) {
+			features := make([]*entities.GeoJSONFeature, len(domainData))
+			for i, t := range domainData {
+				if features[i], err = geojson.NewFeature(t.ID, geojson.Point(t.Latitude, t.Longitude), mapTreeToDto(t)); err != nil {
+					return err
+				}
+			}
+			return geojson.Send(c, features, pagination.Create(query.Query, total))
+		}
+
 		data := make([]*entities.TreeResponse, len(domainData))
 		for i, domain := range domainData {
 			data[i] = mapTreeToDto(domain)
@@ -48,11 +80,63 @@ func GetAllTrees(svc service.TreeService) fiber.Handler {
 
 		return c.JSON(entities.TreeListResponse{
 			Data:       data,
-			Pagination: entities.Pagination{}, // TODO: Handle pagination
+			Pagination: pagination.Create(query.Query, total),
 		})
 	}
 }
 
+// @Summary		Get nearby trees
+// @Description	Get the trees closest to a point ordered by their distance in meters, e.g. the trees around the position of a field worker.
+// @Id				get-nearby-trees
+// @Tags			Tree
+// @Produce		json
+// @Success		200	{object}	entities.NearbyTreeListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree/nearby [get]
+// @Param			lat				query	number	true	"Latitude"
+// @Param			lng				query	number	true	"Longitude"
+// @Param			limit			query	string	false	"Number of trees (1 to 100, default 10)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetNearbyTrees(svc service.TreeService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		lat, err := strconv.ParseFloat(c.Query("lat"), 64)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid lat")
+		}
+		lng, err := strconv.ParseFloat(c.Query("lng"), 64)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid lng")
+		}
+		limit, err := parseOptionalInt(c, "limit")
+		if err != nil {
+			return err
+		}
+
+		query := &domain.NearbyTreeQuery{Latitude: lat, Longitude: lng, Limit: defaultNearbyLimit}
+		if limit != nil {
+			query.Limit = *limit
+		}
+
+		nearby, err := svc.GetNearby(c.Context(), query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]*entities.NearbyTreeResponse, len(nearby))
+		for i, n := range nearby {
+			data[i] = &entities.NearbyTreeResponse{
+				Tree:     mapTreeToDto(n.Tree),
+				Distance: n.Distance,
+			}
+		}
+
+		return c.JSON(entities.NearbyTreeListResponse{Data: data})
+	}
+}
+
 // @Summary		Get tree by ID
 // @Description	Get tree by ID
 // @Id				get-trees
@@ -91,7 +175,7 @@ func GetTreeByID(svc service.TreeService) fiber.Handler {
 // @Id				create-tree
 // @Tags			Tree
 // @Produce		json
-// @Success		200	{object}	entities.TreeResponse
+// @Success		201	{object}	entities.TreeResponse
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -100,10 +184,23 @@ func GetTreeByID(svc service.TreeService) fiber.Handler {
 // @Router			/v1/tree [post]
 // @Param			Authorization	header	string						true	"Insert your access token"	default(Bearer <Add access token here>)
 // @Param			body			body	entities.TreeCreateRequest	true	"Tree to create"
-func CreateTree(_ service.TreeService) fiber.Handler {
+func CreateTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+
+		var req entities.TreeCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := treeMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := mapTreeToDto(domainData)
+		return c.Status(fiber.StatusCreated).JSON(data)
 	}
 }
 
@@ -122,10 +219,27 @@ func CreateTree(_ service.TreeService) fiber.Handler {
 // @Param			Authorization	header	string						true	"Insert your access token"	default(Bearer <Add access token here>)
 // @Param			tree_id			path	string						false	"Tree ID"
 // @Param			body			body	entities.TreeUpdateRequest	true	"Tree to update"
-func UpdateTree(_ service.TreeService) fiber.Handler {
+func UpdateTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid tree id")
+		}
+
+		var req entities.TreeUpdateRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := treeMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, int32(id), domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := mapTreeToDto(domainData)
+		return c.JSON(data)
 	}
 }
 
@@ -134,18 +248,28 @@ func UpdateTree(_ service.TreeService) fiber.Handler {
 // @Id				delete-tree
 // @Tags			Tree
 // @Produce		json
-// @Success		200
+// @Success		204
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
 // @Failure		404	{object}	HTTPError
 // @Failure		500	{object}	HTTPError
-// @Router			/v1/tree [delete]
+// @Router			/v1/tree/{tree_id} [delete]
+// @Param			tree_id			path	string	true	"Tree ID"
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func DeleteTree(_ service.TreeService) fiber.Handler {
+func DeleteTree(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := strconv.Atoi(c.Params("id"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid tree id")
+		}
+
+		if err := svc.Delete(ctx, int32(id)); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
 
@@ -220,7 +344,7 @@ func RemoveTreeSensor(_ service.TreeService) fiber.Handler {
 // @Id				get-tree-images
 // @Tags			Tree Images
 // @Produce		json
-// @Success		200	{object}	entities.TreeResponse
+// @Success		200	{object}	entities.ImageListResponse
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
 // @Failure		403	{object}	HTTPError
@@ -231,11 +355,28 @@ func RemoveTreeSensor(_ service.TreeService) fiber.Handler {
 // @Param			page			query	string	false	"Page"
 // @Param			limit			query	string	false	"Limit"
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetTreeImages(_ service.TreeService) fiber.Handler {
-	// TODO: Change @Success to return image.ImageResponse
+func GetTreeImages(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetImages(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.ImageListResponse{
+			Data:       imageMapper.FromResponseList(pagination.Slice(domainData, query)),
+			Pagination: pagination.Create(query, int64(len(domainData))),
+		})
 	}
 }
 
@@ -254,10 +395,32 @@ func GetTreeImages(_ service.TreeService) fiber.Handler {
 // @Param			tree_id			path	string							false	"Tree ID"
 // @Param			body			body	entities.TreeAddImagesRequest	true	"Images to add"
 // @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
-func AddTreeImage(_ service.TreeService) fiber.Handler {
+func AddTreeImage(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.TreeAddImagesRequest
+		if err = c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		imageIDs := make([]int32, 0, len(req.ImageIDs))
+		for _, imageID := range req.ImageIDs {
+			if imageID != nil {
+				imageIDs = append(imageIDs, *imageID)
+			}
+		}
+
+		domainData, err := svc.AddImages(ctx, id, imageIDs)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapTreeToDto(domainData))
 	}
 }
 
@@ -276,11 +439,76 @@ func AddTreeImage(_ service.TreeService) fiber.Handler {
 // @Param			tree_id			path	string	false	"Tree ID"
 // @Param			image_id		path	string	false	"Image ID"
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func RemoveTreeImage(_ service.TreeService) fiber.Handler {
+func RemoveTreeImage(svc service.TreeService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// TODO: Implement
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		imageID, err := parseID(c, "image_id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.RemoveImage(ctx, id, imageID)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(mapTreeToDto(domainData))
+	}
+}
+
+func parseTreeQuery(c *fiber.Ctx) (domain.TreeQuery, error) {
+	q, err := pagination.ParseQuery(c)
+	if err != nil {
+		return domain.TreeQuery{}, err
+	}
+
+	query := domain.TreeQuery{Query: q}
+	if species := c.Query("species"); species != "" {
+		query.Species = &species
+	}
+
+	if query.MinAge, err = parseOptionalInt(c, "min_age"); err != nil {
+		return domain.TreeQuery{}, err
+	}
+	if query.MaxAge, err = parseOptionalInt(c, "max_age"); err != nil {
+		return domain.TreeQuery{}, err
+	}
+	if query.TreeClusterID, err = parseOptionalInt(c, "treecluster_id"); err != nil {
+		return domain.TreeQuery{}, err
+	}
+	if query.SpatialFilter, err = spatial.ParseFilter(c); err != nil {
+		return domain.TreeQuery{}, err
 	}
+
+	return query, nil
+}
+
+func parseOptionalInt(c *fiber.Ctx, param string) (*int32, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return utils.P(int32(v)), nil
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
 }
 
 func mapTreeToDto(t *domain.Tree) *entities.TreeResponse {
@@ -289,3 +517,67 @@ func mapTreeToDto(t *domain.Tree) *entities.TreeResponse {
 
 	return dto
 }
+
+// @Summary		Import trees
+// @Description	Import a tree inventory as CSV file or GeoJSON feature collection of points. Trees are matched by their number, existing trees are updated and unknown trees are created. Rows with errors are skipped and listed in the response. With dry_run the file is only checked.
+// @Id				import-trees
+// @Tags			Tree
+// @Accept			multipart/form-data
+// @Produce		json
+// @Success		200	{object}	entities.TreeImportResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		413	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/tree/import [post]
+// @Param			file			formData	file	true	"CSV or GeoJSON file"
+// @Param			format			query		string	false	"File format (csv or geojson), detected from the file extension if not set"
+// @Param			dry_run			query		bool	false	"Only check the file without importing the trees"
+// @Param			Authorization	header		string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func ImportTrees(svc service.TreeService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "file is required")
+		}
+
+		format, err := parseImportFormat(c.Query("format"), fileHeader.Filename)
+		if err != nil {
+			return err
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		defer file.Close()
+
+		domainData, err := svc.Import(ctx, &domain.TreeImport{
+			Format: format,
+			File:   file,
+			DryRun: c.QueryBool("dry_run"),
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(treeMapper.FromImportResponse(domainData))
+	}
+}
+
+func parseImportFormat(format, filename string) (domain.TreeImportFormat, error) {
+	f := domain.TreeImportFormat(format)
+	if f == "" {
+		f = domain.TreeImportFormatFromFilename(filename)
+	}
+
+	switch f {
+	case domain.TreeImportFormatCSV, domain.TreeImportFormatGeoJSON:
+		return f, nil
+	default:
+		return "", fiber.NewError(fiber.StatusBadRequest, "format must be csv or geojson")
+	}
+}
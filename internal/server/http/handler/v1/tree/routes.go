@@ -2,25 +2,30 @@ package tree
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 func RegisterRoutes(svc service.TreeService) *fiber.App {
 	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
 
 	app.Get("/", GetAllTrees(svc))
+	app.Get("/nearby", GetNearbyTrees(svc))
 	app.Get("/:id", GetTreeByID(svc))
-	app.Put("/:id", UpdateTree(svc))
-	app.Post("/", CreateTree(svc))
-	app.Delete("/", DeleteTree(svc))
+	app.Put("/:id", canEdit, UpdateTree(svc))
+	app.Post("/", canEdit, CreateTree(svc))
+	app.Post("/import", canEdit, ImportTrees(svc))
+	app.Delete("/:id", canEdit, DeleteTree(svc))
 
 	app.Get("/:id/images", GetTreeImages(svc))
-	app.Post("/:id/images", AddTreeImage(svc))
-	app.Delete("/:id/images/:image_id", RemoveTreeImage(svc))
+	app.Post("/:id/images", canEdit, AddTreeImage(svc))
+	app.Delete("/:id/images/:image_id", canEdit, RemoveTreeImage(svc))
 
 	app.Get("/:id/sensor", GetTreeSensor(svc))
-	app.Post("/:id/sensor", AddTreeSensor(svc))
-	app.Delete("/:id/sensor/:sensor_id", RemoveTreeSensor(svc))
+	app.Post("/:id/sensor", canEdit, AddTreeSensor(svc))
+	app.Delete("/:id/sensor/:sensor_id", canEdit, RemoveTreeSensor(svc))
 
 	return app
 }
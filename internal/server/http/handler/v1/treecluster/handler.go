@@ -1,7 +1,6 @@
 package treecluster
 
 import (
-	"fmt"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,7 +8,16 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/geojson"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/spatial"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+const (
+	defaultSuggestionDistance = 30
+	defaultSuggestionMinSize  = 3
 )
 
 var (
@@ -18,10 +26,10 @@ var (
 )
 
 // @Summary		Get all tree clusters
-// @Description	Get all tree clusters
+// @Description	Get all tree clusters. With format=geojson or the Accept header application/geo+json the tree clusters are returned as GeoJSON feature collection. The geometry is the hull covered by the trees of a cluster, clusters without hull are points and clusters without location have no geometry.
 // @Id				get-all-tree-clusters
 // @Tags			Tree Cluster
-// @Produce		json
+// @Produce		json,application/geo+json
 // @Success		200	{object}	entities.TreeClusterListResponse
 // @Failure		400	{object}	HTTPError
 // @Failure		401	{object}	HTTPError
@@ -31,18 +39,52 @@ var (
 // @Router			/v1/cluster [get]
 // @Param			page			query	string	false	"Page"
 // @Param			limit			query	string	false	"Limit"
-// @Param			status			query	string	false	"Status"
+// @Param			sort			query	string	false	"Sort field (id, name, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			status			query	string	false	"Watering status (good, moderate, bad, unknown)"
+// @Param			bbox			query	string	false	"Bounding box (min_lng,min_lat,max_lng,max_lat)"
+// @Param			lat				query	number	false	"Latitude of the center of radius"
+// @Param			lng				query	number	false	"Longitude of the center of radius"
+// @Param			radius			query	number	false	"Radius in meters around lat and lng"
+// @Param			polygon			query	string	false	"Polygon or multi polygon as WKT which the results intersect"
+// @Param			format			query	string	false	"Response format (json or geojson)"
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
 func GetAllTreeClusters(svc service.TreeClusterService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
-		domainData, err := svc.GetAll(ctx)
+		q, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		filter, err := spatial.ParseFilter(c)
+		if err != nil {
+			return err
+		}
+
+		query := domain.TreeClusterQuery{Query: q, SpatialFilter: filter}
+		if status := c.Query("status"); status != "" {
+			query.WateringStatus = utils.P(domain.TreeClusterWateringStatus(status))
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
 		if err != nil {
 			return errorhandler.HandleError(err)
 		}
 
-		for i, cluster := range domainData {
-			fmt.Printf("TreeCluster %d: %+v\n", i, *cluster)
+		if geojson.Requested(c) {
+			features := make([]*entities.GeoJSONFeature, len(domainData))
+			for i, tc := range domainData {
+				// the hull shows the covered street section, clusters without hull are drawn at their center
+				geometry := geojson.Polygon(tc.Hull)
+				if geometry == nil && tc.Latitude != nil && tc.Longitude != nil {
+					geometry = geojson.Point(*tc.Latitude, *tc.Longitude)
+				}
+				if features[i], err = geojson.NewFeature(tc.ID, geometry, mapTreeClusterToDto(tc)); err != nil {
+					return err
+				}
+			}
+			return geojson.Send(c, features, pagination.Create(query.Query, total))
 		}
 
 		data := make([]*entities.TreeClusterResponse, len(domainData))
@@ -52,7 +94,7 @@ func GetAllTreeClusters(svc service.TreeClusterService) fiber.Handler {
 
 		return c.JSON(entities.TreeClusterListResponse{
 			Data:       data,
-			Pagination: &entities.Pagination{}, // TODO: Handle pagination
+			Pagination: utils.P(pagination.Create(query.Query, total)),
 		})
 	}
 }
@@ -195,6 +237,116 @@ func DeleteTreeCluster(svc service.TreeClusterService) fiber.Handler {
 	}
 }
 
+// @Summary		Suggest tree clusters
+// @Description	Suggest tree clusters for the trees without tree cluster. Trees are grouped by location with DBSCAN, trees belong to the same suggestion if they are connected by neighbours at most distance meters apart. Nothing is created until the suggestions are accepted.
+// @Id				suggest-tree-clusters
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		200	{object}	entities.TreeClusterSuggestionListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/suggestions [get]
+// @Param			distance		query	number	false	"Maximum distance in meters between neighbouring trees (default 30)"
+// @Param			min_size		query	string	false	"Minimum number of trees of a suggestion (default 3)"
+// @Param			region_id		query	string	false	"Only suggest tree clusters of trees inside the region"
+// @Param			soil_condition	query	string	false	"Soil condition assigned to all suggestions, trees have no soil condition so it does not filter the trees"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func SuggestTreeClusters(svc service.TreeClusterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		query := &domain.TreeClusterSuggestionQuery{
+			Distance:      defaultSuggestionDistance,
+			MinSize:       defaultSuggestionMinSize,
+			SoilCondition: domain.TreeSoilCondition(c.Query("soil_condition")),
+		}
+
+		if distance := c.Query("distance"); distance != "" {
+			d, err := strconv.ParseFloat(distance, 64)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid distance")
+			}
+			query.Distance = d
+		}
+
+		if minSize := c.Query("min_size"); minSize != "" {
+			size, err := strconv.Atoi(minSize)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid min_size")
+			}
+			query.MinSize = int32(size)
+		}
+
+		if regionID := c.Query("region_id"); regionID != "" {
+			id, err := strconv.Atoi(regionID)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid region_id")
+			}
+			query.RegionID = utils.P(int32(id))
+		}
+
+		suggestions, err := svc.Suggest(c.Context(), query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]*entities.TreeClusterSuggestionResponse, len(suggestions))
+		for i, suggestion := range suggestions {
+			data[i] = &entities.TreeClusterSuggestionResponse{
+				Name:          suggestion.Name,
+				Latitude:      suggestion.Latitude,
+				Longitude:     suggestion.Longitude,
+				SoilCondition: entities.TreeSoilCondition(suggestion.SoilCondition),
+				TreeIDs:       suggestion.TreeIDs,
+			}
+			if suggestion.Region != nil {
+				data[i].Region = &entities.RegionResponse{
+					ID:   suggestion.Region.ID,
+					Name: suggestion.Region.Name,
+				}
+			}
+		}
+
+		return c.JSON(entities.TreeClusterSuggestionListResponse{Data: data})
+	}
+}
+
+// @Summary		Accept tree cluster suggestions
+// @Description	Create the tree clusters of accepted suggestions in one transaction, either all of them are created or none.
+// @Id				accept-tree-cluster-suggestions
+// @Tags			Tree Cluster
+// @Produce		json
+// @Success		201	{object}	entities.TreeClusterListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/cluster/suggestions [post]
+// @Param			body			body	entities.TreeClusterAcceptSuggestionsRequest	true	"Accepted suggestions"
+// @Param			Authorization	header	string											true	"Insert your access token"	default(Bearer <Add access token here>)
+func AcceptTreeClusterSuggestions(svc service.TreeClusterService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req entities.TreeClusterAcceptSuggestionsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		tcs := make([]*domain.TreeClusterCreate, len(req.Clusters))
+		for i, tc := range req.Clusters {
+			tcs[i] = treeClusterMapper.FromCreateRequest(tc)
+		}
+
+		clusters, err := svc.AcceptSuggestions(c.Context(), tcs)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(entities.TreeClusterListResponse{
+			Data: utils.Map(clusters, mapTreeClusterToDto),
+		})
+	}
+}
+
 // @Summary		Get all trees in tree cluster
 // @Description	Get all trees in tree cluster
 // @Id				get-all-trees-in-tree-cluster
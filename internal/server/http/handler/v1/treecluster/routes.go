@@ -2,20 +2,25 @@ package treecluster
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 func RegisterRoutes(svc service.TreeClusterService) *fiber.App {
 	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
 
 	app.Get("/", GetAllTreeClusters(svc))
+	app.Get("/suggestions", SuggestTreeClusters(svc))
+	app.Post("/suggestions", canEdit, AcceptTreeClusterSuggestions(svc))
 	app.Get("/:treecluster_id", GetTreeClusterByID(svc))
-	app.Post("/", CreateTreeCluster(svc))
-	app.Put("/:treecluster_id", UpdateTreeCluster(svc))
-	app.Delete("/:treecluster_id", DeleteTreeCluster(svc))
+	app.Post("/", canEdit, CreateTreeCluster(svc))
+	app.Put("/:treecluster_id", canEdit, UpdateTreeCluster(svc))
+	app.Delete("/:treecluster_id", canEdit, DeleteTreeCluster(svc))
 	app.Get("/:treecluster_id/trees", GetTreesInTreeCluster(svc))
-	app.Post("/:treecluster_id/trees", AddTreesToTreeCluster(svc))
-	app.Delete("/:treecluster_id/trees/:tree_id", RemoveTreesFromTreeCluster(svc))
+	app.Post("/:treecluster_id/trees", canEdit, AddTreesToTreeCluster(svc))
+	app.Delete("/:treecluster_id/trees/:tree_id", canEdit, RemoveTreesFromTreeCluster(svc))
 
 	return app
 }
@@ -4,8 +4,11 @@ import (
 	"net/url"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/pkg/errors"
 )
@@ -126,6 +129,8 @@ func RequestToken(svc service.AuthService) fiber.Handler {
 // @Param			user	body		entities.UserRegisterRequest	true	"User information"
 // @Success		201		{object}	entities.UserResponse
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Router			/v1/user [post]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
@@ -141,10 +146,12 @@ func Register(svc service.AuthService) fiber.Handler {
 
 		domainUser := domain.RegisterUser{
 			User: domain.User{
-				Email:     req.Email,
-				FirstName: req.FirstName,
-				LastName:  req.LastName,
-				Username:  req.Username,
+				Email:       req.Email,
+				FirstName:   req.FirstName,
+				LastName:    req.LastName,
+				Username:    req.Username,
+				EmployeeID:  req.EmployeeID,
+				PhoneNumber: req.PhoneNumber,
 			},
 			Password: req.Password,
 			Roles:    req.Roles,
@@ -155,19 +162,7 @@ func Register(svc service.AuthService) fiber.Handler {
 			return err
 		}
 
-		response := entities.UserResponse{
-			ID:            u.ID.String(),
-			CreatedAt:     u.CreatedAt,
-			Email:         u.Email,
-			FirstName:     u.FirstName,
-			LastName:      u.LastName,
-			Username:      u.Username,
-			EmployeeID:    u.EmployeeID,
-			PhoneNumber:   u.PhoneNumber,
-			EmailVerified: u.EmailVerified,
-		}
-
-		return c.Status(fiber.StatusCreated).JSON(response)
+		return c.Status(fiber.StatusCreated).JSON(MapUserResponse(u))
 	}
 }
 
@@ -176,19 +171,46 @@ func parseURL(rawURL string) (*url.URL, error) {
 }
 
 // @Summary		Get all users
-// @Description	Get all users
+// @Description	Get all users, optionally only the users matching a search term
 // @Tags			User
 // @Produce		json
 // @Success		200		{object}	entities.UserListResponse
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			page	query		string	false	"Page"
 // @Param			limit	query		string	false	"Limit"
+// @Param			search	query		string	false	"Search in username, name and email"
 // @Router			/v1/user [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetAllUsers(_ service.AuthService) fiber.Handler {
+func GetAllUsers(svc service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		userQuery := domain.UserQuery{Query: query}
+		if search := c.Query("search"); search != "" {
+			userQuery.Search = &search
+		}
+
+		users, total, err := svc.GetAllUsers(ctx, userQuery)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]entities.UserResponse, len(users))
+		for i, u := range users {
+			data[i] = MapUserResponse(u)
+		}
+
+		return c.JSON(entities.UserListResponse{
+			Data:       data,
+			Pagination: pagination.Create(query, total),
+		})
 	}
 }
 
@@ -198,33 +220,74 @@ func GetAllUsers(_ service.AuthService) fiber.Handler {
 // @Produce		json
 // @Success		200		{object}	entities.UserResponse
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
 // @Failure		404		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			user_id	path		string	true	"User ID"
 // @Router			/v1/user/{user_id} [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetUserByID(_ service.AuthService) fiber.Handler {
+func GetUserByID(svc service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		u, err := svc.GetUserByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(MapUserResponse(u))
 	}
 }
 
 // @Summary		Update a user by ID
-// @Description	Update a user by ID
+// @Description	Update a user by ID. Empty fields are left unchanged, a user is disabled by setting enabled to false.
 // @Tags			User
 // @Accept			json
 // @Produce		json
 // @Success		200		{object}	entities.UserResponse
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
 // @Failure		404		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			user_id	path		string						true	"User ID"
 // @Param			user	body		entities.UserUpdateRequest	true	"User information"
 // @Router			/v1/user/{user_id} [put]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func UpdateUserByID(_ service.AuthService) fiber.Handler {
+func UpdateUserByID(svc service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		req := entities.UserUpdateRequest{}
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, errors.Wrap(err, "failed to parse request").Error())
+		}
+
+		domainReq := domain.UserUpdate{
+			Username:    req.Username,
+			FirstName:   req.FirstName,
+			LastName:    req.LastName,
+			Email:       req.Email,
+			EmployeeID:  req.EmployeeID,
+			PhoneNumber: req.PhoneNumber,
+			Enabled:     req.Enabled,
+		}
+
+		u, err := svc.UpdateUser(ctx, id, &domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(MapUserResponse(u))
 	}
 }
 
@@ -232,33 +295,164 @@ func UpdateUserByID(_ service.AuthService) fiber.Handler {
 // @Description	Delete a user by ID
 // @Tags			User
 // @Produce		json
-// @Success		200		{string}	string
+// @Success		204
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
 // @Failure		404		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			user_id	path		string	true	"User ID"
 // @Router			/v1/user/{user_id} [delete]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func DeleteUserByID(_ service.AuthService) fiber.Handler {
+func DeleteUserByID(svc service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		if err := svc.DeleteUser(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
 
 // @Summary		Get user roles
-// @Description	Get user roles
+// @Description	Get the realm roles of a user
 // @Tags			User
 // @Produce		json
 // @Success		200		{object}	entities.RoleListResponse
 // @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
+// @Failure		404		{object}	HTTPError
 // @Failure		500		{object}	HTTPError
 // @Param			user_id	path		string	true	"User ID"
-// @Param			page	query		string	false	"Page"
-// @Param			limit	query		string	false	"Limit"
 // @Router			/v1/user/{user_id}/roles [get]
 // @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
-func GetUserRoles(_ service.AuthService) fiber.Handler {
+func GetUserRoles(svc service.AuthService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.SendStatus(fiber.StatusNotImplemented)
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		roles, err := svc.GetUserRoles(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]entities.RoleResponse, len(roles))
+		for i, r := range roles {
+			data[i] = entities.RoleResponse{
+				ID:          r.ID,
+				Name:        r.Name,
+				Description: r.Description,
+			}
+		}
+
+		return c.JSON(entities.RoleListResponse{
+			Data:       data,
+			Pagination: pagination.Create(domain.Query{}, int64(len(data))),
+		})
+	}
+}
+
+// @Summary		Assign roles to a user
+// @Description	Assign realm roles to a user, roles the user already has are kept
+// @Tags			User
+// @Accept			json
+// @Success		204
+// @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
+// @Failure		404		{object}	HTTPError
+// @Failure		500		{object}	HTTPError
+// @Param			user_id	path		string						true	"User ID"
+// @Param			body	body		entities.UserRolesRequest	true	"Names of the roles"
+// @Router			/v1/user/{user_id}/roles [post]
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func AddUserRoles(svc service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		req := entities.UserRolesRequest{}
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, errors.Wrap(err, "failed to parse request").Error())
+		}
+
+		if err := svc.AddUserRoles(ctx, id, req.Roles); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
 	}
 }
+
+// @Summary		Remove a role from a user
+// @Description	Remove a realm role from a user
+// @Tags			User
+// @Success		204
+// @Failure		400		{object}	HTTPError
+// @Failure		401		{object}	HTTPError
+// @Failure		403		{object}	HTTPError
+// @Failure		404		{object}	HTTPError
+// @Failure		500		{object}	HTTPError
+// @Param			user_id	path		string	true	"User ID"
+// @Param			role	path		string	true	"Role name"
+// @Router			/v1/user/{user_id}/roles/{role} [delete]
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func RemoveUserRole(svc service.AuthService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseUserID(c)
+		if err != nil {
+			return err
+		}
+
+		if err := svc.RemoveUserRoles(ctx, id, []string{c.Params("role")}); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func parseUserID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "invalid user_id")
+	}
+
+	return id, nil
+}
+
+// MapUserResponse maps a user to the http response, it is shared with the role handlers
+func MapUserResponse(u *domain.User) entities.UserResponse {
+	response := entities.UserResponse{
+		ID:            u.ID.String(),
+		CreatedAt:     u.CreatedAt,
+		Email:         u.Email,
+		FirstName:     u.FirstName,
+		LastName:      u.LastName,
+		Username:      u.Username,
+		EmployeeID:    u.EmployeeID,
+		PhoneNumber:   u.PhoneNumber,
+		EmailVerified: u.EmailVerified,
+		Enabled:       u.Enabled,
+	}
+
+	if u.Avatar != nil {
+		response.Avatar = u.Avatar.String()
+	}
+
+	return response
+}
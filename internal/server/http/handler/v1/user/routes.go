@@ -2,18 +2,24 @@ package user
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 )
 
 func RegisterRoutes(svc service.AuthService) *fiber.App {
 	app := fiber.New()
+	isAdmin := middleware.HasRole(entities.RoleAdministrator)
 
-	app.Post("/", Register(svc))
-	app.Get("/", GetAllUsers(svc))
-	app.Get("/:id", GetUserByID(svc))
-	app.Put("/:id", UpdateUserByID(svc))
-	app.Delete("/:id", DeleteUserByID(svc))
-	app.Get("/:id/roles", GetUserRoles(svc))
+	app.Post("/", isAdmin, Register(svc))
+	app.Get("/", isAdmin, GetAllUsers(svc))
+	app.Get("/:user_id", isAdmin, GetUserByID(svc))
+	app.Put("/:user_id", isAdmin, UpdateUserByID(svc))
+	app.Delete("/:user_id", isAdmin, DeleteUserByID(svc))
+
+	app.Get("/:user_id/roles", isAdmin, GetUserRoles(svc))
+	app.Post("/:user_id/roles", isAdmin, AddUserRoles(svc))
+	app.Delete("/:user_id/roles/:role", isAdmin, RemoveUserRole(svc))
 
 	return app
 }
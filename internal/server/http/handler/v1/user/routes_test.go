@@ -0,0 +1,47 @@
+package user
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	serviceMock "github.com/green-ecolution/green-ecolution-backend/internal/service/_mock"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRoutesTestApp(t *testing.T, roles ...any) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		claims := golangJwt.MapClaims{"realm_access": map[string]any{"roles": roles}}
+		c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+		return c.Next()
+	})
+	app.Mount("/", RegisterRoutes(serviceMock.NewMockAuthService(t)))
+
+	return app
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	readRoutes := []string{
+		"/",
+		"/6f1d8a4e-2b1c-4f7a-9c3e-1a2b3c4d5e6f",
+		"/6f1d8a4e-2b1c-4f7a-9c3e-1a2b3c4d5e6f/roles",
+	}
+
+	for _, route := range readRoutes {
+		t.Run("should forbid reading "+route+" without administrator role", func(t *testing.T) {
+			// given
+			app := newRoutesTestApp(t, "tbz", "driver")
+
+			// when
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, route, nil))
+
+			// then
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+		})
+	}
+}
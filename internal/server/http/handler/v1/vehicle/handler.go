@@ -0,0 +1,362 @@
+package vehicle
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	vehicleMapper = generated.VehicleHTTPMapperImpl{}
+)
+
+// @Summary		Get all vehicles
+// @Description	Get all vehicles, optionally only the vehicles assigned to a user
+// @Id				get-all-vehicles
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.VehicleListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle [get]
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			sort			query	string	false	"Sort field (id, number_plate, water_capacity, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			user_id			query	string	false	"User ID, the vehicles of a user are not paginated"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetAllVehicles(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		if userIDParam := c.Query("user_id"); userIDParam != "" {
+			userID, err := uuid.Parse(userIDParam)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid user_id")
+			}
+
+			domainData, err := svc.GetByUserID(ctx, userID)
+			if err != nil {
+				return errorhandler.HandleError(err)
+			}
+
+			return c.JSON(entities.VehicleListResponse{
+				Data:       vehicleMapper.FromResponseList(domainData),
+				Pagination: pagination.Create(domain.Query{}, int64(len(domainData))),
+			})
+		}
+
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.VehicleListResponse{
+			Data:       vehicleMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(query, total),
+		})
+	}
+}
+
+// @Summary		Get vehicle by ID
+// @Description	Get vehicle by ID
+// @Id				get-vehicle
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.VehicleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id} [get]
+// @Param			vehicle_id		path	string	true	"Vehicle ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetVehicleByID(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(vehicleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Get vehicle by number plate
+// @Description	Get vehicle by number plate
+// @Id				get-vehicle-by-plate
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.VehicleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/plate/{plate} [get]
+// @Param			plate			path	string	true	"Number plate"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetVehicleByPlate(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		plate, err := url.PathUnescape(c.Params("plate"))
+		if err != nil || plate == "" {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid plate")
+		}
+
+		domainData, err := svc.GetByPlate(ctx, plate)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(vehicleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create vehicle
+// @Description	Create vehicle
+// @Id				create-vehicle
+// @Tags			Vehicle
+// @Produce		json
+// @Success		201	{object}	entities.VehicleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle [post]
+// @Param			body			body	entities.VehicleCreateRequest	true	"Vehicle to create"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func CreateVehicle(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.VehicleCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := vehicleMapper.FromCreateRequest(&req)
+		domainData, err := svc.Create(ctx, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(vehicleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Update vehicle
+// @Description	Update vehicle
+// @Id				update-vehicle
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.VehicleResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id} [put]
+// @Param			vehicle_id		path	string							true	"Vehicle ID"
+// @Param			body			body	entities.VehicleUpdateRequest	true	"Vehicle to update"
+// @Param			Authorization	header	string							true	"Insert your access token"	default(Bearer <Add access token here>)
+func UpdateVehicle(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.VehicleUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		domainReq := vehicleMapper.FromUpdateRequest(&req)
+		domainData, err := svc.Update(ctx, id, domainReq)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(vehicleMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete vehicle
+// @Description	Delete vehicle and all of its user assignments
+// @Id				delete-vehicle
+// @Tags			Vehicle
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id} [delete]
+// @Param			vehicle_id		path	string	true	"Vehicle ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteVehicle(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Delete(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Get users assigned to vehicle
+// @Description	Get the ids of all users the vehicle is assigned to
+// @Id				get-vehicle-users
+// @Tags			Vehicle
+// @Produce		json
+// @Success		200	{object}	entities.VehicleUserListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id}/users [get]
+// @Param			vehicle_id		path	string	true	"Vehicle ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetVehicleUsers(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		userIDs, err := svc.GetAssignedUserIDs(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		data := make([]string, len(userIDs))
+		for i, userID := range userIDs {
+			data[i] = userID.String()
+		}
+
+		return c.JSON(entities.VehicleUserListResponse{
+			Data: data,
+		})
+	}
+}
+
+// @Summary		Assign vehicle to user
+// @Description	Assign vehicle to user
+// @Id				assign-vehicle-to-user
+// @Tags			Vehicle
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id}/users [post]
+// @Param			vehicle_id		path	string								true	"Vehicle ID"
+// @Param			body			body	entities.VehicleUserAssignRequest	true	"User to assign"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func AssignVehicleToUser(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.VehicleUserAssignRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid user_id")
+		}
+
+		if err := svc.AssignToUser(ctx, id, userID); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// @Summary		Unassign vehicle from user
+// @Description	Unassign vehicle from user
+// @Id				unassign-vehicle-from-user
+// @Tags			Vehicle
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/vehicle/{vehicle_id}/users/{user_id} [delete]
+// @Param			vehicle_id		path	string	true	"Vehicle ID"
+// @Param			user_id			path	string	true	"User ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func UnassignVehicleFromUser(svc service.VehicleService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		userID, err := uuid.Parse(c.Params("user_id"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid user_id")
+		}
+
+		if err := svc.UnassignFromUser(ctx, id, userID); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
+}
@@ -0,0 +1,26 @@
+package vehicle
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.VehicleService) *fiber.App {
+	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
+
+	app.Get("/", GetAllVehicles(svc))
+	app.Get("/plate/:plate", GetVehicleByPlate(svc))
+	app.Get("/:id", GetVehicleByID(svc))
+	app.Post("/", canEdit, CreateVehicle(svc))
+	app.Put("/:id", canEdit, UpdateVehicle(svc))
+	app.Delete("/:id", canEdit, DeleteVehicle(svc))
+
+	app.Get("/:id/users", GetVehicleUsers(svc))
+	app.Post("/:id/users", canEdit, AssignVehicleToUser(svc))
+	app.Delete("/:id/users/:user_id", canEdit, UnassignVehicleFromUser(svc))
+
+	return app
+}
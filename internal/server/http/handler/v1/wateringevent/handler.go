@@ -0,0 +1,262 @@
+package wateringevent
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+var (
+	wateringEventMapper = generated.WateringEventHTTPMapperImpl{}
+)
+
+// @Summary		Get all watering events
+// @Description	Get all watering events together with the total amount of water of all matching events. The events can be filtered by tree cluster, vehicle and time range.
+// @Id				get-all-watering-events
+// @Tags			Watering Event
+// @Produce		json
+// @Success		200	{object}	entities.WateringEventListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-event [get]
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			sort			query	string	false	"Sort field (id, watered_at, water_amount, created_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			treecluster_id	query	string	false	"Tree Cluster ID"
+// @Param			vehicle_id		query	string	false	"Vehicle ID"
+// @Param			from			query	string	false	"Watered at or after (RFC 3339 or YYYY-MM-DD)"
+// @Param			to				query	string	false	"Watered before (RFC 3339 or YYYY-MM-DD)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetAllWateringEvents(svc service.WateringEventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := parseWateringEventQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		totalWater, err := svc.GetTotalWaterAmount(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringEventListResponse{
+			Data:             wateringEventMapper.FromResponseList(domainData),
+			Pagination:       pagination.Create(query.Query, total),
+			TotalWaterAmount: totalWater,
+		})
+	}
+}
+
+// @Summary		Get watering event by ID
+// @Description	Get watering event by ID
+// @Id				get-watering-event
+// @Tags			Watering Event
+// @Produce		json
+// @Success		200	{object}	entities.WateringEventResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-event/{id} [get]
+// @Param			id				path	string	true	"Watering event ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetWateringEventByID(svc service.WateringEventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringEventMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create watering event
+// @Description	Record a watering of a tree cluster. The last watering of the tree cluster is updated and its watering status is reset. Without user the authenticated user is recorded, without time the current time.
+// @Id				create-watering-event
+// @Tags			Watering Event
+// @Produce		json
+// @Success		201	{object}	entities.WateringEventResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-event [post]
+// @Param			body			body	entities.WateringEventCreateRequest	true	"Watering event to create"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func CreateWateringEvent(svc service.WateringEventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.WateringEventCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		wec := &domain.WateringEventCreate{
+			TreeClusterID: req.TreeClusterID,
+			VehicleID:     req.VehicleID,
+			UserID:        currentUserID(c),
+			WaterAmount:   req.WaterAmount,
+		}
+
+		if req.UserID != nil {
+			userID, err := uuid.Parse(*req.UserID)
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "invalid user id")
+			}
+			wec.UserID = &userID
+		}
+
+		if req.WateredAt != nil {
+			wec.WateredAt = *req.WateredAt
+		}
+
+		domainData, err := svc.Create(ctx, wec)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(wateringEventMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete watering event
+// @Description	Delete a wrongly recorded watering event. The last watering of the tree cluster is not changed.
+// @Id				delete-watering-event
+// @Tags			Watering Event
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-event/{id} [delete]
+// @Param			id				path	string	true	"Watering event ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteWateringEvent(svc service.WateringEventService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Delete(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func parseWateringEventQuery(c *fiber.Ctx) (domain.WateringEventQuery, error) {
+	q, err := pagination.ParseQuery(c)
+	if err != nil {
+		return domain.WateringEventQuery{}, err
+	}
+
+	query := domain.WateringEventQuery{Query: q}
+	if query.TreeClusterID, err = parseOptionalInt(c, "treecluster_id"); err != nil {
+		return domain.WateringEventQuery{}, err
+	}
+	if query.VehicleID, err = parseOptionalInt(c, "vehicle_id"); err != nil {
+		return domain.WateringEventQuery{}, err
+	}
+	if query.From, err = parseOptionalTime(c, "from"); err != nil {
+		return domain.WateringEventQuery{}, err
+	}
+	if query.To, err = parseOptionalTime(c, "to"); err != nil {
+		return domain.WateringEventQuery{}, err
+	}
+
+	return query, nil
+}
+
+func parseOptionalInt(c *fiber.Ctx, param string) (*int32, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return utils.P(int32(v)), nil
+}
+
+// parseOptionalTime accepts a timestamp in RFC 3339 or a date, which is the start of the day in UTC
+func parseOptionalTime(c *fiber.Ctx, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+param+", must be RFC 3339 or YYYY-MM-DD")
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
+}
+
+// currentUserID returns the id of the authenticated user from the subject of the access token
+func currentUserID(c *fiber.Ctx) *uuid.UUID {
+	claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil {
+		return nil
+	}
+
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		return nil
+	}
+
+	return &userID
+}
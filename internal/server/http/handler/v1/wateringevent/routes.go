@@ -0,0 +1,21 @@
+package wateringevent
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.WateringEventService) *fiber.App {
+	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
+	canWater := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz, entities.RoleDriver)
+
+	app.Get("/", GetAllWateringEvents(svc))
+	app.Get("/:id", GetWateringEventByID(svc))
+	app.Post("/", canWater, CreateWateringEvent(svc))
+	app.Delete("/:id", canEdit, DeleteWateringEvent(svc))
+
+	return app
+}
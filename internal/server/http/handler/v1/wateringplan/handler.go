@@ -0,0 +1,229 @@
+package wateringplan
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/entities/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/pagination"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+var (
+	wateringPlanMapper = generated.WateringPlanHTTPMapperImpl{}
+)
+
+// @Summary		Get all watering plans
+// @Description	Get all watering plans
+// @Id				get-all-watering-plans
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanListResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan [get]
+// @Param			page			query	string	false	"Page"
+// @Param			limit			query	string	false	"Limit"
+// @Param			sort			query	string	false	"Sort field (id, date, status, created_at, updated_at)"
+// @Param			order			query	string	false	"Sort order (asc or desc)"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetAllWateringPlans(svc service.WateringPlanService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		query, err := pagination.ParseQuery(c)
+		if err != nil {
+			return err
+		}
+
+		domainData, total, err := svc.GetAll(ctx, query)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(entities.WateringPlanListResponse{
+			Data:       wateringPlanMapper.FromResponseList(domainData),
+			Pagination: pagination.Create(query, total),
+		})
+	}
+}
+
+// @Summary		Get watering plan by ID
+// @Description	Get watering plan by ID including its vehicle, users and tree clusters in watering order
+// @Id				get-watering-plan
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/{id} [get]
+// @Param			id				path	string	true	"Watering plan ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func GetWateringPlanByID(svc service.WateringPlanService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.GetByID(ctx, id)
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringPlanMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Create watering plan
+// @Description	Create a planned watering plan. The total water required is calculated from the trees of the clusters.
+// @Id				create-watering-plan
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		201	{object}	entities.WateringPlanResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan [post]
+// @Param			body			body	entities.WateringPlanCreateRequest	true	"Watering plan to create"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func CreateWateringPlan(svc service.WateringPlanService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		var req entities.WateringPlanCreateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		userIDs, err := parseUserIDs(req.UserIDs)
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.Create(ctx, &domain.WateringPlanCreate{
+			Date:           req.Date,
+			Description:    req.Description,
+			VehicleID:      req.VehicleID,
+			UserIDs:        userIDs,
+			TreeClusterIDs: req.TreeClusterIDs,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(wateringPlanMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Update watering plan
+// @Description	Update watering plan. The status can change from planned to active and from active to finished, unfinished plans can be cancelled.
+// @Id				update-watering-plan
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		200	{object}	entities.WateringPlanResponse
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/{id} [put]
+// @Param			id				path	string								true	"Watering plan ID"
+// @Param			body			body	entities.WateringPlanUpdateRequest	true	"Watering plan to update"
+// @Param			Authorization	header	string								true	"Insert your access token"	default(Bearer <Add access token here>)
+func UpdateWateringPlan(svc service.WateringPlanService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		var req entities.WateringPlanUpdateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		userIDs, err := parseUserIDs(req.UserIDs)
+		if err != nil {
+			return err
+		}
+
+		domainData, err := svc.Update(ctx, id, &domain.WateringPlanUpdate{
+			Date:           req.Date,
+			Description:    req.Description,
+			Status:         domain.WateringPlanStatus(req.Status),
+			VehicleID:      req.VehicleID,
+			UserIDs:        userIDs,
+			TreeClusterIDs: req.TreeClusterIDs,
+		})
+		if err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.JSON(wateringPlanMapper.FromResponse(domainData))
+	}
+}
+
+// @Summary		Delete watering plan
+// @Description	Delete watering plan
+// @Id				delete-watering-plan
+// @Tags			Watering Plan
+// @Produce		json
+// @Success		204
+// @Failure		400	{object}	HTTPError
+// @Failure		401	{object}	HTTPError
+// @Failure		403	{object}	HTTPError
+// @Failure		404	{object}	HTTPError
+// @Failure		500	{object}	HTTPError
+// @Router			/v1/watering-plan/{id} [delete]
+// @Param			id				path	string	true	"Watering plan ID"
+// @Param			Authorization	header	string	true	"Insert your access token"	default(Bearer <Add access token here>)
+func DeleteWateringPlan(svc service.WateringPlanService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id, err := parseID(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := svc.Delete(ctx, id); err != nil {
+			return errorhandler.HandleError(err)
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func parseID(c *fiber.Ctx, param string) (int32, error) {
+	id, err := strconv.Atoi(c.Params(param))
+	if err != nil {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "invalid "+param)
+	}
+
+	return int32(id), nil
+}
+
+func parseUserIDs(rawIDs []string) ([]uuid.UUID, error) {
+	userIDs := make([]uuid.UUID, len(rawIDs))
+	for i, rawID := range rawIDs {
+		userID, err := uuid.Parse(rawID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid user id: "+rawID)
+		}
+		userIDs[i] = userID
+	}
+
+	return userIDs, nil
+}
@@ -0,0 +1,21 @@
+package wateringplan
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+func RegisterRoutes(svc service.WateringPlanService) *fiber.App {
+	app := fiber.New()
+	canEdit := middleware.HasRole(entities.RoleAdministrator, entities.RoleTbz)
+
+	app.Get("/", GetAllWateringPlans(svc))
+	app.Get("/:id", GetWateringPlanByID(svc))
+	app.Post("/", canEdit, CreateWateringPlan(svc))
+	app.Put("/:id", canEdit, UpdateWateringPlan(svc))
+	app.Delete("/:id", canEdit, DeleteWateringPlan(svc))
+
+	return app
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/errorhandler"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+)
+
+// HasRole only lets a request pass if the token of the user contains at least one of the given realm roles.
+// It relies on the claims stored in the user context by the jwt middleware.
+func HasRole(roles ...entities.RoleName) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.UserContext().Value(enums.ContextKeyClaims).(golangJwt.MapClaims)
+		if !ok {
+			return errorhandler.HandleError(service.NewError(service.Unauthorized, "missing token claims"))
+		}
+
+		userRoles := realmRoles(claims)
+		for _, role := range roles {
+			if slices.Contains(userRoles, role) {
+				return c.Next()
+			}
+		}
+
+		return errorhandler.HandleError(service.NewError(service.Forbidden, fmt.Sprintf("requires one of the roles %v", roles)))
+	}
+}
+
+// realmRoles returns the Keycloak realm roles stored in the realm_access claim of a token
+func realmRoles(claims golangJwt.MapClaims) []entities.RoleName {
+	realmAccess, ok := claims["realm_access"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	rawRoles, ok := realmAccess["roles"].([]any)
+	if !ok {
+		return nil
+	}
+
+	roles := make([]entities.RoleName, 0, len(rawRoles))
+	for _, r := range rawRoles {
+		if name, ok := r.(string); ok {
+			roles = append(roles, entities.RoleName(name))
+		}
+	}
+
+	return roles
+}
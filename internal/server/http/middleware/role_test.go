@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	golangJwt "github.com/golang-jwt/jwt/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils/enums"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRoleTestApp(claims golangJwt.MapClaims, roles ...entities.RoleName) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if claims != nil {
+			c.SetUserContext(context.WithValue(c.UserContext(), enums.ContextKeyClaims, claims))
+		}
+		return c.Next()
+	})
+	app.Post("/", HasRole(roles...), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	return app
+}
+
+func claimsWithRoles(roles ...string) golangJwt.MapClaims {
+	rawRoles := make([]any, 0, len(roles))
+	for _, r := range roles {
+		rawRoles = append(rawRoles, r)
+	}
+
+	return golangJwt.MapClaims{"realm_access": map[string]any{"roles": rawRoles}}
+}
+
+func TestHasRole(t *testing.T) {
+	t.Run("should pass when user has one of the roles", func(t *testing.T) {
+		// given
+		app := newRoleTestApp(claimsWithRoles("offline_access", "tbz"), entities.RoleAdministrator, entities.RoleTbz)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden when user has none of the roles", func(t *testing.T) {
+		// given
+		app := newRoleTestApp(claimsWithRoles("driver"), entities.RoleAdministrator, entities.RoleTbz)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return forbidden when token has no realm roles", func(t *testing.T) {
+		// given
+		app := newRoleTestApp(golangJwt.MapClaims{"sub": "user"}, entities.RoleAdministrator)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("should return unauthorized when claims are missing", func(t *testing.T) {
+		// given
+		app := newRoleTestApp(nil, entities.RoleAdministrator)
+
+		// when
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -3,12 +3,20 @@ package http
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/flowerbed"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/image"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/info"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/region"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/role"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/route"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tile"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/user"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/vehicle"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringevent"
+	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/handler/v1/wateringplan"
 	"github.com/green-ecolution/green-ecolution-backend/internal/server/http/middleware"
 )
 
@@ -20,8 +28,15 @@ func (s *Server) privateRoutes(app *fiber.App) {
 	grp.Mount("/tree", tree.RegisterRoutes(s.services.TreeService))
 	grp.Mount("/sensor", sensor.RegisterRoutes(s.services.MqttService))
 	grp.Mount("/user", user.RegisterRoutes(s.services.AuthService))
-	grp.Mount("/role", user.RegisterRoutes(s.services.AuthService))
+	grp.Mount("/role", role.RegisterRoutes(s.services.RoleService))
 	grp.Mount("/region", region.RegisterRoutes(s.services.RegionService))
+	grp.Mount("/flowerbed", flowerbed.RegisterRoutes(s.services.FlowerbedService))
+	grp.Mount("/vehicle", vehicle.RegisterRoutes(s.services.VehicleService))
+	grp.Mount("/image", image.RegisterRoutes(s.services.ImageService))
+	grp.Mount("/watering-plan", wateringplan.RegisterRoutes(s.services.WateringPlanService))
+	grp.Mount("/route", route.RegisterRoutes(s.services.RouteService))
+	grp.Mount("/watering-event", wateringevent.RegisterRoutes(s.services.WateringEventService))
+	grp.Mount("/tiles", tile.RegisterRoutes(s.services.TileService))
 }
 
 func (s *Server) publicRoutes(app *fiber.App) {
@@ -34,6 +34,7 @@ func (s *Server) Run(ctx context.Context) error {
 		AppName:      s.cfg.Dashboard.Title,
 		ServerHeader: s.cfg.Dashboard.Title,
 		ErrorHandler: errorHandler,
+		BodyLimit:    s.bodyLimit(),
 	})
 
 	app.Mount("/", s.middleware(s.publicRoutes, s.privateRoutes))
@@ -49,6 +50,19 @@ func (s *Server) Run(ctx context.Context) error {
 	return app.Listen(fmt.Sprintf(":%d", s.cfg.Server.Port))
 }
 
+// multipartOverhead is the space reserved for multipart boundaries and form fields of an upload
+const multipartOverhead = 1 << 20
+
+// bodyLimit returns the maximum request body size which is large enough for image uploads
+func (s *Server) bodyLimit() int {
+	limit := int(s.cfg.Storage.MaxUploadSize) + multipartOverhead
+	if limit < fiber.DefaultBodyLimit {
+		return fiber.DefaultBodyLimit
+	}
+
+	return limit
+}
+
 func errorHandler(c *fiber.Ctx, err error) error {
 	c.Status(fiber.StatusInternalServerError)
 	var e *fiber.Error
@@ -24,5 +24,5 @@ func NewAuthService(repo storage.AuthRepository, userRepo storage.UserRepository
 }
 
 func (s *AuthService) Ready() bool {
-	return s.authRepository != nil
+	return s.authRepository != nil && s.userRepo != nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"net/url"
 
+	"github.com/google/uuid"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/pkg/errors"
 )
 
@@ -16,12 +18,100 @@ func (s *AuthService) Register(ctx context.Context, user *domain.RegisterUser) (
 
 	createdUser, err := s.userRepo.Create(ctx, &user.User, user.Password, user.Roles)
 	if err != nil {
-		return nil, service.NewError(service.InternalError, errors.Wrap(err, "failed to create user").Error())
+		return nil, handleError(errors.Wrap(err, "failed to create user"))
 	}
 
 	return createdUser, nil
 }
 
+func (s *AuthService) GetAllUsers(ctx context.Context, query domain.UserQuery) ([]*domain.User, int64, error) {
+	users, total, err := s.userRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return users, total, nil
+}
+
+func (s *AuthService) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return user, nil
+}
+
+// UpdateUser applies the non empty fields of the update to the user. A user is disabled by setting enabled to false.
+func (s *AuthService) UpdateUser(ctx context.Context, id uuid.UUID, update *domain.UserUpdate) (*domain.User, error) {
+	if err := s.validator.Struct(update); err != nil {
+		return nil, service.NewError(service.BadRequest, errors.Wrap(err, "validation error").Error())
+	}
+
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	setIfNotEmpty(&user.Username, update.Username)
+	setIfNotEmpty(&user.FirstName, update.FirstName)
+	setIfNotEmpty(&user.LastName, update.LastName)
+	setIfNotEmpty(&user.Email, update.Email)
+	setIfNotEmpty(&user.EmployeeID, update.EmployeeID)
+	setIfNotEmpty(&user.PhoneNumber, update.PhoneNumber)
+	if update.Enabled != nil {
+		user.Enabled = *update.Enabled
+	}
+
+	updatedUser, err := s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return updatedUser, nil
+}
+
+func (s *AuthService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *AuthService) GetUserRoles(ctx context.Context, id uuid.UUID) ([]*domain.Role, error) {
+	roles, err := s.userRepo.GetRoles(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return roles, nil
+}
+
+func (s *AuthService) AddUserRoles(ctx context.Context, id uuid.UUID, roles []string) error {
+	if len(roles) == 0 {
+		return service.NewError(service.BadRequest, "at least one role is required")
+	}
+
+	if err := s.userRepo.AddRoles(ctx, id, roles); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *AuthService) RemoveUserRoles(ctx context.Context, id uuid.UUID, roles []string) error {
+	if len(roles) == 0 {
+		return service.NewError(service.BadRequest, "at least one role is required")
+	}
+
+	if err := s.userRepo.RemoveRoles(ctx, id, roles); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
 func (s *AuthService) LoginRequest(_ context.Context, loginRequest *domain.LoginRequest) (*domain.LoginResp, error) {
 	loginURL, err := url.ParseRequestURI(s.cfg.KeyCloak.Frontend.AuthURL)
 	if err != nil {
@@ -66,3 +156,23 @@ func (s *AuthService) LogoutRequest(ctx context.Context, logoutRequest *domain.L
 
 	return nil
 }
+
+func setIfNotEmpty(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrUserNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrRoleNotFound) ||
+		errors.Is(err, storage.ErrDuplicateEntity) ||
+		errors.Is(err, storage.ErrInvalidSortField) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
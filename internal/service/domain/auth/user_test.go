@@ -9,8 +9,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestRegisterUser(t *testing.T) {
@@ -234,3 +237,99 @@ func TestClientTokenCallback(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGetUserByID(t *testing.T) {
+	t.Run("should return not found when user does not exist", func(t *testing.T) {
+		// given
+		id := uuid.New()
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewAuthService(storageMock.NewMockAuthRepository(t), userRepo, &config.IdentityAuthConfig{})
+
+		// when
+		userRepo.EXPECT().GetByID(context.Background(), id).Return(nil, storage.ErrUserNotFound)
+		resp, err := svc.GetUserByID(context.Background(), id)
+
+		// then
+		assert.Nil(t, resp)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	t.Run("should only change fields which are set", func(t *testing.T) {
+		// given
+		id := uuid.New()
+		stored := &entities.User{
+			ID:          id,
+			Username:    "username",
+			FirstName:   "firstName",
+			LastName:    "lastName",
+			Email:       "mail@foo.com",
+			EmployeeID:  "employeeID",
+			PhoneNumber: "phoneNumber",
+			Enabled:     true,
+		}
+		enabled := false
+		update := &entities.UserUpdate{PhoneNumber: "0461 123", Enabled: &enabled}
+
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewAuthService(storageMock.NewMockAuthRepository(t), userRepo, &config.IdentityAuthConfig{})
+
+		// when
+		userRepo.EXPECT().GetByID(context.Background(), id).Return(stored, nil)
+		userRepo.EXPECT().Update(context.Background(), mock.Anything).
+			RunAndReturn(func(_ context.Context, u *entities.User) (*entities.User, error) { return u, nil })
+		resp, err := svc.UpdateUser(context.Background(), id, update)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "0461 123", resp.PhoneNumber)
+		assert.Equal(t, "employeeID", resp.EmployeeID)
+		assert.Equal(t, "firstName", resp.FirstName)
+		assert.False(t, resp.Enabled)
+	})
+
+	t.Run("should return error when validation error", func(t *testing.T) {
+		// given
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewAuthService(storageMock.NewMockAuthRepository(t), userRepo, &config.IdentityAuthConfig{})
+
+		// when
+		resp, err := svc.UpdateUser(context.Background(), uuid.New(), &entities.UserUpdate{Email: "not a mail"})
+
+		// then
+		assert.Nil(t, resp)
+		assert.Error(t, err)
+	})
+}
+
+func TestAddUserRoles(t *testing.T) {
+	t.Run("should return bad request when role does not exist", func(t *testing.T) {
+		// given
+		id := uuid.New()
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewAuthService(storageMock.NewMockAuthRepository(t), userRepo, &config.IdentityAuthConfig{})
+
+		// when
+		userRepo.EXPECT().AddRoles(context.Background(), id, []string{"unknown"}).Return(storage.ErrRoleNotFound)
+		err := svc.AddUserRoles(context.Background(), id, []string{"unknown"})
+
+		// then
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+
+	t.Run("should return bad request without roles", func(t *testing.T) {
+		// given
+		svc := NewAuthService(storageMock.NewMockAuthRepository(t), storageMock.NewMockUserRepository(t), &config.IdentityAuthConfig{})
+
+		// when
+		err := svc.AddUserRoles(context.Background(), uuid.New(), nil)
+
+		// then
+		assert.Error(t, err)
+	})
+}
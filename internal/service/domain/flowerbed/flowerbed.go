@@ -0,0 +1,333 @@
+package flowerbed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/flowerbed"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+type FlowerbedService struct {
+	flowerbedRepo storage.FlowerbedRepository
+	sensorRepo    storage.SensorRepository
+	imageRepo     storage.ImageRepository
+	regionRepo    storage.RegionRepository
+	validator     *validator.Validate
+}
+
+func NewFlowerbedService(
+	flowerbedRepo storage.FlowerbedRepository,
+	sensorRepo storage.SensorRepository,
+	imageRepo storage.ImageRepository,
+	regionRepo storage.RegionRepository,
+) service.FlowerbedService {
+	return &FlowerbedService{
+		flowerbedRepo: flowerbedRepo,
+		sensorRepo:    sensorRepo,
+		imageRepo:     imageRepo,
+		regionRepo:    regionRepo,
+		validator:     validator.New(),
+	}
+}
+
+func (s *FlowerbedService) GetAll(ctx context.Context, query domain.Query) ([]*domain.Flowerbed, int64, error) {
+	flowerbeds, total, err := s.flowerbedRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return flowerbeds, total, nil
+}
+
+func (s *FlowerbedService) GetByID(ctx context.Context, id int32) (*domain.Flowerbed, error) {
+	f, err := s.flowerbedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) Create(ctx context.Context, fc *domain.FlowerbedCreate) (*domain.Flowerbed, error) {
+	if err := s.validate(fc, fc.Geometry); err != nil {
+		return nil, err
+	}
+
+	fn, err := s.prepareFields(ctx, fc.Geometry, fc.SensorID, fc.ImageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fn = append(fn,
+		flowerbed.WithSize(fc.Size),
+		flowerbed.WithDescription(fc.Description),
+		flowerbed.WithNumberOfPlants(fc.NumberOfPlants),
+		flowerbed.WithMoistureLevel(fc.MoistureLevel),
+		flowerbed.WithAddress(fc.Address),
+	)
+
+	f, err := s.flowerbedRepo.CreateAndLinkImages(ctx, fn...)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := utils.FlagMisplacedImages(ctx, s.flowerbedRepo, f.ID, f.Images, f.Latitude, f.Longitude); err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) Update(ctx context.Context, id int32, fu *domain.FlowerbedUpdate) (*domain.Flowerbed, error) {
+	if err := s.validate(fu, fu.Geometry); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.flowerbedRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	fn, err := s.prepareFields(ctx, fu.Geometry, fu.SensorID, fu.ImageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fn = append(fn,
+		flowerbed.WithSize(fu.Size),
+		flowerbed.WithDescription(fu.Description),
+		flowerbed.WithNumberOfPlants(fu.NumberOfPlants),
+		flowerbed.WithMoistureLevel(fu.MoistureLevel),
+		flowerbed.WithAddress(fu.Address),
+	)
+
+	f, err := s.flowerbedRepo.UpdateWithImages(ctx, id, fn...)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := utils.FlagMisplacedImages(ctx, s.flowerbedRepo, f.ID, f.Images, f.Latitude, f.Longitude); err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) Archive(ctx context.Context, id int32) error {
+	if _, err := s.flowerbedRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.flowerbedRepo.Archive(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *FlowerbedService) Delete(ctx context.Context, id int32) error {
+	if _, err := s.flowerbedRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.flowerbedRepo.DeleteAndUnlinkImages(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *FlowerbedService) GetSensor(ctx context.Context, id int32) (*domain.Sensor, error) {
+	f, err := s.flowerbedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if f.Sensor == nil {
+		return nil, service.NewError(service.NotFound, fmt.Sprintf("flowerbed %d has no sensor", id))
+	}
+
+	return f.Sensor, nil
+}
+
+func (s *FlowerbedService) AddSensor(ctx context.Context, id, sensorID int32) (*domain.Flowerbed, error) {
+	if _, err := s.flowerbedRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	sensor, err := s.sensorRepo.GetByID(ctx, sensorID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	f, err := s.flowerbedRepo.Update(ctx, id, flowerbed.WithSensor(sensor))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) RemoveSensor(ctx context.Context, id, sensorID int32) (*domain.Flowerbed, error) {
+	f, err := s.flowerbedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if f.Sensor == nil || f.Sensor.ID != sensorID {
+		return nil, service.NewError(service.NotFound, fmt.Sprintf("sensor %d is not linked to flowerbed %d", sensorID, id))
+	}
+
+	f, err = s.flowerbedRepo.Update(ctx, id, flowerbed.WithSensor(nil))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) GetImages(ctx context.Context, id int32) ([]*domain.Image, error) {
+	if _, err := s.flowerbedRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	images, err := s.flowerbedRepo.GetAllImagesByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return images, nil
+}
+
+func (s *FlowerbedService) AddImages(ctx context.Context, id int32, imageIDs []int32) (*domain.Flowerbed, error) {
+	f, err := s.flowerbedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	images := f.Images
+	for _, imageID := range imageIDs {
+		if containsImage(images, imageID) {
+			continue
+		}
+
+		img, err := s.imageRepo.GetByID(ctx, imageID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		images = append(images, img)
+	}
+
+	f, err = s.flowerbedRepo.UpdateWithImages(ctx, id, flowerbed.WithImages(images))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := utils.FlagMisplacedImages(ctx, s.flowerbedRepo, f.ID, f.Images, f.Latitude, f.Longitude); err != nil {
+		return nil, handleError(err)
+	}
+
+	return f, nil
+}
+
+func (s *FlowerbedService) RemoveImage(ctx context.Context, id, imageID int32) (*domain.Flowerbed, error) {
+	f, err := s.flowerbedRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if !containsImage(f.Images, imageID) {
+		return nil, service.NewError(service.NotFound, fmt.Sprintf("image %d is not linked to flowerbed %d", imageID, id))
+	}
+
+	if err := s.flowerbedRepo.UnlinkImage(ctx, id, imageID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *FlowerbedService) Ready() bool {
+	return s.flowerbedRepo != nil
+}
+
+func (s *FlowerbedService) validate(v any, geometry domain.Polygon) error {
+	if err := s.validator.Struct(v); err != nil {
+		return service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if err := geometry.Validate(); err != nil {
+		return service.NewError(service.BadRequest, fmt.Sprintf("invalid geometry: %s", err.Error()))
+	}
+
+	return nil
+}
+
+// prepareFields resolves the location, region, sensor and images of a flowerbed
+func (s *FlowerbedService) prepareFields(ctx context.Context, geometry domain.Polygon, sensorID *int32, imageIDs []*int32) ([]domain.EntityFunc[domain.Flowerbed], error) {
+	lat, long := geometry.Centroid()
+	region, err := s.regionRepo.GetByPoint(ctx, lat, long)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	var sensor *domain.Sensor
+	if sensorID != nil {
+		sensor, err = s.sensorRepo.GetByID(ctx, *sensorID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+	}
+
+	images := make([]*domain.Image, 0, len(imageIDs))
+	for _, imageID := range imageIDs {
+		if imageID == nil {
+			continue
+		}
+
+		img, err := s.imageRepo.GetByID(ctx, *imageID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		images = append(images, img)
+	}
+
+	return []domain.EntityFunc[domain.Flowerbed]{
+		flowerbed.WithGeometry(geometry),
+		flowerbed.WithLatitude(lat),
+		flowerbed.WithLongitude(long),
+		flowerbed.WithRegion(region),
+		flowerbed.WithSensor(sensor),
+		flowerbed.WithImages(images),
+	}, nil
+}
+
+func containsImage(images []*domain.Image, id int32) bool {
+	for _, img := range images {
+		if img.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrFlowerbedNotFound) ||
+		errors.Is(err, storage.ErrSensorNotFound) ||
+		errors.Is(err, storage.ErrImageNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrInvalidSortField) || errors.Is(err, storage.ErrInvalidGeometry) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
@@ -0,0 +1,219 @@
+package flowerbed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.FlowerbedService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewFlowerbedService(m.FlowerbedRepo, m.SensorRepo, m.ImageRepo, m.RegionRepo), m
+}
+
+// newPostgresTestService returns the service with the postgres repositories, so the errors of the
+// repositories are the ones returned by the database instead of mocked ones
+func newPostgresTestService(t *testing.T) service.FlowerbedService {
+	repo := postgres.NewRepository(testutils.SetupTestPool(t), &config.TreeClusterConfig{})
+	return NewFlowerbedService(repo.Flowerbed, repo.Sensor, repo.Image, repo.Region)
+}
+
+var square = entities.Polygon{{{9.0, 54.0}, {9.2, 54.0}, {9.2, 54.2}, {9.0, 54.2}, {9.0, 54.0}}}
+
+func TestFlowerbedService_Create(t *testing.T) {
+	t.Run("should create flowerbed at the centroid of the geometry", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		region := &entities.Region{ID: 1}
+		expected := &entities.Flowerbed{ID: 1, Geometry: square}
+
+		// when
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), mock.MatchedBy(func(lat float64) bool {
+			return lat > 54.0999 && lat < 54.1001
+		}), mock.MatchedBy(func(long float64) bool {
+			return long > 9.0999 && long < 9.1001
+		})).Return(region, nil)
+		m.FlowerbedRepo.EXPECT().CreateAndLinkImages(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		result, err := svc.Create(context.Background(), &entities.FlowerbedCreate{Geometry: square})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return bad request when geometry is missing", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.FlowerbedCreate{})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when ring is not closed", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		open := entities.Polygon{{{9.0, 54.0}, {9.2, 54.0}, {9.2, 54.2}, {9.0, 54.2}}}
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.FlowerbedCreate{Geometry: open})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when sensor does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		sensorID := int32(3)
+
+		// when
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), mock.Anything, mock.Anything).Return(nil, nil)
+		m.SensorRepo.EXPECT().GetByID(context.Background(), sensorID).Return(nil, storage.ErrSensorNotFound)
+		result, err := svc.Create(context.Background(), &entities.FlowerbedCreate{Geometry: square, SensorID: &sensorID})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestFlowerbedService_Delete(t *testing.T) {
+	t.Run("should delete flowerbed", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Flowerbed{ID: 1}, nil)
+		m.FlowerbedRepo.EXPECT().DeleteAndUnlinkImages(context.Background(), int32(1)).Return(nil)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found when flowerbed does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrFlowerbedNotFound)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestFlowerbedService_Archive(t *testing.T) {
+	t.Run("should archive flowerbed", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Flowerbed{ID: 1}, nil)
+		m.FlowerbedRepo.EXPECT().Archive(context.Background(), int32(1)).Return(nil)
+		err := svc.Archive(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+}
+
+func TestFlowerbedService_Sensor(t *testing.T) {
+	t.Run("should return not found when flowerbed has no sensor", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Flowerbed{ID: 1}, nil)
+		result, err := svc.GetSensor(context.Background(), 1)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should not remove sensor that is not linked", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Flowerbed{ID: 1, Sensor: &entities.Sensor{ID: 2}}, nil)
+		result, err := svc.RemoveSensor(context.Background(), 1, 5)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestFlowerbedService_AddImages(t *testing.T) {
+	t.Run("should only link images that are not linked yet", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		linked := &entities.Image{ID: 1}
+		added := &entities.Image{ID: 2}
+		expected := &entities.Flowerbed{ID: 1, Images: []*entities.Image{linked, added}}
+
+		// when
+		m.FlowerbedRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Flowerbed{ID: 1, Images: []*entities.Image{linked}}, nil)
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(added, nil)
+		m.FlowerbedRepo.EXPECT().UpdateWithImages(context.Background(), int32(1), mock.Anything).Return(expected, nil)
+		result, err := svc.AddImages(context.Background(), 1, []int32{1, 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestFlowerbedService_NotFoundWithPostgres(t *testing.T) {
+	t.Run("should return not found if flowerbed doesn't exist", func(t *testing.T) {
+		// given
+		svc := newPostgresTestService(t)
+
+		// when
+		f, err := svc.GetByID(context.Background(), 999999)
+
+		// then
+		assert.Nil(t, f)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return not found on update if flowerbed doesn't exist", func(t *testing.T) {
+		// given
+		svc := newPostgresTestService(t)
+
+		// when
+		f, err := svc.Update(context.Background(), 999999, &entities.FlowerbedUpdate{Geometry: square})
+
+		// then
+		assert.Nil(t, f)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return not found on delete if flowerbed doesn't exist", func(t *testing.T) {
+		// given
+		svc := newPostgresTestService(t)
+
+		// when
+		err := svc.Delete(context.Background(), 999999)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
@@ -0,0 +1,248 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	tagOrientation        = 0x0112
+	tagDateTime           = 0x0132
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+	tagGPSLatitudeRef     = 0x0001
+	tagGPSLatitude        = 0x0002
+	tagGPSLongitudeRef    = 0x0003
+	tagGPSLongitude       = 0x0004
+
+	typeASCII    = 2
+	typeShort    = 3
+	typeRational = 5
+
+	exifDateFormat = "2006:01:02 15:04:05"
+)
+
+var errNoExif = errors.New("no exif data")
+
+// exifData holds the metadata of a photo which is relevant for us
+type exifData struct {
+	capturedAt *time.Time
+	latitude   *float64
+	longitude  *float64
+	// orientation is the EXIF orientation (1-8) the image has to be rotated or flipped by to be displayed upright
+	orientation int
+}
+
+type ifdEntry struct {
+	tag    uint16
+	typ    uint16
+	count  uint32
+	offset uint32
+	// raw holds the 4 byte value field, which contains the value itself if it fits
+	raw []byte
+}
+
+// parseExif reads the capture time, GPS position and orientation from the EXIF segment of a JPEG file
+func parseExif(data []byte) (*exifData, error) {
+	tiff, err := findExifSegment(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tiff) < 8 {
+		return nil, errNoExif
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, errNoExif
+	}
+
+	p := &tiffParser{data: tiff, order: order}
+	ifd0, err := p.readIFD(order.Uint32(tiff[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &exifData{orientation: p.short(ifd0[tagOrientation])}
+	dateTime := p.ascii(ifd0[tagDateTime])
+
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if exifIFD, err := p.readIFD(e.offset); err == nil {
+			if original := p.ascii(exifIFD[tagDateTimeOriginal]); original != "" {
+				dateTime = original
+			}
+			result.capturedAt = parseExifTime(dateTime, p.ascii(exifIFD[tagOffsetTimeOriginal]))
+		}
+	}
+	if result.capturedAt == nil {
+		result.capturedAt = parseExifTime(dateTime, "")
+	}
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if gpsIFD, err := p.readIFD(e.offset); err == nil {
+			result.latitude = p.coordinate(gpsIFD[tagGPSLatitude], p.ascii(gpsIFD[tagGPSLatitudeRef]), "S")
+			result.longitude = p.coordinate(gpsIFD[tagGPSLongitude], p.ascii(gpsIFD[tagGPSLongitudeRef]), "W")
+		}
+	}
+
+	if result.latitude == nil || result.longitude == nil {
+		result.latitude, result.longitude = nil, nil
+	}
+
+	return result, nil
+}
+
+// findExifSegment returns the TIFF structure of the APP1 segment of a JPEG file
+func findExifSegment(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errNoExif
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil, errNoExif
+		}
+		marker := data[pos+1]
+		// start of scan, the image data follows and no more metadata segments
+		if marker == 0xDA || marker == 0xD9 {
+			return nil, errNoExif
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, errNoExif
+		}
+
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+
+		pos = end
+	}
+
+	return nil, errNoExif
+}
+
+type tiffParser struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (p *tiffParser) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	start := int(offset)
+	if start+2 > len(p.data) {
+		return nil, errNoExif
+	}
+
+	count := int(p.order.Uint16(p.data[start : start+2]))
+	entries := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		pos := start + 2 + i*12
+		if pos+12 > len(p.data) {
+			return nil, errNoExif
+		}
+
+		entry := ifdEntry{
+			tag:    p.order.Uint16(p.data[pos : pos+2]),
+			typ:    p.order.Uint16(p.data[pos+2 : pos+4]),
+			count:  p.order.Uint32(p.data[pos+4 : pos+8]),
+			offset: p.order.Uint32(p.data[pos+8 : pos+12]),
+			raw:    p.data[pos+8 : pos+12],
+		}
+		entries[entry.tag] = entry
+	}
+
+	return entries, nil
+}
+
+func (p *tiffParser) ascii(e ifdEntry) string {
+	if e.typ != typeASCII || e.count == 0 {
+		return ""
+	}
+
+	var value []byte
+	if e.count <= 4 {
+		value = e.raw[:e.count]
+	} else {
+		end := int(e.offset) + int(e.count)
+		if end > len(p.data) || end < int(e.offset) {
+			return ""
+		}
+		value = p.data[e.offset:end]
+	}
+
+	return strings.TrimRight(string(value), "\x00 ")
+}
+
+func (p *tiffParser) short(e ifdEntry) int {
+	if e.typ != typeShort || e.count != 1 {
+		return 0
+	}
+
+	return int(p.order.Uint16(e.raw[:2]))
+}
+
+// coordinate converts a GPS position given as degrees, minutes and seconds to decimal degrees
+func (p *tiffParser) coordinate(e ifdEntry, ref, negativeRef string) *float64 {
+	if e.typ != typeRational || e.count != 3 {
+		return nil
+	}
+
+	start := int(e.offset)
+	if start+24 > len(p.data) || start < 0 {
+		return nil
+	}
+
+	var parts [3]float64
+	for i := range parts {
+		num := p.order.Uint32(p.data[start+i*8 : start+i*8+4])
+		den := p.order.Uint32(p.data[start+i*8+4 : start+i*8+8])
+		if den == 0 {
+			return nil
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+
+	value := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		value = -value
+	}
+
+	return &value
+}
+
+// parseExifTime parses an EXIF date. EXIF dates have no time zone, so UTC is assumed
+// unless the offset of the capture time is given.
+func parseExifTime(value, offset string) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	loc := time.UTC
+	if offset != "" {
+		if t, err := time.Parse("-07:00", offset); err == nil {
+			loc = t.Location()
+		}
+	}
+
+	t, err := time.ParseInLocation(exifDateFormat, value, loc)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,84 @@
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildExifJPEG creates a minimal JPEG file containing only an EXIF segment with the capture time,
+// the orientation of a photo taken in portrait mode and a GPS position of 54°48'0" N 9°26'24" W
+func buildExifJPEG(t *testing.T) []byte {
+	t.Helper()
+	order := binary.LittleEndian
+	tiff := new(bytes.Buffer)
+
+	entry := func(tag, typ uint16, count uint32, value []byte) {
+		_ = binary.Write(tiff, order, tag)
+		_ = binary.Write(tiff, order, typ)
+		_ = binary.Write(tiff, order, count)
+		tiff.Write(append(value, make([]byte, 4-len(value))...))
+	}
+	u32 := func(v uint32) []byte {
+		return order.AppendUint32(nil, v)
+	}
+
+	// header, IFD0 at offset 8 with 3 entries (8 + 2 + 3*12 + 4 = 50), GPS IFD with 4 entries at 50 (50 + 2 + 4*12 + 4 = 104)
+	const gpsOffset, dateOffset, latOffset, lonOffset = 50, 104, 124, 148
+	tiff.WriteString("II")
+	_ = binary.Write(tiff, order, uint16(42))
+	_ = binary.Write(tiff, order, uint32(8))
+
+	_ = binary.Write(tiff, order, uint16(3))
+	entry(tagOrientation, typeShort, 1, order.AppendUint16(nil, 6))
+	entry(tagDateTime, typeASCII, 20, u32(dateOffset))
+	entry(tagGPSIFD, 4, 1, u32(gpsOffset))
+	_ = binary.Write(tiff, order, uint32(0))
+
+	_ = binary.Write(tiff, order, uint16(4))
+	entry(tagGPSLatitudeRef, typeASCII, 2, []byte("N\x00"))
+	entry(tagGPSLatitude, typeRational, 3, u32(latOffset))
+	entry(tagGPSLongitudeRef, typeASCII, 2, []byte("W\x00"))
+	entry(tagGPSLongitude, typeRational, 3, u32(lonOffset))
+	_ = binary.Write(tiff, order, uint32(0))
+
+	tiff.WriteString("2024:06:01 12:30:00\x00")
+	for _, v := range []uint32{54, 1, 48, 1, 0, 1, 9, 1, 26, 1, 24, 1} {
+		_ = binary.Write(tiff, order, v)
+	}
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1}
+	data = binary.BigEndian.AppendUint16(data, uint16(len(segment)+2))
+	data = append(data, segment...)
+	return append(data, 0xFF, 0xD9)
+}
+
+func TestParseExif(t *testing.T) {
+	t.Run("should read capture time and gps position", func(t *testing.T) {
+		// given
+		data := buildExifJPEG(t)
+
+		// when
+		result, err := parseExif(data)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC), *result.capturedAt)
+		assert.InDelta(t, 54.8, *result.latitude, 1e-9)
+		assert.InDelta(t, -9.44, *result.longitude, 1e-9)
+		assert.Equal(t, 6, result.orientation)
+	})
+
+	t.Run("should return error for file without exif data", func(t *testing.T) {
+		// when
+		result, err := parseExif(pngHeader)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, errNoExif)
+	})
+}
@@ -0,0 +1,260 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/image"
+)
+
+// allowedMimeTypes maps the supported image types to the file extension used for the object key
+var allowedMimeTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type ImageService struct {
+	imageRepo     storage.ImageRepository
+	blobRepo      storage.BlobRepository
+	maxUploadSize int64
+}
+
+func NewImageService(
+	imageRepo storage.ImageRepository,
+	blobRepo storage.BlobRepository,
+	cfg *config.ObjectStorageConfig,
+) service.ImageService {
+	return &ImageService{
+		imageRepo:     imageRepo,
+		blobRepo:      blobRepo,
+		maxUploadSize: cfg.MaxUploadSize,
+	}
+}
+
+func (s *ImageService) GetAll(ctx context.Context, query domain.Query) ([]*domain.Image, int64, error) {
+	images, total, err := s.imageRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return images, total, nil
+}
+
+func (s *ImageService) GetByID(ctx context.Context, id int32) (*domain.Image, error) {
+	img, err := s.imageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return img, nil
+}
+
+// Upload stores the uploaded file in the blob storage and creates an image referencing it.
+// The type of the file is detected from its content, the file name sent by the client is only kept for display.
+func (s *ImageService) Upload(ctx context.Context, upload *domain.ImageUpload) (*domain.Image, error) {
+	if upload == nil || upload.File == nil {
+		return nil, service.NewError(service.BadRequest, "image file is required")
+	}
+
+	if s.maxUploadSize > 0 && upload.Size > s.maxUploadSize {
+		return nil, s.errTooLarge()
+	}
+
+	data, err := s.readFile(upload.File)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := allowedMimeTypes[mimeType]
+	if !ok {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("unsupported image type: %s", mimeType))
+	}
+
+	baseKey := uuid.NewString()
+	key := baseKey + ext
+	if err := s.blobRepo.Upload(ctx, key, mimeType, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, service.NewError(service.InternalError, err.Error())
+	}
+
+	fn := []domain.EntityFunc[domain.Image]{
+		image.WithURL(s.blobRepo.URL(key)),
+		image.WithFilename(sanitizeFilename(upload.Filename)),
+		image.WithMimeType(&mimeType),
+		image.WithObjectKey(&key),
+	}
+
+	var orientation int
+	if meta, err := parseExif(data); err == nil {
+		orientation = meta.orientation
+		fn = append(fn,
+			image.WithCapturedAt(meta.capturedAt),
+			image.WithLocation(meta.latitude, meta.longitude),
+		)
+	}
+
+	thumbnails := s.storeThumbnails(ctx, baseKey, data, mimeType, orientation)
+	fn = append(fn, image.WithThumbnails(thumbnails))
+
+	img, err := s.imageRepo.Create(ctx, fn...)
+	if err != nil {
+		s.deleteObjects(ctx, &key, thumbnails)
+		return nil, handleError(err)
+	}
+
+	return img, nil
+}
+
+// Download returns the content of a stored image file or of one of its thumbnails
+func (s *ImageService) Download(ctx context.Context, key string) (*domain.Image, io.ReadCloser, error) {
+	img, err := s.imageRepo.GetByObjectKey(ctx, key)
+	if err != nil {
+		return nil, nil, handleError(err)
+	}
+
+	body, err := s.blobRepo.Download(ctx, key)
+	if err != nil {
+		return nil, nil, handleError(err)
+	}
+
+	// thumbnails may be encoded in another format than the original image
+	if img.ObjectKey == nil || *img.ObjectKey != key {
+		thumbnail := *img
+		mimeType := mime.TypeByExtension(filepath.Ext(key))
+		thumbnail.MimeType = &mimeType
+		return &thumbnail, body, nil
+	}
+
+	return img, body, nil
+}
+
+// Delete removes the image including its links to trees and flowerbeds, the stored file and its thumbnails
+func (s *ImageService) Delete(ctx context.Context, id int32) error {
+	img, err := s.imageRepo.GetByID(ctx, id)
+	if err != nil {
+		return handleError(err)
+	}
+
+	if err := s.imageRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	s.deleteObjects(ctx, img.ObjectKey, img.Thumbnails)
+
+	return nil
+}
+
+// storeThumbnails generates and stores the thumbnails of an image. Thumbnails are optional,
+// so errors are only logged and the image is stored without the affected thumbnails.
+func (s *ImageService) storeThumbnails(ctx context.Context, baseKey string, data []byte, mimeType string, orientation int) []*domain.ImageThumbnail {
+	generated, err := generateThumbnails(data, mimeType, orientation)
+	if err != nil {
+		slog.Warn("failed to generate thumbnails", "key", baseKey, "error", err)
+		return nil
+	}
+
+	thumbnails := make([]*domain.ImageThumbnail, 0, len(generated))
+	for _, t := range generated {
+		key := fmt.Sprintf("%s_%s%s", baseKey, t.size, t.ext)
+		if err := s.blobRepo.Upload(ctx, key, t.mimeType, bytes.NewReader(t.data), int64(len(t.data))); err != nil {
+			slog.Warn("failed to store thumbnail", "key", key, "error", err)
+			continue
+		}
+
+		thumbnails = append(thumbnails, &domain.ImageThumbnail{
+			Size:      t.size,
+			Width:     int32(t.width),
+			Height:    int32(t.height),
+			URL:       s.blobRepo.URL(key),
+			ObjectKey: key,
+		})
+	}
+
+	return thumbnails
+}
+
+// deleteObjects removes the stored file and the thumbnails of an image. The image itself is already
+// gone at this point, so failures are only logged.
+func (s *ImageService) deleteObjects(ctx context.Context, key *string, thumbnails []*domain.ImageThumbnail) {
+	keys := make([]string, 0, len(thumbnails)+1)
+	if key != nil {
+		keys = append(keys, *key)
+	}
+	for _, t := range thumbnails {
+		keys = append(keys, t.ObjectKey)
+	}
+
+	for _, k := range keys {
+		if err := s.blobRepo.Delete(ctx, k); err != nil {
+			slog.Error("failed to delete image object", "key", k, "error", err)
+		}
+	}
+}
+
+func (s *ImageService) Ready() bool {
+	return s.imageRepo != nil && s.blobRepo != nil
+}
+
+// readFile reads the whole file while enforcing the size limit, the size sent by the client is not trusted
+func (s *ImageService) readFile(r io.Reader) ([]byte, error) {
+	if s.maxUploadSize > 0 {
+		r = io.LimitReader(r, s.maxUploadSize+1)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("failed to read image: %s", err.Error()))
+	}
+
+	if s.maxUploadSize > 0 && int64(len(data)) > s.maxUploadSize {
+		return nil, s.errTooLarge()
+	}
+
+	if len(data) == 0 {
+		return nil, service.NewError(service.BadRequest, "image file is empty")
+	}
+
+	return data, nil
+}
+
+func (s *ImageService) errTooLarge() error {
+	return service.NewError(service.BadRequest, fmt.Sprintf("image exceeds the maximum size of %d bytes", s.maxUploadSize))
+}
+
+func sanitizeFilename(name string) *string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		return nil
+	}
+
+	return &name
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrImageNotFound) ||
+		errors.Is(err, storage.ErrBlobNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrInvalidBlobKey) || errors.Is(err, storage.ErrInvalidSortField) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
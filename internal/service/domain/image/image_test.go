@@ -0,0 +1,162 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newTestService(t *testing.T) (service.ImageService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewImageService(m.ImageRepo, m.BlobRepo, &config.ObjectStorageConfig{MaxUploadSize: 1024}), m
+}
+
+func TestImageService_Upload(t *testing.T) {
+	t.Run("should store file and create image with url of the object", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.Image{ID: 1}
+		var key string
+
+		// when
+		m.BlobRepo.EXPECT().Upload(context.Background(), mock.AnythingOfType("string"), "image/png", mock.Anything, int64(len(pngHeader))).
+			Run(func(_ context.Context, k, _ string, _ io.Reader, _ int64) { key = k }).
+			Return(nil)
+		m.BlobRepo.EXPECT().URL(mock.AnythingOfType("string")).Return("http://localhost/images/key.png")
+		m.ImageRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		result, err := svc.Upload(context.Background(), &entities.ImageUpload{
+			Filename: "../../damaged-tree.png",
+			Size:     int64(len(pngHeader)),
+			File:     bytes.NewReader(pngHeader),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.True(t, strings.HasSuffix(key, ".png"))
+	})
+
+	t.Run("should return bad request for unsupported file type", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Upload(context.Background(), &entities.ImageUpload{
+			Filename: "notes.txt",
+			Size:     5,
+			File:     strings.NewReader("hello"),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when announced size exceeds limit", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Upload(context.Background(), &entities.ImageUpload{
+			Size: 2048,
+			File: bytes.NewReader(pngHeader),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when file is larger than announced", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		data := append(append([]byte{}, pngHeader...), make([]byte, 2048)...)
+
+		// when
+		result, err := svc.Upload(context.Background(), &entities.ImageUpload{
+			Size: 10,
+			File: bytes.NewReader(data),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should delete stored file when image could not be created", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.BlobRepo.EXPECT().Upload(context.Background(), mock.Anything, "image/png", mock.Anything, mock.Anything).Return(nil)
+		m.BlobRepo.EXPECT().URL(mock.Anything).Return("http://localhost/images/key.png")
+		m.ImageRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		m.BlobRepo.EXPECT().Delete(context.Background(), mock.Anything).Return(nil)
+		result, err := svc.Upload(context.Background(), &entities.ImageUpload{
+			Size: int64(len(pngHeader)),
+			File: bytes.NewReader(pngHeader),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
+
+func TestImageService_Delete(t *testing.T) {
+	t.Run("should delete image and stored file", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		key := "key.png"
+
+		// when
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Image{ID: 1, ObjectKey: &key}, nil)
+		m.ImageRepo.EXPECT().Delete(context.Background(), int32(1)).Return(nil)
+		m.BlobRepo.EXPECT().Delete(context.Background(), key).Return(nil)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found when image does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrImageNotFound)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestImageService_Download(t *testing.T) {
+	t.Run("should return not found when object is missing", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.ImageRepo.EXPECT().GetByObjectKey(context.Background(), "key.png").Return(&entities.Image{ID: 1}, nil)
+		m.BlobRepo.EXPECT().Download(context.Background(), "key.png").Return(nil, storage.ErrBlobNotFound)
+		img, body, err := svc.Download(context.Background(), "key.png")
+
+		// then
+		assert.Nil(t, img)
+		assert.Nil(t, body)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
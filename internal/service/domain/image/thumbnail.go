@@ -0,0 +1,219 @@
+package image
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // register gif decoder
+	"image/jpeg"
+	"image/png"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// thumbnailSizes are the standard thumbnail sizes with the maximum length of the longest edge in pixels,
+// ordered from the largest to the smallest so every thumbnail can be scaled down from the previous one
+var thumbnailSizes = []struct {
+	size    domain.ImageThumbnailSize
+	maxEdge int
+}{
+	{domain.ImageThumbnailSizeLarge, 1024},
+	{domain.ImageThumbnailSizeMedium, 480},
+	{domain.ImageThumbnailSizeSmall, 160},
+}
+
+const thumbnailJPEGQuality = 80
+
+// maxThumbnailPixels limits the size of images decoded for thumbnails. A small compressed file can
+// declare huge dimensions and decoding it would allocate several gigabytes.
+const maxThumbnailPixels = 40_000_000
+
+var errImageTooLarge = errors.New("image dimensions exceed the thumbnail limit")
+
+type thumbnail struct {
+	size     domain.ImageThumbnailSize
+	width    int
+	height   int
+	data     []byte
+	mimeType string
+	ext      string
+}
+
+// generateThumbnails scales the image down to the standard thumbnail sizes. Sizes which are not smaller
+// than the original image are skipped. Photos are encoded as JPEG, all other images as PNG to keep transparency.
+// Image types the standard library can not decode (e.g. WebP) get no thumbnails, images with more
+// pixels than maxThumbnailPixels are rejected before they are decoded. The thumbnails are rotated and
+// flipped according to the EXIF orientation, so photos taken in portrait mode are displayed upright.
+func generateThumbnails(data []byte, mimeType string, orientation int) ([]*thumbnail, error) {
+	if mimeType == "image/webp" {
+		return nil, nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(cfg.Width)*int64(cfg.Height) > maxThumbnailPixels {
+		return nil, errImageTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	src := orient(decoded, orientation)
+
+	thumbnails := make([]*thumbnail, 0, len(thumbnailSizes))
+	for _, ts := range thumbnailSizes {
+		b := src.Bounds()
+		width, height := fitInto(b.Dx(), b.Dy(), ts.maxEdge)
+		if width >= b.Dx() && height >= b.Dy() {
+			continue
+		}
+
+		scaled := scaleDown(src, width, height)
+		t, err := encodeThumbnail(scaled, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		t.size = ts.size
+		thumbnails = append(thumbnails, t)
+
+		src = scaled
+	}
+
+	return thumbnails, nil
+}
+
+// orientedImage is a view on an image rotated or flipped according to an EXIF orientation.
+// It maps the coordinates on access, so the decoded image does not have to be copied.
+type orientedImage struct {
+	image.Image
+	orientation int
+}
+
+// orient returns the image as it is displayed with the given EXIF orientation
+func orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	return &orientedImage{Image: img, orientation: orientation}
+}
+
+// transposed reports whether width and height are swapped by the orientation
+func (o *orientedImage) transposed() bool {
+	return o.orientation >= 5
+}
+
+func (o *orientedImage) Bounds() image.Rectangle {
+	b := o.Image.Bounds()
+	if o.transposed() {
+		return image.Rect(0, 0, b.Dy(), b.Dx())
+	}
+
+	return image.Rect(0, 0, b.Dx(), b.Dy())
+}
+
+func (o *orientedImage) At(x, y int) color.Color {
+	b := o.Image.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	var sx, sy int
+	switch o.orientation {
+	case 2: // mirrored horizontally
+		sx, sy = w-1-x, y
+	case 3: // rotated by 180°
+		sx, sy = w-1-x, h-1-y
+	case 4: // mirrored vertically
+		sx, sy = x, h-1-y
+	case 5: // mirrored along the top-left to bottom-right diagonal
+		sx, sy = y, x
+	case 6: // has to be rotated by 90° clockwise
+		sx, sy = y, h-1-x
+	case 7: // mirrored along the top-right to bottom-left diagonal
+		sx, sy = w-1-y, h-1-x
+	case 8: // has to be rotated by 90° counterclockwise
+		sx, sy = w-1-y, x
+	default:
+		sx, sy = x, y
+	}
+
+	return o.Image.At(b.Min.X+sx, b.Min.Y+sy)
+}
+
+// fitInto returns the dimensions of an image scaled so its longest edge is at most maxEdge
+func fitInto(width, height, maxEdge int) (w, h int) {
+	if width <= maxEdge && height <= maxEdge {
+		return width, height
+	}
+
+	if width >= height {
+		return maxEdge, max(1, height*maxEdge/width)
+	}
+
+	return max(1, width*maxEdge/height), maxEdge
+}
+
+// scaleDown resizes the image by averaging all source pixels covered by a target pixel
+func scaleDown(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		sy0 := b.Min.Y + y*b.Dy()/height
+		sy1 := max(sy0+1, b.Min.Y+(y+1)*b.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + x*b.Dx()/width
+			sx1 := max(sx0+1, b.Min.X+(x+1)*b.Dx()/width)
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					bl += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
+
+func encodeThumbnail(img *image.RGBA, mimeType string) (*thumbnail, error) {
+	var buf bytes.Buffer
+	t := &thumbnail{
+		width:  img.Bounds().Dx(),
+		height: img.Bounds().Dy(),
+	}
+
+	if mimeType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, err
+		}
+		t.mimeType, t.ext = "image/jpeg", ".jpg"
+	} else {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+		t.mimeType, t.ext = "image/png", ".png"
+	}
+
+	t.data = buf.Bytes()
+	return t, nil
+}
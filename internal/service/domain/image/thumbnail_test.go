@@ -0,0 +1,80 @@
+package image
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateThumbnails(t *testing.T) {
+	t.Run("should generate thumbnails smaller than the original image", func(t *testing.T) {
+		// given
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 600, 300))))
+
+		// when
+		result, err := generateThumbnails(buf.Bytes(), "image/png", 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, entities.ImageThumbnailSizeMedium, result[0].size)
+		assert.Equal(t, 480, result[0].width)
+		assert.Equal(t, 240, result[0].height)
+		assert.Equal(t, entities.ImageThumbnailSizeSmall, result[1].size)
+		assert.Equal(t, 160, result[1].width)
+		assert.Equal(t, 80, result[1].height)
+		assert.Equal(t, "image/png", result[1].mimeType)
+	})
+
+	t.Run("should rotate thumbnails of photos taken in portrait mode", func(t *testing.T) {
+		// given
+		// the left half becomes the top half when the image is rotated clockwise
+		src := image.NewRGBA(image.Rect(0, 0, 600, 300))
+		draw.Draw(src, image.Rect(0, 0, 300, 300), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+		draw.Draw(src, image.Rect(300, 0, 600, 300), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, src))
+
+		// when
+		result, err := generateThumbnails(buf.Bytes(), "image/png", 6)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, 240, result[0].width)
+		assert.Equal(t, 480, result[0].height)
+
+		thumbnail, err := png.Decode(bytes.NewReader(result[0].data))
+		assert.NoError(t, err)
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, thumbnail.At(120, 10))
+		assert.Equal(t, color.RGBA{B: 255, A: 255}, thumbnail.At(120, 470))
+	})
+
+	t.Run("should not decode image with too many pixels", func(t *testing.T) {
+		// given
+		// gif header declaring a logical screen of 65535x65535 pixels without any image data
+		data := []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00")
+
+		// when
+		result, err := generateThumbnails(data, "image/gif", 1)
+
+		// then
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, errImageTooLarge)
+	})
+
+	t.Run("should return error for invalid image", func(t *testing.T) {
+		// when
+		result, err := generateThumbnails(pngHeader, "image/png", 1)
+
+		// then
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
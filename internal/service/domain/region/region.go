@@ -2,40 +2,228 @@ package region
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/go-playground/validator/v10"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/flowerbed"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/region"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
 )
 
 type RegionService struct {
-	regionRepo storage.RegionRepository
+	regionRepo      storage.RegionRepository
+	treeClusterRepo storage.TreeClusterRepository
+	flowerbedRepo   storage.FlowerbedRepository
+	unitOfWork      storage.UnitOfWork
+	validator       *validator.Validate
 }
 
-func NewRegionService(regionRepository storage.RegionRepository) service.RegionService {
+func NewRegionService(
+	regionRepository storage.RegionRepository,
+	treeClusterRepository storage.TreeClusterRepository,
+	flowerbedRepository storage.FlowerbedRepository,
+	unitOfWork storage.UnitOfWork,
+) service.RegionService {
 	return &RegionService{
-		regionRepo: regionRepository,
+		regionRepo:      regionRepository,
+		treeClusterRepo: treeClusterRepository,
+		flowerbedRepo:   flowerbedRepository,
+		unitOfWork:      unitOfWork,
+		validator:       validator.New(),
 	}
 }
 
-func (s *RegionService) GetAll(ctx context.Context) ([]*domain.Region, error) {
-	regions, err := s.regionRepo.GetAll(ctx)
+func (s *RegionService) GetAll(ctx context.Context, query domain.Query) ([]*domain.Region, int64, error) {
+	regions, total, err := s.regionRepo.GetAll(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, 0, handleError(err)
 	}
 
-	return regions, nil
+	return regions, total, nil
 }
 
 func (s *RegionService) GetByID(ctx context.Context, id int32) (*domain.Region, error) {
 	region, err := s.regionRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, handleError(err)
 	}
 
 	return region, nil
 }
 
+func (s *RegionService) Create(ctx context.Context, rc *domain.RegionCreate) (*domain.Region, error) {
+	if err := s.validate(ctx, rc, 0, rc.Name, rc.Geometry); err != nil {
+		return nil, err
+	}
+
+	var r *domain.Region
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.regionRepo.Create(ctx,
+			region.WithName(rc.Name),
+			region.WithGeometry(rc.Geometry),
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.updateRegionOfLocations(ctx, r)
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return r, nil
+}
+
+func (s *RegionService) Update(ctx context.Context, id int32, ru *domain.RegionUpdate) (*domain.Region, error) {
+	if _, err := s.regionRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := s.validate(ctx, ru, id, ru.Name, ru.Geometry); err != nil {
+		return nil, err
+	}
+
+	var r *domain.Region
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		r, err = s.regionRepo.Update(ctx, id,
+			region.WithName(ru.Name),
+			region.WithGeometry(ru.Geometry),
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.updateRegionOfLocations(ctx, r)
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return r, nil
+}
+
+// Delete removes the region. Its tree clusters and flowerbeds are left without region,
+// as regions don't overlap no other region contains them.
+func (s *RegionService) Delete(ctx context.Context, id int32) error {
+	if _, err := s.regionRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.regionRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+// validate checks the input, that the name is unique and that the geometry is valid and doesn't
+// overlap other regions. id is the id of the updated region or 0 on create.
+func (s *RegionService) validate(ctx context.Context, v any, id int32, name string, geometry domain.MultiPolygon) error {
+	if err := s.validator.Struct(v); err != nil {
+		return service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if err := geometry.Validate(); err != nil {
+		return service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	existing, err := s.regionRepo.GetByName(ctx, name)
+	if err != nil && !errors.Is(err, storage.ErrRegionNotFound) {
+		return handleError(err)
+	}
+	if existing != nil && existing.ID != id {
+		return service.NewError(service.BadRequest, fmt.Sprintf("region with name %q already exists", name))
+	}
+
+	if err := s.regionRepo.CheckGeometry(ctx, geometry); err != nil {
+		return handleError(err)
+	}
+
+	overlapping, err := s.regionRepo.GetOverlapping(ctx, geometry, id)
+	if err != nil {
+		return handleError(err)
+	}
+	if len(overlapping) > 0 {
+		return service.NewError(service.BadRequest, fmt.Sprintf("geometry overlaps region %q", overlapping[0].Name))
+	}
+
+	return nil
+}
+
+// updateRegionOfLocations assigns the tree clusters and flowerbeds that were linked to the region
+// or are located inside its new boundary to the region containing them
+func (s *RegionService) updateRegionOfLocations(ctx context.Context, r *domain.Region) error {
+	clusters, err := s.treeClusterRepo.GetByRegionOrArea(ctx, r.ID, r.Geometry)
+	if err != nil {
+		return err
+	}
+
+	for _, tc := range clusters {
+		if tc.Latitude == nil || tc.Longitude == nil {
+			continue
+		}
+
+		containing, err := s.regionRepo.GetByPoint(ctx, *tc.Latitude, *tc.Longitude)
+		if err != nil {
+			return err
+		}
+		if regionID(containing) == regionID(tc.Region) {
+			continue
+		}
+
+		if _, err := s.treeClusterRepo.Update(ctx, tc.ID, treecluster.WithRegion(containing)); err != nil {
+			return err
+		}
+	}
+
+	flowerbeds, err := s.flowerbedRepo.GetByRegionOrArea(ctx, r.ID, r.Geometry)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range flowerbeds {
+		containing, err := s.regionRepo.GetByPoint(ctx, f.Latitude, f.Longitude)
+		if err != nil {
+			return err
+		}
+		if regionID(containing) == regionID(f.Region) {
+			continue
+		}
+
+		if _, err := s.flowerbedRepo.Update(ctx, f.ID, flowerbed.WithRegion(containing)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func regionID(r *domain.Region) int32 {
+	if r == nil {
+		return 0
+	}
+	return r.ID
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrRegionNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrInvalidSortField) || errors.Is(err, storage.ErrInvalidGeometry) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
+
 func (s *RegionService) Ready() bool {
 	return s.regionRepo != nil
 }
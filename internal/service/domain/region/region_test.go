@@ -0,0 +1,211 @@
+package region
+
+import (
+	"context"
+	"fmt"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.RegionService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewRegionService(m.RegionRepo, m.TreeClusterRepo, m.FlowerbedRepo, m.UnitOfWork), m
+}
+
+var square = entities.MultiPolygon{{{
+	{9.40, 54.78}, {9.45, 54.78}, {9.45, 54.80}, {9.40, 54.80}, {9.40, 54.78},
+}}}
+
+func TestRegionService_GetByID(t *testing.T) {
+	t.Run("should return region", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.Region{ID: 1, Name: "Mürwik", Geometry: square}
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(expected, nil)
+		region, err := svc.GetByID(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, region)
+	})
+
+	t.Run("should return not found if region doesn't exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrRegionNotFound)
+		region, err := svc.GetByID(context.Background(), 1)
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestRegionService_Create(t *testing.T) {
+	t.Run("should create region and assign clusters and flowerbeds inside of it", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		created := &entities.Region{ID: 3, Name: "Mürwik", Geometry: square}
+		inside := &entities.TreeCluster{ID: 1, Latitude: utils.P(54.79), Longitude: utils.P(9.42)}
+		unchanged := &entities.TreeCluster{ID: 2, Latitude: utils.P(54.79), Longitude: utils.P(9.43), Region: created}
+		withoutPosition := &entities.TreeCluster{ID: 4}
+		flowerbed := &entities.Flowerbed{ID: 5, Latitude: 54.79, Longitude: 9.44}
+
+		// when
+		m.RegionRepo.EXPECT().GetByName(context.Background(), "Mürwik").Return(nil, storage.ErrRegionNotFound)
+		m.RegionRepo.EXPECT().CheckGeometry(context.Background(), square).Return(nil)
+		m.RegionRepo.EXPECT().GetOverlapping(context.Background(), square, int32(0)).Return(nil, nil)
+		m.RunInTx()
+		m.RegionRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything).Return(created, nil)
+		m.TreeClusterRepo.EXPECT().GetByRegionOrArea(context.Background(), int32(3), square).
+			Return([]*entities.TreeCluster{inside, unchanged, withoutPosition}, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.79, 9.42).Return(created, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.79, 9.43).Return(created, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything).Return(inside, nil)
+		m.FlowerbedRepo.EXPECT().GetByRegionOrArea(context.Background(), int32(3), square).
+			Return([]*entities.Flowerbed{flowerbed}, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.79, 9.44).Return(created, nil)
+		m.FlowerbedRepo.EXPECT().Update(context.Background(), int32(5), mock.Anything).Return(flowerbed, nil)
+		region, err := svc.Create(context.Background(), &entities.RegionCreate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, created, region)
+	})
+
+	t.Run("should return bad request if name is already used", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByName(context.Background(), "Mürwik").Return(&entities.Region{ID: 1, Name: "Mürwik"}, nil)
+		region, err := svc.Create(context.Background(), &entities.RegionCreate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request if geometry is not closed", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		open := entities.MultiPolygon{{{{9.40, 54.78}, {9.45, 54.78}, {9.45, 54.80}, {9.40, 54.80}}}}
+
+		// when
+		region, err := svc.Create(context.Background(), &entities.RegionCreate{Name: "Mürwik", Geometry: open})
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request if geometry is invalid", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByName(context.Background(), "Mürwik").Return(nil, storage.ErrRegionNotFound)
+		m.RegionRepo.EXPECT().CheckGeometry(context.Background(), square).
+			Return(fmt.Errorf("%w: %s", storage.ErrInvalidGeometry, "Self-intersection"))
+		region, err := svc.Create(context.Background(), &entities.RegionCreate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request if geometry overlaps other region", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByName(context.Background(), "Mürwik").Return(nil, storage.ErrRegionNotFound)
+		m.RegionRepo.EXPECT().CheckGeometry(context.Background(), square).Return(nil)
+		m.RegionRepo.EXPECT().GetOverlapping(context.Background(), square, int32(0)).
+			Return([]*entities.Region{{ID: 1, Name: "Innenstadt"}}, nil)
+		region, err := svc.Create(context.Background(), &entities.RegionCreate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+		assert.ErrorContains(t, err, "Innenstadt")
+	})
+}
+
+func TestRegionService_Update(t *testing.T) {
+	t.Run("should update region and unassign clusters outside of its boundary", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		updated := &entities.Region{ID: 1, Name: "Mürwik", Geometry: square}
+		outside := &entities.TreeCluster{ID: 1, Latitude: utils.P(54.81), Longitude: utils.P(9.42), Region: updated}
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Region{ID: 1, Name: "Mürwik"}, nil)
+		m.RegionRepo.EXPECT().GetByName(context.Background(), "Mürwik").Return(&entities.Region{ID: 1, Name: "Mürwik"}, nil)
+		m.RegionRepo.EXPECT().CheckGeometry(context.Background(), square).Return(nil)
+		m.RegionRepo.EXPECT().GetOverlapping(context.Background(), square, int32(1)).Return(nil, nil)
+		m.RunInTx()
+		m.RegionRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything).Return(updated, nil)
+		m.TreeClusterRepo.EXPECT().GetByRegionOrArea(context.Background(), int32(1), square).
+			Return([]*entities.TreeCluster{outside}, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.81, 9.42).Return(nil, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything).Return(outside, nil)
+		m.FlowerbedRepo.EXPECT().GetByRegionOrArea(context.Background(), int32(1), square).Return(nil, nil)
+		region, err := svc.Update(context.Background(), 1, &entities.RegionUpdate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updated, region)
+	})
+
+	t.Run("should return not found if region doesn't exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrRegionNotFound)
+		region, err := svc.Update(context.Background(), 1, &entities.RegionUpdate{Name: "Mürwik", Geometry: square})
+
+		// then
+		assert.Nil(t, region)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestRegionService_Delete(t *testing.T) {
+	t.Run("should delete region", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Region{ID: 1}, nil)
+		m.RegionRepo.EXPECT().Delete(context.Background(), int32(1)).Return(nil)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found if region doesn't exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RegionRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrRegionNotFound)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
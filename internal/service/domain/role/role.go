@@ -0,0 +1,59 @@
+package role
+
+import (
+	"context"
+	"errors"
+
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type RoleService struct {
+	roleRepo storage.RoleRepository
+}
+
+func NewRoleService(roleRepository storage.RoleRepository) service.RoleService {
+	return &RoleService{
+		roleRepo: roleRepository,
+	}
+}
+
+func (s *RoleService) GetAll(ctx context.Context) ([]*domain.Role, error) {
+	roles, err := s.roleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return roles, nil
+}
+
+func (s *RoleService) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return role, nil
+}
+
+func (s *RoleService) GetUsers(ctx context.Context, name string) ([]*domain.User, error) {
+	users, err := s.roleRepo.GetUsers(ctx, name)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return users, nil
+}
+
+func (s *RoleService) Ready() bool {
+	return s.roleRepo != nil
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrRoleNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
@@ -0,0 +1,62 @@
+package role
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleService_GetAll(t *testing.T) {
+	t.Run("should return all roles", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRoleRepository(t)
+		svc := NewRoleService(repo)
+		expected := []*entities.Role{{Name: "tbz", Description: "Staff of the TBZ"}}
+
+		// when
+		repo.EXPECT().GetAll(context.Background()).Return(expected, nil)
+		roles, err := svc.GetAll(context.Background())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, roles)
+	})
+}
+
+func TestRoleService_GetByName(t *testing.T) {
+	t.Run("should return not found when role does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRoleRepository(t)
+		svc := NewRoleService(repo)
+
+		// when
+		repo.EXPECT().GetByName(context.Background(), "unknown").Return(nil, storage.ErrRoleNotFound)
+		role, err := svc.GetByName(context.Background(), "unknown")
+
+		// then
+		assert.Nil(t, role)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestRoleService_GetUsers(t *testing.T) {
+	t.Run("should return internal error when users could not be loaded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockRoleRepository(t)
+		svc := NewRoleService(repo)
+
+		// when
+		repo.EXPECT().GetUsers(context.Background(), "driver").Return(nil, assert.AnError)
+		users, err := svc.GetUsers(context.Background(), "driver")
+
+		// then
+		assert.Nil(t, users)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
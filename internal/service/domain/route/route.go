@@ -0,0 +1,123 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type RouteService struct {
+	treeClusterRepo  storage.TreeClusterRepository
+	vehicleRepo      storage.VehicleRepository
+	wateringPlanRepo storage.WateringPlanRepository
+	waterPerTree     float64
+	cfg              *config.RoutingConfig
+	validator        *validator.Validate
+}
+
+func NewRouteService(
+	treeClusterRepo storage.TreeClusterRepository,
+	vehicleRepo storage.VehicleRepository,
+	wateringPlanRepo storage.WateringPlanRepository,
+	wateringCfg *config.WateringConfig,
+	routingCfg *config.RoutingConfig,
+) service.RouteService {
+	return &RouteService{
+		treeClusterRepo:  treeClusterRepo,
+		vehicleRepo:      vehicleRepo,
+		wateringPlanRepo: wateringPlanRepo,
+		waterPerTree:     wateringCfg.WaterPerTree,
+		cfg:              routingCfg,
+		validator:        validator.New(),
+	}
+}
+
+func (s *RouteService) Calculate(ctx context.Context, rc *domain.RouteCreate) (*domain.Route, error) {
+	if err := s.validator.Struct(rc); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	vehicle, err := s.vehicleRepo.GetByID(ctx, rc.VehicleID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return s.calculate(ctx, vehicle, rc.TreeClusterIDs)
+}
+
+func (s *RouteService) CalculateForWateringPlan(ctx context.Context, id int32) (*domain.Route, error) {
+	wp, err := s.wateringPlanRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if wp.Vehicle == nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("watering plan %d has no vehicle", id))
+	}
+
+	if len(wp.TreeClusters) == 0 {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("watering plan %d has no tree clusters", id))
+	}
+
+	treeClusterIDs := make([]int32, len(wp.TreeClusters))
+	for i, tc := range wp.TreeClusters {
+		treeClusterIDs[i] = tc.ID
+	}
+
+	return s.calculate(ctx, wp.Vehicle, treeClusterIDs)
+}
+
+func (s *RouteService) Ready() bool {
+	return s.treeClusterRepo != nil && s.vehicleRepo != nil && s.wateringPlanRepo != nil && s.cfg != nil
+}
+
+// calculate loads the tree clusters including their trees and plans the tour for the vehicle
+func (s *RouteService) calculate(ctx context.Context, vehicle *domain.Vehicle, treeClusterIDs []int32) (*domain.Route, error) {
+	if vehicle.WaterCapacity <= 0 {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("vehicle %d has no water capacity", vehicle.ID))
+	}
+
+	visits := make([]*visit, len(treeClusterIDs))
+	for i, tcID := range treeClusterIDs {
+		tc, err := s.treeClusterRepo.GetByID(ctx, tcID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+
+		if tc.Archived {
+			return nil, service.NewError(service.BadRequest, fmt.Sprintf("tree cluster %d is archived", tcID))
+		}
+
+		if tc.Latitude == nil || tc.Longitude == nil {
+			return nil, service.NewError(service.BadRequest, fmt.Sprintf("tree cluster %d has no location", tcID))
+		}
+
+		visits[i] = &visit{
+			treeCluster: tc,
+			pos:         point{lat: *tc.Latitude, lon: *tc.Longitude},
+			demand:      float64(len(tc.Trees)) * s.waterPerTree,
+		}
+	}
+
+	route := newSolver(s.cfg, vehicle.WaterCapacity).solve(visits)
+	route.Vehicle = vehicle
+
+	return route, nil
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrWateringPlanNotFound) ||
+		errors.Is(err, storage.ErrVehicleNotFound) ||
+		errors.Is(err, storage.ErrTreeClusterNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
@@ -0,0 +1,143 @@
+package route
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+var routingCfg = config.RoutingConfig{
+	Depot: config.WaterPointConfig{Name: "Depot", Latitude: 54.0, Longitude: 9.0},
+	WaterPoints: []config.WaterPointConfig{
+		{Name: "Depot", Latitude: 54.0, Longitude: 9.0},
+		{Name: "Hydrant", Latitude: 54.0, Longitude: 9.025},
+	},
+	RoadFactor:   1,
+	AverageSpeed: 36,
+}
+
+func newTestService(t *testing.T) (service.RouteService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	wateringCfg := &config.WateringConfig{WaterPerTree: 80}
+	return NewRouteService(m.TreeClusterRepo, m.VehicleRepo, m.WateringPlanRepo, wateringCfg, &routingCfg), m
+}
+
+func cluster(id int32, lon float64, trees int) *entities.TreeCluster {
+	lat := 54.0
+	tc := &entities.TreeCluster{ID: id, Latitude: &lat, Longitude: &lon}
+	for i := 0; i < trees; i++ {
+		tc.Trees = append(tc.Trees, &entities.Tree{ID: int32(i)})
+	}
+	return tc
+}
+
+func stopTypes(route *entities.Route) []entities.RouteStopType {
+	types := make([]entities.RouteStopType, len(route.Stops))
+	for i, stop := range route.Stops {
+		types[i] = stop.Type
+	}
+	return types
+}
+
+func TestRouteService_Calculate(t *testing.T) {
+	t.Run("should visit nearest tree clusters first and refill when the tank runs empty", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		vehicle := &entities.Vehicle{ID: 1, WaterCapacity: 200}
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(vehicle, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(cluster(1, 9.02, 1), nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(cluster(2, 9.03, 1), nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(3)).Return(cluster(3, 9.01, 1), nil)
+		result, err := svc.Calculate(context.Background(), &entities.RouteCreate{VehicleID: 1, TreeClusterIDs: []int32{1, 2, 3}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, vehicle, result.Vehicle)
+		assert.Equal(t, []entities.RouteStopType{
+			entities.RouteStopTypeDepot,
+			entities.RouteStopTypeTreeCluster,
+			entities.RouteStopTypeTreeCluster,
+			entities.RouteStopTypeRefill,
+			entities.RouteStopTypeTreeCluster,
+			entities.RouteStopTypeDepot,
+		}, stopTypes(result))
+		assert.Equal(t, int32(3), result.Stops[1].TreeCluster.ID)
+		assert.Equal(t, int32(1), result.Stops[2].TreeCluster.ID)
+		assert.Equal(t, "Hydrant", result.Stops[3].WaterPoint.Name)
+		assert.Equal(t, int32(2), result.Stops[4].TreeCluster.ID)
+		assert.Equal(t, int32(1), result.Refills)
+		assert.Equal(t, 240.0, result.TotalWaterRequired)
+		// 0.03 degrees of longitude at 54° north are about 1960 m, driven there and back
+		assert.InDelta(t, 3920, result.Distance, 10)
+		assert.InDelta(t, 392, result.Duration.Seconds(), 1)
+	})
+
+	t.Run("should water tree cluster in several rounds when it needs more than a full tank", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1, WaterCapacity: 100}, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(cluster(1, 9.01, 3), nil)
+		result, err := svc.Calculate(context.Background(), &entities.RouteCreate{VehicleID: 1, TreeClusterIDs: []int32{1}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), result.Refills)
+		assert.Equal(t, 240.0, result.TotalWaterRequired)
+		assert.Equal(t, 100.0, result.Stops[1].WaterRequired)
+		assert.Equal(t, 100.0, result.Stops[3].WaterRequired)
+		assert.Equal(t, 40.0, result.Stops[5].WaterRequired)
+	})
+
+	t.Run("should return bad request when tree cluster has no location", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1, WaterCapacity: 100}, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+		result, err := svc.Calculate(context.Background(), &entities.RouteCreate{VehicleID: 1, TreeClusterIDs: []int32{1}})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when vehicle does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrVehicleNotFound)
+		result, err := svc.Calculate(context.Background(), &entities.RouteCreate{VehicleID: 1, TreeClusterIDs: []int32{1}})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestRouteService_CalculateForWateringPlan(t *testing.T) {
+	t.Run("should return bad request when watering plan has no vehicle", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.WateringPlanRepo.EXPECT().GetByID(context.Background(), int32(1)).
+			Return(&entities.WateringPlan{ID: 1, TreeClusters: []*entities.TreeCluster{{ID: 1}}}, nil)
+		result, err := svc.CalculateForWateringPlan(context.Background(), 1)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
@@ -0,0 +1,201 @@
+package route
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+type point struct {
+	lat, lon float64
+}
+
+// visit is a tree cluster which has to be visited on the tour with the water it needs
+type visit struct {
+	treeCluster *domain.TreeCluster
+	pos         point
+	demand      float64
+}
+
+// solver plans watering tours without an external routing service. The visiting order is built
+// with the nearest neighbour heuristic and improved with 2-opt, refill stops are inserted afterwards
+// whenever the tank does not hold enough water for the next tree cluster.
+type solver struct {
+	depot          *domain.WaterPoint
+	waterPoints    []*domain.WaterPoint
+	roadFactor     float64
+	averageSpeed   float64
+	treeDuration   time.Duration
+	refillDuration time.Duration
+	capacity       float64
+}
+
+func newSolver(cfg *config.RoutingConfig, capacity float64) *solver {
+	depot := &domain.WaterPoint{Name: cfg.Depot.Name, Latitude: cfg.Depot.Latitude, Longitude: cfg.Depot.Longitude}
+
+	waterPoints := make([]*domain.WaterPoint, len(cfg.WaterPoints))
+	for i, wp := range cfg.WaterPoints {
+		waterPoints[i] = &domain.WaterPoint{Name: wp.Name, Latitude: wp.Latitude, Longitude: wp.Longitude}
+	}
+	if len(waterPoints) == 0 {
+		waterPoints = []*domain.WaterPoint{depot}
+	}
+
+	roadFactor := cfg.RoadFactor
+	if roadFactor < 1 {
+		roadFactor = 1
+	}
+
+	return &solver{
+		depot:          depot,
+		waterPoints:    waterPoints,
+		roadFactor:     roadFactor,
+		averageSpeed:   cfg.AverageSpeed,
+		treeDuration:   cfg.TreeDuration,
+		refillDuration: cfg.RefillDuration,
+		capacity:       capacity,
+	}
+}
+
+// solve returns the tour visiting all tree clusters, starting and ending at the depot with a full tank
+func (s *solver) solve(visits []*visit) *domain.Route {
+	ordered := s.improve(s.nearestNeighbour(visits))
+
+	route := &domain.Route{}
+	start := waterPointPos(s.depot)
+	s.addStop(route, start, &domain.RouteStop{Type: domain.RouteStopTypeDepot, WaterPoint: s.depot})
+
+	cur, load := start, s.capacity
+	for _, v := range ordered {
+		remaining := v.demand
+		for {
+			// refill before the cluster if the tank can not deliver what the cluster needs,
+			// clusters needing more than a full tank are watered in several rounds
+			if load < min(remaining, s.capacity) {
+				wp := s.nearestWaterPoint(cur, v.pos)
+				s.addStop(route, cur, &domain.RouteStop{Type: domain.RouteStopTypeRefill, WaterPoint: wp})
+				cur, load = waterPointPos(wp), s.capacity
+				route.Refills++
+			}
+
+			deliver := min(load, remaining)
+			s.addStop(route, cur, &domain.RouteStop{
+				Type:          domain.RouteStopTypeTreeCluster,
+				TreeCluster:   v.treeCluster,
+				Latitude:      v.pos.lat,
+				Longitude:     v.pos.lon,
+				WaterRequired: deliver,
+			})
+			cur = v.pos
+			load -= deliver
+			remaining -= deliver
+			route.TotalWaterRequired += deliver
+
+			if remaining <= 0 {
+				break
+			}
+		}
+		route.Duration += time.Duration(len(v.treeCluster.Trees)) * s.treeDuration
+	}
+
+	s.addStop(route, cur, &domain.RouteStop{Type: domain.RouteStopTypeDepot, WaterPoint: s.depot})
+
+	if s.averageSpeed > 0 {
+		route.Duration += time.Duration(route.Distance / (s.averageSpeed / 3.6) * float64(time.Second))
+	}
+	route.Duration += time.Duration(route.Refills) * s.refillDuration
+
+	return route
+}
+
+// addStop appends the stop and adds the distance from the previous position to the route
+func (s *solver) addStop(route *domain.Route, from point, stop *domain.RouteStop) {
+	if stop.WaterPoint != nil {
+		stop.Latitude, stop.Longitude = stop.WaterPoint.Latitude, stop.WaterPoint.Longitude
+	}
+
+	if len(route.Stops) > 0 {
+		stop.Distance = s.distance(from, point{lat: stop.Latitude, lon: stop.Longitude})
+		route.Distance += stop.Distance
+	}
+
+	route.Stops = append(route.Stops, stop)
+}
+
+// nearestNeighbour orders the visits by always driving to the nearest unvisited tree cluster
+func (s *solver) nearestNeighbour(visits []*visit) []*visit {
+	left := make([]*visit, len(visits))
+	copy(left, visits)
+
+	ordered := make([]*visit, 0, len(visits))
+	cur := waterPointPos(s.depot)
+	for len(left) > 0 {
+		next := 0
+		for i := 1; i < len(left); i++ {
+			if s.distance(cur, left[i].pos) < s.distance(cur, left[next].pos) {
+				next = i
+			}
+		}
+
+		ordered = append(ordered, left[next])
+		cur = left[next].pos
+		left = append(left[:next], left[next+1:]...)
+	}
+
+	return ordered
+}
+
+// improve applies 2-opt to the tour from and back to the depot by reversing parts of the tour
+// as long as this makes the tour shorter
+func (s *solver) improve(visits []*visit) []*visit {
+	depot := waterPointPos(s.depot)
+	pos := func(i int) point {
+		if i < 0 || i >= len(visits) {
+			return depot
+		}
+		return visits[i].pos
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < len(visits)-1; i++ {
+			for j := i + 1; j < len(visits); j++ {
+				before := s.distance(pos(i-1), pos(i)) + s.distance(pos(j), pos(j+1))
+				after := s.distance(pos(i-1), pos(j)) + s.distance(pos(i), pos(j+1))
+				if after < before-1e-6 {
+					slices.Reverse(visits[i : j+1])
+					improved = true
+				}
+			}
+		}
+	}
+
+	return visits
+}
+
+// nearestWaterPoint returns the water point with the smallest detour between two positions
+func (s *solver) nearestWaterPoint(from, to point) *domain.WaterPoint {
+	var best *domain.WaterPoint
+	bestDistance := math.Inf(1)
+	for _, wp := range s.waterPoints {
+		d := s.distance(from, waterPointPos(wp)) + s.distance(waterPointPos(wp), to)
+		if d < bestDistance {
+			best, bestDistance = wp, d
+		}
+	}
+
+	return best
+}
+
+// distance estimates the distance on roads in meters from the great circle distance
+func (s *solver) distance(a, b point) float64 {
+	return utils.HaversineDistance(a.lat, a.lon, b.lat, b.lon) * s.roadFactor
+}
+
+func waterPointPos(wp *domain.WaterPoint) point {
+	return point{lat: wp.Latitude, lon: wp.Longitude}
+}
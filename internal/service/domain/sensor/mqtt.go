@@ -2,24 +2,92 @@ package sensor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 
+	"github.com/green-ecolution/green-ecolution-backend/config"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor"
 )
 
 type MqttService struct {
-	sensorRepo  storage.SensorRepository
-	isConnected bool
+	sensorRepo      storage.SensorRepository
+	treeRepo        storage.TreeRepository
+	treeClusterRepo storage.TreeClusterRepository
+	wateringCfg     *config.WateringConfig
+	isConnected     bool
 }
 
-func NewMqttService(sensorRepository storage.SensorRepository) *MqttService {
-	return &MqttService{sensorRepo: sensorRepository}
+func NewMqttService(
+	sensorRepository storage.SensorRepository,
+	treeRepository storage.TreeRepository,
+	treeClusterRepository storage.TreeClusterRepository,
+	wateringCfg *config.WateringConfig,
+) *MqttService {
+	return &MqttService{
+		sensorRepo:      sensorRepository,
+		treeRepo:        treeRepository,
+		treeClusterRepo: treeClusterRepository,
+		wateringCfg:     wateringCfg,
+	}
 }
 
-func (s *MqttService) HandleMessage(_ context.Context, _ *domain.MqttPayload) (*domain.MqttPayload, error) {
-	// TODO: Implement the business logic of HandleMessage
+// HandleMessage stores an uplink payload for the sensor identified by the TTN end device id
+// (or its DevEUI as fallback) and marks the sensor as online. Afterwards the watering status of the
+// tree cluster the sensor belongs to is recalculated. The payload is stored at this point, so a failed
+// recalculation is only logged and caught up with the next uplink. Payloads of unknown devices are rejected.
+func (s *MqttService) HandleMessage(ctx context.Context, payload *domain.MqttPayload) (*domain.MqttPayload, error) {
+	if payload == nil {
+		return nil, service.NewError(service.BadRequest, "mqtt payload is empty")
+	}
 
-	return nil, nil
+	deviceID := payload.EndDeviceIDs.DeviceID
+	devEUI := payload.EndDeviceIDs.DevEUI
+	if deviceID == "" && devEUI == "" {
+		return nil, service.NewError(service.BadRequest, "mqtt payload has no device id")
+	}
+
+	sens, err := s.findSensor(ctx, deviceID, devEUI)
+	if err != nil {
+		if errors.Is(err, storage.ErrSensorNotFound) {
+			return nil, service.NewError(service.NotFound, fmt.Sprintf("unknown device: %s (dev_eui: %s)", deviceID, devEUI))
+		}
+		return nil, service.NewError(service.InternalError, err.Error())
+	}
+
+	data := []*domain.SensorData{{Data: payload}}
+	if _, err := s.sensorRepo.InsertSensorData(ctx, sens.ID, data); err != nil {
+		return nil, service.NewError(service.InternalError, err.Error())
+	}
+
+	if sens.Status != domain.SensorStatusOnline {
+		slog.Debug("Set sensor status to online", "sensor_id", sens.ID, "device_id", deviceID)
+		if _, err := s.sensorRepo.Update(ctx, sens.ID, sensor.WithStatus(domain.SensorStatusOnline)); err != nil {
+			return nil, service.NewError(service.InternalError, err.Error())
+		}
+	}
+
+	if err := s.updateWateringStatus(ctx, sens.ID); err != nil {
+		slog.Error("failed to update watering status", "sensor_id", sens.ID, "error", err)
+	}
+
+	return payload, nil
+}
+
+// findSensor looks up the sensor by its TTN device id and falls back to the DevEUI
+// if no sensor is registered with that device id.
+func (s *MqttService) findSensor(ctx context.Context, deviceID, devEUI string) (*domain.Sensor, error) {
+	if deviceID != "" {
+		sens, err := s.sensorRepo.GetByDeviceID(ctx, deviceID)
+		if err == nil || !errors.Is(err, storage.ErrSensorNotFound) || devEUI == "" {
+			return sens, err
+		}
+	}
+
+	return s.sensorRepo.GetByDevEUI(ctx, devEUI)
 }
 
 func (s *MqttService) SetConnected(ready bool) {
@@ -1,18 +1,172 @@
 package sensor
 
 import (
+	"context"
 	"testing"
 
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewSensorService(t *testing.T) {
 	repo := storageMock.NewMockSensorRepository(t)
+	treeRepo := storageMock.NewMockTreeRepository(t)
+	tcRepo := storageMock.NewMockTreeClusterRepository(t)
 	t.Run("should create a new service", func(t *testing.T) {
-		svc := NewMqttService(repo)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
 		assert.NotNil(t, svc)
 	})
 }
 
-// other test cases
+func TestHandleMessage(t *testing.T) {
+	payload := &entities.MqttPayload{
+		EndDeviceIDs: entities.MqttIdentifierDeviceID{
+			DeviceID: "tree-sensor-01",
+			DevEUI:   "0004A30B001C1B2A",
+		},
+		UplinkMessage: entities.MqttUplinkMessage{
+			DecodedPayload: entities.MqttDecodedPayload{
+				Battery:  3.4,
+				Humidity: 42,
+			},
+		},
+	}
+
+	t.Run("should store payload and set sensor online", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+		sensor := &entities.Sensor{ID: 1, Status: entities.SensorStatusOffline}
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(sensor, nil)
+		repo.EXPECT().InsertSensorData(context.Background(), int32(1), []*entities.SensorData{{Data: payload}}).Return(nil, nil)
+		repo.EXPECT().Update(context.Background(), int32(1), mock.Anything).Return(sensor, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(nil, storage.ErrTreeNotFound)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload, resp)
+	})
+
+	t.Run("should not update status when sensor is already online", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+		sensor := &entities.Sensor{ID: 1, Status: entities.SensorStatusOnline}
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(sensor, nil)
+		repo.EXPECT().InsertSensorData(context.Background(), int32(1), mock.Anything).Return(nil, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(nil, storage.ErrTreeNotFound)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload, resp)
+	})
+
+	t.Run("should return payload when watering status can't be updated", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+		sensor := &entities.Sensor{ID: 1, Status: entities.SensorStatusOnline}
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(sensor, nil)
+		repo.EXPECT().InsertSensorData(context.Background(), int32(1), mock.Anything).Return(nil, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(nil, assert.AnError)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload, resp)
+	})
+
+	t.Run("should return not found error when device is unknown", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(nil, storage.ErrSensorNotFound)
+		repo.EXPECT().GetByDevEUI(context.Background(), "0004A30B001C1B2A").Return(nil, storage.ErrSensorNotFound)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.Nil(t, resp)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.NotFound, svcErr.Code)
+	})
+
+	t.Run("should fall back to dev eui when device id is unknown", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+		sensor := &entities.Sensor{ID: 2, Status: entities.SensorStatusOnline}
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(nil, storage.ErrSensorNotFound)
+		repo.EXPECT().GetByDevEUI(context.Background(), "0004A30B001C1B2A").Return(sensor, nil)
+		repo.EXPECT().InsertSensorData(context.Background(), int32(2), mock.Anything).Return(nil, nil)
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(2)).Return(nil, storage.ErrTreeNotFound)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, payload, resp)
+	})
+
+	t.Run("should return error when storing data failed", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+		sensor := &entities.Sensor{ID: 1, Status: entities.SensorStatusOnline}
+
+		// when
+		repo.EXPECT().GetByDeviceID(context.Background(), "tree-sensor-01").Return(sensor, nil)
+		repo.EXPECT().InsertSensorData(context.Background(), int32(1), mock.Anything).Return(nil, assert.AnError)
+		resp, err := svc.HandleMessage(context.Background(), payload)
+
+		// then
+		assert.Nil(t, resp)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.InternalError, svcErr.Code)
+	})
+
+	t.Run("should return bad request when payload has no device id", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		resp, err := svc.HandleMessage(context.Background(), &entities.MqttPayload{})
+
+		// then
+		assert.Nil(t, resp)
+		var svcErr service.Error
+		assert.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, service.BadRequest, svcErr.Code)
+	})
+}
@@ -0,0 +1,101 @@
+package sensor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
+)
+
+// updateWateringStatus recalculates the moisture level and watering status of the tree cluster
+// the sensor is mounted in. Sensors without a tree or trees without a cluster are skipped.
+func (s *MqttService) updateWateringStatus(ctx context.Context, sensorID int32) error {
+	tree, err := s.treeRepo.GetBySensorID(ctx, sensorID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTreeNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if tree.TreeCluster == nil {
+		return nil
+	}
+	tc := tree.TreeCluster
+
+	trees, err := s.treeRepo.GetByTreeClusterID(ctx, tc.ID)
+	if err != nil {
+		return err
+	}
+
+	readings := make([]float64, 0, len(trees))
+	for _, t := range trees {
+		if t.Sensor == nil {
+			continue
+		}
+
+		data, err := s.sensorRepo.GetLatestSensorDataByID(ctx, t.Sensor.ID)
+		if err != nil {
+			if errors.Is(err, storage.ErrSensorDataNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if data.Data != nil {
+			readings = append(readings, float64(data.Data.GetHumidity()))
+		}
+	}
+
+	moisture, status := tc.MoistureLevel, domain.TreeClusterWateringStatusUnknown
+	if len(readings) > 0 {
+		moisture = average(readings)
+		status = calculateWateringStatus(moisture, s.thresholds(tc.SoilCondition))
+	}
+
+	slog.Debug("Update watering status of tree cluster", "tree_cluster_id", tc.ID, "moisture_level", moisture, "watering_status", status)
+	_, err = s.treeClusterRepo.Update(ctx, tc.ID,
+		treecluster.WithMoistureLevel(moisture),
+		treecluster.WithWateringStatus(status),
+	)
+
+	return err
+}
+
+func (s *MqttService) thresholds(soilCondition domain.TreeSoilCondition) config.MoistureThresholdConfig {
+	if s.wateringCfg == nil {
+		return config.MoistureThresholdConfig{}
+	}
+
+	if t, ok := s.wateringCfg.Thresholds[string(soilCondition)]; ok {
+		return t
+	}
+
+	return s.wateringCfg.Default
+}
+
+func calculateWateringStatus(moisture float64, t config.MoistureThresholdConfig) domain.TreeClusterWateringStatus {
+	switch {
+	case t.Good == 0 && t.Moderate == 0:
+		return domain.TreeClusterWateringStatusUnknown
+	case moisture >= t.Good:
+		return domain.TreeClusterWateringStatusGood
+	case moisture >= t.Moderate:
+		return domain.TreeClusterWateringStatusModerate
+	default:
+		return domain.TreeClusterWateringStatusBad
+	}
+}
+
+func average(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
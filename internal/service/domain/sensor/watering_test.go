@@ -0,0 +1,153 @@
+package sensor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var wateringCfg = config.WateringConfig{
+	Default: config.MoistureThresholdConfig{Good: 30, Moderate: 20},
+	Thresholds: map[string]config.MoistureThresholdConfig{
+		"sandig": {Good: 15, Moderate: 8},
+		"tonig":  {Good: 35, Moderate: 25},
+	},
+}
+
+func TestCalculateWateringStatus(t *testing.T) {
+	svc := NewMqttService(nil, nil, nil, &wateringCfg)
+
+	tests := []struct {
+		name     string
+		moisture float64
+		soil     entities.TreeSoilCondition
+		want     entities.TreeClusterWateringStatus
+	}{
+		{"sandy soil with good moisture", 16, entities.TreeSoilConditionSandig, entities.TreeClusterWateringStatusGood},
+		{"sandy soil with moderate moisture", 10, entities.TreeSoilConditionSandig, entities.TreeClusterWateringStatusModerate},
+		{"sandy soil with low moisture", 5, entities.TreeSoilConditionSandig, entities.TreeClusterWateringStatusBad},
+		{"clay soil with same moisture is bad", 16, entities.TreeSoilConditionTonig, entities.TreeClusterWateringStatusBad},
+		{"clay soil with good moisture", 40, entities.TreeSoilConditionTonig, entities.TreeClusterWateringStatusGood},
+		{"unknown soil uses default thresholds", 25, entities.TreeSoilConditionUnknown, entities.TreeClusterWateringStatusModerate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateWateringStatus(tt.moisture, svc.thresholds(tt.soil))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should return unknown without thresholds", func(t *testing.T) {
+		got := calculateWateringStatus(50, config.MoistureThresholdConfig{})
+		assert.Equal(t, entities.TreeClusterWateringStatusUnknown, got)
+	})
+}
+
+func TestUpdateWateringStatus(t *testing.T) {
+	cluster := &entities.TreeCluster{ID: 1, SoilCondition: entities.TreeSoilConditionSandig}
+	trees := []*entities.Tree{
+		{ID: 1, TreeCluster: cluster, Sensor: &entities.Sensor{ID: 1}},
+		{ID: 2, TreeCluster: cluster, Sensor: &entities.Sensor{ID: 2}},
+		{ID: 3, TreeCluster: cluster},
+	}
+
+	sensorData := func(humidity int) *entities.SensorData {
+		return &entities.SensorData{Data: &entities.MqttPayload{
+			UplinkMessage: entities.MqttUplinkMessage{
+				DecodedPayload: entities.MqttDecodedPayload{Humidity: humidity},
+			},
+		}}
+	}
+
+	t.Run("should update tree cluster with average moisture of all sensors", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(trees[0], nil)
+		treeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return(trees, nil)
+		repo.EXPECT().GetLatestSensorDataByID(context.Background(), int32(1)).Return(sensorData(10), nil)
+		repo.EXPECT().GetLatestSensorDataByID(context.Background(), int32(2)).Return(sensorData(14), nil)
+		tcRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ int32, fn ...entities.EntityFunc[entities.TreeCluster]) (*entities.TreeCluster, error) {
+				tc := &entities.TreeCluster{}
+				for _, f := range fn {
+					f(tc)
+				}
+				assert.Equal(t, 12.0, tc.MoistureLevel)
+				assert.Equal(t, entities.TreeClusterWateringStatusModerate, tc.WateringStatus)
+				return tc, nil
+			})
+		err := svc.updateWateringStatus(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should set status unknown when no sensor has data", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(trees[0], nil)
+		treeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return(trees, nil)
+		repo.EXPECT().GetLatestSensorDataByID(context.Background(), mock.Anything).Return(nil, storage.ErrSensorDataNotFound)
+		tcRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ int32, fn ...entities.EntityFunc[entities.TreeCluster]) (*entities.TreeCluster, error) {
+				tc := &entities.TreeCluster{}
+				for _, f := range fn {
+					f(tc)
+				}
+				assert.Equal(t, entities.TreeClusterWateringStatusUnknown, tc.WateringStatus)
+				return tc, nil
+			})
+		err := svc.updateWateringStatus(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should skip trees without tree cluster", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(&entities.Tree{ID: 1}, nil)
+		err := svc.updateWateringStatus(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when trees cannot be loaded", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockSensorRepository(t)
+		treeRepo := storageMock.NewMockTreeRepository(t)
+		tcRepo := storageMock.NewMockTreeClusterRepository(t)
+		svc := NewMqttService(repo, treeRepo, tcRepo, &wateringCfg)
+
+		// when
+		treeRepo.EXPECT().GetBySensorID(context.Background(), int32(1)).Return(trees[0], nil)
+		treeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return(nil, assert.AnError)
+		err := svc.updateWateringStatus(context.Background(), 1)
+
+		// then
+		assert.Error(t, err)
+	})
+}
@@ -4,21 +4,37 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/auth"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/flowerbed"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/image"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/info"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/region"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/role"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/route"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/sensor"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tile"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/vehicle"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/wateringevent"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/wateringplan"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 )
 
 func NewService(cfg *config.Config, repos *storage.Repository) *service.Services {
 	return &service.Services{
-		InfoService:        info.NewInfoService(repos.Info),
-		MqttService:        sensor.NewMqttService(repos.Sensor),
-		TreeService:        tree.NewTreeService(repos.Tree, repos.Sensor),
-		AuthService:        auth.NewAuthService(repos.Auth, repos.User, &cfg.IdentityAuth),
-		RegionService:      region.NewRegionService(repos.Region),
-		TreeClusterService: treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region),
+		InfoService:          info.NewInfoService(repos.Info),
+		MqttService:          sensor.NewMqttService(repos.Sensor, repos.Tree, repos.TreeCluster, &cfg.Watering),
+		TreeService:          tree.NewTreeService(repos.Tree, repos.Sensor, repos.TreeCluster, repos.Region, repos.Image, repos.UnitOfWork),
+		AuthService:          auth.NewAuthService(repos.Auth, repos.User, &cfg.IdentityAuth),
+		RegionService:        region.NewRegionService(repos.Region, repos.TreeCluster, repos.Flowerbed, repos.UnitOfWork),
+		TreeClusterService:   treecluster.NewTreeClusterService(repos.TreeCluster, repos.Tree, repos.Region, repos.UnitOfWork),
+		FlowerbedService:     flowerbed.NewFlowerbedService(repos.Flowerbed, repos.Sensor, repos.Image, repos.Region),
+		VehicleService:       vehicle.NewVehicleService(repos.Vehicle, repos.User),
+		ImageService:         image.NewImageService(repos.Image, repos.Blob, &cfg.Storage),
+		RoleService:          role.NewRoleService(repos.Role),
+		WateringPlanService:  wateringplan.NewWateringPlanService(repos.WateringPlan, repos.TreeCluster, repos.Vehicle, repos.User, &cfg.Watering),
+		RouteService:         route.NewRouteService(repos.TreeCluster, repos.Vehicle, repos.WateringPlan, &cfg.Watering, &cfg.Routing),
+		WateringEventService: wateringevent.NewWateringEventService(repos.WateringEvent, repos.TreeCluster, repos.Vehicle, repos.UnitOfWork),
+		TileService:          tile.NewTileService(repos.Tile),
 	}
 }
@@ -0,0 +1,62 @@
+package servicetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mocks holds a mock of every repository a service can depend on. Mocks without
+// expectations are never called, so each service test only sets up what it uses.
+type Mocks struct {
+	TreeRepo          *storageMock.MockTreeRepository
+	TreeClusterRepo   *storageMock.MockTreeClusterRepository
+	SensorRepo        *storageMock.MockSensorRepository
+	FlowerbedRepo     *storageMock.MockFlowerbedRepository
+	ImageRepo         *storageMock.MockImageRepository
+	BlobRepo          *storageMock.MockBlobRepository
+	RegionRepo        *storageMock.MockRegionRepository
+	VehicleRepo       *storageMock.MockVehicleRepository
+	UserRepo          *storageMock.MockUserRepository
+	WateringPlanRepo  *storageMock.MockWateringPlanRepository
+	WateringEventRepo *storageMock.MockWateringEventRepository
+	UnitOfWork        *storageMock.MockUnitOfWork
+}
+
+// NewMocks creates the mocks, their expectations are asserted when the test ends
+func NewMocks(t *testing.T) Mocks {
+	return Mocks{
+		TreeRepo:          storageMock.NewMockTreeRepository(t),
+		TreeClusterRepo:   storageMock.NewMockTreeClusterRepository(t),
+		SensorRepo:        storageMock.NewMockSensorRepository(t),
+		FlowerbedRepo:     storageMock.NewMockFlowerbedRepository(t),
+		ImageRepo:         storageMock.NewMockImageRepository(t),
+		BlobRepo:          storageMock.NewMockBlobRepository(t),
+		RegionRepo:        storageMock.NewMockRegionRepository(t),
+		VehicleRepo:       storageMock.NewMockVehicleRepository(t),
+		UserRepo:          storageMock.NewMockUserRepository(t),
+		WateringPlanRepo:  storageMock.NewMockWateringPlanRepository(t),
+		WateringEventRepo: storageMock.NewMockWateringEventRepository(t),
+		UnitOfWork:        storageMock.NewMockUnitOfWork(t),
+	}
+}
+
+// RunInTx lets the unit of work mock run the function like a transaction would
+func (m Mocks) RunInTx() {
+	m.UnitOfWork.EXPECT().WithTx(context.Background(), mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+}
+
+// AssertErrorCode checks that err is a service error with the given code
+func AssertErrorCode(t *testing.T, err error, code service.ErrorCode) {
+	t.Helper()
+	var svcErr service.Error
+	assert.ErrorAs(t, err, &svcErr)
+	assert.Equal(t, code, svcErr.Code)
+}
@@ -0,0 +1,45 @@
+package tile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+type TileService struct {
+	tileRepo  storage.TileRepository
+	validator *validator.Validate
+}
+
+func NewTileService(tileRepo storage.TileRepository) service.TileService {
+	return &TileService{
+		tileRepo:  tileRepo,
+		validator: validator.New(),
+	}
+}
+
+func (s *TileService) GetTile(ctx context.Context, query *domain.TileQuery) ([]byte, error) {
+	if err := s.validator.Struct(query); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	// a zoom level z has 2^z tiles per axis
+	if tiles := int32(1) << query.Z; query.X >= tiles || query.Y >= tiles {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("tile %d/%d/%d does not exist", query.Z, query.X, query.Y))
+	}
+
+	tile, err := s.tileRepo.GetTile(ctx, query)
+	if err != nil {
+		return nil, service.NewError(service.InternalError, err.Error())
+	}
+
+	return tile, nil
+}
+
+func (s *TileService) Ready() bool {
+	return s.tileRepo != nil
+}
@@ -0,0 +1,85 @@
+package tile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileService_GetTile(t *testing.T) {
+	t.Run("should return tile of layer", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockTileRepository(t)
+		svc := NewTileService(repo)
+		query := &entities.TileQuery{Layer: entities.TileLayerTrees, Z: 14, X: 8621, Y: 5140, ExcludeArchived: true}
+		expected := []byte{0x1a, 0x05}
+
+		// when
+		repo.EXPECT().GetTile(context.Background(), query).Return(expected, nil)
+		tile, err := svc.GetTile(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tile)
+	})
+
+	t.Run("should return bad request for unknown layer", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockTileRepository(t)
+		svc := NewTileService(repo)
+
+		// when
+		tile, err := svc.GetTile(context.Background(), &entities.TileQuery{Layer: "sensors", Z: 1})
+
+		// then
+		assert.Nil(t, tile)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request for tile outside of the grid", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockTileRepository(t)
+		svc := NewTileService(repo)
+
+		// when
+		tile, err := svc.GetTile(context.Background(), &entities.TileQuery{Layer: entities.TileLayerTrees, Z: 2, X: 4, Y: 0})
+
+		// then
+		assert.Nil(t, tile)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request for zoom level above 22", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockTileRepository(t)
+		svc := NewTileService(repo)
+
+		// when
+		tile, err := svc.GetTile(context.Background(), &entities.TileQuery{Layer: entities.TileLayerTrees, Z: 23})
+
+		// then
+		assert.Nil(t, tile)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return internal error if tile can not be rendered", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockTileRepository(t)
+		svc := NewTileService(repo)
+		query := &entities.TileQuery{Layer: entities.TileLayerRegions}
+
+		// when
+		repo.EXPECT().GetTile(context.Background(), query).Return(nil, errors.New("connection refused"))
+		tile, err := svc.GetTile(context.Background(), query)
+
+		// then
+		assert.Nil(t, tile)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
@@ -0,0 +1,186 @@
+package tree
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+)
+
+// importBatchSize is the number of trees looked up and written with a single statement
+const importBatchSize = 1000
+
+// Import creates or updates the trees of a tree inventory. Trees are matched by their tree number.
+// Rows that can't be read or fail validation are skipped and listed in the result. All batches run
+// in one transaction, so on a storage error nothing is imported.
+func (s *TreeService) Import(ctx context.Context, ti *entities.TreeImport) (*entities.TreeImportResult, error) {
+	if err := s.validator.Struct(ti); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	rows, rowErrs, err := parseImport(ti.File, ti.Format)
+	if err != nil {
+		return nil, service.NewError(service.BadRequest, err.Error())
+	}
+
+	result := &entities.TreeImportResult{
+		DryRun: ti.DryRun,
+		Total:  int32(len(rows) + len(rowErrs)),
+	}
+
+	rows, checkErrs := s.checkImportRows(rows)
+	rowErrs = append(rowErrs, checkErrs...)
+
+	// clusters of updated trees that changed their location need a new center point
+	movedClusters := make(map[int32]struct{})
+	err = s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		for start := 0; start < len(rows); start += importBatchSize {
+			batch := rows[start:min(start+importBatchSize, len(rows))]
+			batchErrs, err := s.importBatch(ctx, batch, ti.DryRun, result, movedClusters)
+			if err != nil {
+				return err
+			}
+			rowErrs = append(rowErrs, batchErrs...)
+		}
+
+		if ti.DryRun {
+			return nil
+		}
+
+		for id := range movedClusters {
+			if err := s.updateTreeClusterPosition(ctx, &id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	slices.SortFunc(rowErrs, func(a, b *entities.TreeImportError) int {
+		return cmp.Compare(a.Row, b.Row)
+	})
+	result.Errors = rowErrs
+	result.Skipped = int32(len(rowErrs))
+
+	return result, nil
+}
+
+// checkImportRows validates the rows and rejects every row whose tree number already occurred
+// in an earlier row of the file
+func (s *TreeService) checkImportRows(rows []*importRow) ([]*importRow, []*entities.TreeImportError) {
+	valid := make([]*importRow, 0, len(rows))
+	var rowErrs []*entities.TreeImportError
+	seen := make(map[int32]int, len(rows))
+	for _, row := range rows {
+		if msg := s.validateImportRow(&row.tree); msg != "" {
+			rowErrs = append(rowErrs, newImportError(row.row, &row.tree, msg))
+			continue
+		}
+
+		if first, ok := seen[row.tree.Number]; ok {
+			rowErrs = append(rowErrs, newImportError(row.row, &row.tree, fmt.Sprintf("tree number %d already used in row %d", row.tree.Number, first)))
+			continue
+		}
+		seen[row.tree.Number] = row.row
+
+		valid = append(valid, row)
+	}
+
+	return valid, rowErrs
+}
+
+func (s *TreeService) validateImportRow(tc *entities.TreeCreate) string {
+	switch {
+	case tc.Latitude < -90 || tc.Latitude > 90:
+		return fmt.Sprintf("latitude %g is out of range, coordinates must be WGS 84 (EPSG:4326)", tc.Latitude)
+	case tc.Longitude < -180 || tc.Longitude > 180:
+		return fmt.Sprintf("longitude %g is out of range, coordinates must be WGS 84 (EPSG:4326)", tc.Longitude)
+	case tc.Latitude == 0 && tc.Longitude == 0:
+		return "coordinates are missing"
+	case tc.PlantingYear > int32(time.Now().Year()):
+		return "planting year must not be in the future"
+	}
+
+	if err := s.validator.Struct(tc); err != nil {
+		return fmt.Sprintf("validation error: %s", err.Error())
+	}
+
+	return ""
+}
+
+func (s *TreeService) importBatch(
+	ctx context.Context,
+	rows []*importRow,
+	dryRun bool,
+	result *entities.TreeImportResult,
+	movedClusters map[int32]struct{},
+) ([]*entities.TreeImportError, error) {
+	numbers := make([]int32, len(rows))
+	for i, row := range rows {
+		numbers[i] = row.tree.Number
+	}
+
+	existing, err := s.treeRepo.GetByTreeNumbers(ctx, numbers)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[int32][]*entities.Tree, len(existing))
+	for _, t := range existing {
+		byNumber[t.Number] = append(byNumber[t.Number], t)
+	}
+
+	var creates, updates []*entities.Tree
+	var rowErrs []*entities.TreeImportError
+	for _, row := range rows {
+		t := &entities.Tree{
+			Age:                 row.tree.Age,
+			HeightAboveSeaLevel: row.tree.HeightAboveSeaLevel,
+			PlantingYear:        row.tree.PlantingYear,
+			Species:             row.tree.Species,
+			Number:              row.tree.Number,
+			Latitude:            row.tree.Latitude,
+			Longitude:           row.tree.Longitude,
+		}
+
+		matches := byNumber[t.Number]
+		switch len(matches) {
+		case 0:
+			creates = append(creates, t)
+		case 1:
+			prev := matches[0]
+			t.ID = prev.ID
+			updates = append(updates, t)
+			if prev.TreeCluster != nil && (prev.Latitude != t.Latitude || prev.Longitude != t.Longitude) {
+				movedClusters[prev.TreeCluster.ID] = struct{}{}
+			}
+		default:
+			msg := fmt.Sprintf("tree number %d is used by %d existing trees", t.Number, len(matches))
+			rowErrs = append(rowErrs, newImportError(row.row, &row.tree, msg))
+		}
+	}
+
+	if !dryRun {
+		if len(creates) > 0 {
+			if _, err := s.treeRepo.CreateBatch(ctx, creates); err != nil {
+				return nil, err
+			}
+		}
+		if len(updates) > 0 {
+			if err := s.treeRepo.UpdateBatch(ctx, updates); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result.Created += int32(len(creates))
+	result.Updated += int32(len(updates))
+
+	return rowErrs, nil
+}
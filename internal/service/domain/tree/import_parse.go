@@ -0,0 +1,289 @@
+package tree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+const (
+	columnNumber              = "number"
+	columnSpecies             = "species"
+	columnPlantingYear        = "planting_year"
+	columnAge                 = "age"
+	columnHeightAboveSeaLevel = "height_above_sea_level"
+	columnLatitude            = "latitude"
+	columnLongitude           = "longitude"
+)
+
+// importColumns maps the accepted column names of CSV files and property names of GeoJSON features
+// to the tree fields. Names are compared case insensitive, the German names are common in tree cadastres.
+var importColumns = map[string]string{
+	"number":                 columnNumber,
+	"tree_number":            columnNumber,
+	"baumnummer":             columnNumber,
+	"baumnr":                 columnNumber,
+	"species":                columnSpecies,
+	"baumart":                columnSpecies,
+	"planting_year":          columnPlantingYear,
+	"pflanzjahr":             columnPlantingYear,
+	"age":                    columnAge,
+	"alter":                  columnAge,
+	"height_above_sea_level": columnHeightAboveSeaLevel,
+	"latitude":               columnLatitude,
+	"lat":                    columnLatitude,
+	"longitude":              columnLongitude,
+	"lon":                    columnLongitude,
+	"lng":                    columnLongitude,
+}
+
+// importRow is a tree read from the imported file together with its position in the file
+type importRow struct {
+	row  int
+	tree entities.TreeCreate
+}
+
+func parseImport(r io.Reader, format entities.TreeImportFormat) ([]*importRow, []*entities.TreeImportError, error) {
+	switch format {
+	case entities.TreeImportFormatCSV:
+		return parseCSV(r)
+	case entities.TreeImportFormatGeoJSON:
+		return parseGeoJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+// parseCSV reads a CSV file with a header line. The delimiter may be a comma, semicolon or tab
+// and is detected from the header, decimals may use a comma as separator.
+func parseCSV(r io.Reader) ([]*importRow, []*entities.TreeImportError, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		_, _ = br.Discard(3)
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = detectDelimiter(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("csv file is empty")
+		}
+		return nil, nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		if column, ok := importColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+	for _, column := range []string{columnNumber, columnLatitude, columnLongitude} {
+		if _, ok := columns[column]; !ok {
+			return nil, nil, fmt.Errorf("csv file has no %s column", column)
+		}
+	}
+
+	var rows []*importRow
+	var rowErrs []*entities.TreeImportError
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid csv file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		fields := make(map[string]string, len(columns))
+		for column, i := range columns {
+			if i < len(record) {
+				fields[column] = record[i]
+			}
+		}
+
+		tc, err := parseImportFields(fields)
+		if err != nil {
+			rowErrs = append(rowErrs, newImportError(line, tc, err.Error()))
+			continue
+		}
+		rows = append(rows, &importRow{row: line, tree: *tc})
+	}
+
+	return rows, rowErrs, nil
+}
+
+func detectDelimiter(br *bufio.Reader) rune {
+	head, _ := br.Peek(br.Size())
+	if i := bytes.IndexByte(head, '\n'); i >= 0 {
+		head = head[:i]
+	}
+
+	delimiter, count := ',', bytes.Count(head, []byte{','})
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(head, []byte(string(d))); n > count {
+			delimiter, count = d, n
+		}
+	}
+
+	return delimiter
+}
+
+type importFeatureCollection struct {
+	Type     string          `json:"type"`
+	Features []importFeature `json:"features"`
+}
+
+type importFeature struct {
+	Geometry *struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// parseGeoJSON reads a feature collection of points. The tree fields are taken from the feature
+// properties, the location from the point coordinates (longitude, latitude).
+func parseGeoJSON(r io.Reader) ([]*importRow, []*entities.TreeImportError, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var collection importFeatureCollection
+	if err := decoder.Decode(&collection); err != nil {
+		return nil, nil, fmt.Errorf("invalid geojson file: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, nil, errors.New("geojson file must contain a FeatureCollection")
+	}
+
+	var rows []*importRow
+	var rowErrs []*entities.TreeImportError
+	for i, feature := range collection.Features {
+		row := i + 1
+
+		fields := make(map[string]string, len(feature.Properties)+2)
+		for name, value := range feature.Properties {
+			if column, ok := importColumns[strings.ToLower(name)]; ok && value != nil {
+				fields[column] = fmt.Sprint(value)
+			}
+		}
+
+		lat, long, err := parsePoint(feature)
+		if err == nil {
+			fields[columnLatitude] = strconv.FormatFloat(lat, 'f', -1, 64)
+			fields[columnLongitude] = strconv.FormatFloat(long, 'f', -1, 64)
+		}
+
+		tc, fieldErr := parseImportFields(fields)
+		if err == nil {
+			err = fieldErr
+		}
+		if err != nil {
+			rowErrs = append(rowErrs, newImportError(row, tc, err.Error()))
+			continue
+		}
+		rows = append(rows, &importRow{row: row, tree: *tc})
+	}
+
+	return rows, rowErrs, nil
+}
+
+func parsePoint(feature importFeature) (lat, long float64, err error) {
+	if feature.Geometry == nil || feature.Geometry.Type != "Point" {
+		return 0, 0, errors.New("geometry must be a point")
+	}
+
+	var coordinates []float64
+	if err := json.Unmarshal(feature.Geometry.Coordinates, &coordinates); err != nil || len(coordinates) < 2 {
+		return 0, 0, errors.New("invalid point coordinates")
+	}
+
+	return coordinates[1], coordinates[0], nil
+}
+
+// parseImportFields converts the values of a row. If the tree number could be read the returned tree
+// is set even on error, so that the error can be reported with the number.
+func parseImportFields(fields map[string]string) (*entities.TreeCreate, error) {
+	number, err := parseImportInt(fields, columnNumber, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &entities.TreeCreate{
+		Number:  number,
+		Species: strings.TrimSpace(fields[columnSpecies]),
+	}
+
+	if tc.PlantingYear, err = parseImportInt(fields, columnPlantingYear, false); err != nil {
+		return tc, err
+	}
+	if tc.Age, err = parseImportInt(fields, columnAge, false); err != nil {
+		return tc, err
+	}
+	if tc.HeightAboveSeaLevel, err = parseImportFloat(fields, columnHeightAboveSeaLevel, false); err != nil {
+		return tc, err
+	}
+	if tc.Latitude, err = parseImportFloat(fields, columnLatitude, true); err != nil {
+		return tc, err
+	}
+	if tc.Longitude, err = parseImportFloat(fields, columnLongitude, true); err != nil {
+		return tc, err
+	}
+
+	return tc, nil
+}
+
+func parseImportInt(fields map[string]string, column string, required bool) (int32, error) {
+	value := strings.TrimSpace(fields[column])
+	if value == "" {
+		if required {
+			return 0, fmt.Errorf("%s is missing", column)
+		}
+		return 0, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+
+	return int32(i), nil
+}
+
+func parseImportFloat(fields map[string]string, column string, required bool) (float64, error) {
+	value := strings.TrimSpace(fields[column])
+	if value == "" {
+		if required {
+			return 0, fmt.Errorf("%s is missing", column)
+		}
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", column, value)
+	}
+
+	return f, nil
+}
+
+func newImportError(row int, tc *entities.TreeCreate, msg string) *entities.TreeImportError {
+	importErr := &entities.TreeImportError{Row: row, Message: msg}
+	if tc != nil {
+		importErr.TreeNumber = &tc.Number
+	}
+
+	return importErr
+}
@@ -0,0 +1,224 @@
+package tree
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTreeService_Import(t *testing.T) {
+	t.Run("should create unknown and update existing trees from csv file", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "Baumnummer;Baumart;Pflanzjahr;Latitude;Longitude\n" +
+			"1;Quercus robur;2010;54,8212;9,4857\n" +
+			"2;Tilia cordata;2015;54.7878;9.4440\n"
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1, 2}).
+			Return([]*entities.Tree{{ID: 7, Number: 2, Latitude: 54.7878, Longitude: 9.4440}}, nil)
+		m.TreeRepo.EXPECT().CreateBatch(context.Background(), []*entities.Tree{
+			{Number: 1, Species: "Quercus robur", PlantingYear: 2010, Latitude: 54.8212, Longitude: 9.4857},
+		}).Return([]int32{8}, nil)
+		m.TreeRepo.EXPECT().UpdateBatch(context.Background(), []*entities.Tree{
+			{ID: 7, Number: 2, Species: "Tilia cordata", PlantingYear: 2015, Latitude: 54.7878, Longitude: 9.4440},
+		}).Return(nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &entities.TreeImportResult{Total: 2, Created: 1, Updated: 1}, result)
+	})
+
+	t.Run("should report invalid rows and import the others", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "number,species,latitude,longitude\n" +
+			"1,Quercus robur,54.8212,9.4857\n" +
+			"2,Tilia cordata,527000,6070000\n" +
+			"x,Tilia cordata,54.8212,9.4857\n" +
+			"1,Acer platanoides,54.7878,9.4440\n" +
+			"3,Acer platanoides,54.7878,\n"
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1}).Return(nil, nil)
+		m.TreeRepo.EXPECT().CreateBatch(context.Background(), mock.Anything).Return([]int32{1}, nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(5), result.Total)
+		assert.Equal(t, int32(1), result.Created)
+		assert.Equal(t, int32(4), result.Skipped)
+		assert.Len(t, result.Errors, 4)
+		assert.Equal(t, 3, result.Errors[0].Row)
+		assert.Contains(t, result.Errors[0].Message, "latitude")
+		assert.Equal(t, 4, result.Errors[1].Row)
+		assert.Nil(t, result.Errors[1].TreeNumber)
+		assert.Equal(t, 5, result.Errors[2].Row)
+		assert.Equal(t, "tree number 1 already used in row 2", result.Errors[2].Message)
+		assert.Equal(t, 6, result.Errors[3].Row)
+		assert.Equal(t, int32(3), *result.Errors[3].TreeNumber)
+		assert.Equal(t, "longitude is missing", result.Errors[3].Message)
+	})
+
+	t.Run("should skip trees whose number is used by several existing trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "number,latitude,longitude\n1,54.8212,9.4857\n"
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1}).
+			Return([]*entities.Tree{{ID: 1, Number: 1}, {ID: 2, Number: 1}}, nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), result.Skipped)
+		assert.Equal(t, "tree number 1 is used by 2 existing trees", result.Errors[0].Message)
+	})
+
+	t.Run("should not write anything on dry run", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "number,latitude,longitude\n1,54.8212,9.4857\n2,54.7878,9.4440\n"
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1, 2}).
+			Return([]*entities.Tree{{ID: 7, Number: 2, TreeCluster: &entities.TreeCluster{ID: 3}}}, nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+			DryRun: true,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, &entities.TreeImportResult{DryRun: true, Total: 2, Created: 1, Updated: 1}, result)
+	})
+
+	t.Run("should recalculate position of tree cluster if a tree moved", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "number,latitude,longitude\n2,54.7878,9.4440\n"
+		prev := &entities.Tree{ID: 7, Number: 2, Latitude: 54.7, Longitude: 9.4, TreeCluster: &entities.TreeCluster{ID: 3}}
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{2}).Return([]*entities.Tree{prev}, nil)
+		m.TreeRepo.EXPECT().UpdateBatch(context.Background(), mock.Anything).Return(nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{3}).Return(nil)
+		m.TreeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(3)).Return([]*entities.Tree{{ID: 7}}, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{7}).Return(54.7878, 9.4440, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.7878, 9.4440).Return(nil, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(3), mock.Anything, mock.Anything, mock.Anything).
+			Return(&entities.TreeCluster{ID: 3}, nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), result.Updated)
+	})
+
+	t.Run("should import points of geojson feature collection", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := `{"type": "FeatureCollection", "features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [9.4857, 54.8212]},
+			 "properties": {"tree_number": 1, "species": "Quercus robur", "planting_year": 2010}},
+			{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[9.4, 54.8], [9.5, 54.8]]},
+			 "properties": {"tree_number": 2}}
+		]}`
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1}).Return(nil, nil)
+		m.TreeRepo.EXPECT().CreateBatch(context.Background(), []*entities.Tree{
+			{Number: 1, Species: "Quercus robur", PlantingYear: 2010, Latitude: 54.8212, Longitude: 9.4857},
+		}).Return([]int32{1}, nil)
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatGeoJSON,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), result.Created)
+		assert.Equal(t, 2, result.Errors[0].Row)
+		assert.Equal(t, int32(2), *result.Errors[0].TreeNumber)
+		assert.Equal(t, "geometry must be a point", result.Errors[0].Message)
+	})
+
+	t.Run("should return bad request if a required column is missing", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		file := "number,species\n1,Quercus robur\n"
+
+		// when
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request for unknown format", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: "xlsx",
+			File:   strings.NewReader(""),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return internal error if writing a batch fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		file := "number,latitude,longitude\n1,54.8212,9.4857\n"
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByTreeNumbers(context.Background(), []int32{1}).Return(nil, nil)
+		m.TreeRepo.EXPECT().CreateBatch(context.Background(), mock.Anything).Return(nil, errors.New("connection lost"))
+		result, err := svc.Import(context.Background(), &entities.TreeImport{
+			Format: entities.TreeImportFormatCSV,
+			File:   strings.NewReader(file),
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
@@ -3,26 +3,66 @@ package tree
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 type TreeService struct {
-	treeRepo   storage.TreeRepository
-	sensorRepo storage.SensorRepository
+	treeRepo        storage.TreeRepository
+	sensorRepo      storage.SensorRepository
+	treeClusterRepo storage.TreeClusterRepository
+	regionRepo      storage.RegionRepository
+	imageRepo       storage.ImageRepository
+	unitOfWork      storage.UnitOfWork
+	validator       *validator.Validate
 }
 
-func NewTreeService(repoTree storage.TreeRepository, repoSensor storage.SensorRepository) service.TreeService {
+func NewTreeService(
+	repoTree storage.TreeRepository,
+	repoSensor storage.SensorRepository,
+	repoTreeCluster storage.TreeClusterRepository,
+	repoRegion storage.RegionRepository,
+	repoImage storage.ImageRepository,
+	unitOfWork storage.UnitOfWork,
+) service.TreeService {
 	return &TreeService{
-		treeRepo:   repoTree,
-		sensorRepo: repoSensor,
+		treeRepo:        repoTree,
+		sensorRepo:      repoSensor,
+		treeClusterRepo: repoTreeCluster,
+		regionRepo:      repoRegion,
+		imageRepo:       repoImage,
+		unitOfWork:      unitOfWork,
+		validator:       validator.New(),
 	}
 }
 
-func (s *TreeService) GetAll(ctx context.Context) ([]*entities.Tree, error) {
-	trees, err := s.treeRepo.GetAll(ctx)
+func (s *TreeService) GetAll(ctx context.Context, query entities.TreeQuery) ([]*entities.Tree, int64, error) {
+	if err := query.SpatialFilter.Validate(); err != nil {
+		return nil, 0, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	trees, total, err := s.treeRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return trees, total, nil
+}
+
+func (s *TreeService) GetNearby(ctx context.Context, query *entities.NearbyTreeQuery) ([]*entities.NearbyTree, error) {
+	if err := s.validator.Struct(query); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	trees, err := s.treeRepo.GetNearby(ctx, query)
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -39,11 +79,306 @@ func (s *TreeService) GetByID(ctx context.Context, id int32) (*entities.Tree, er
 	return tree, nil
 }
 
+func (s *TreeService) Create(ctx context.Context, tc *entities.TreeCreate) (*entities.Tree, error) {
+	if err := s.validate(tc, tc.PlantingYear); err != nil {
+		return nil, err
+	}
+
+	var t *entities.Tree
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = s.create(ctx, tc)
+		return err
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return t, nil
+}
+
+func (s *TreeService) create(ctx context.Context, tc *entities.TreeCreate) (*entities.Tree, error) {
+	cluster, err := s.getTreeCluster(ctx, tc.TreeClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.treeRepo.Create(ctx,
+		tree.WithTreeCluster(cluster),
+		tree.WithAge(tc.Age),
+		tree.WithHeightAboveSeaLevel(tc.HeightAboveSeaLevel),
+		tree.WithPlantingYear(tc.PlantingYear),
+		tree.WithSpecies(tc.Species),
+		tree.WithTreeNumber(tc.Number),
+		tree.WithLatitude(tc.Latitude),
+		tree.WithLongitude(tc.Longitude),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateTreeClusterPosition(ctx, tc.TreeClusterID); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *TreeService) Update(ctx context.Context, id int32, tu *entities.TreeUpdate) (*entities.Tree, error) {
+	if err := s.validate(tu, tu.PlantingYear); err != nil {
+		return nil, err
+	}
+
+	var t *entities.Tree
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = s.update(ctx, id, tu)
+		return err
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return t, nil
+}
+
+func (s *TreeService) update(ctx context.Context, id int32, tu *entities.TreeUpdate) (*entities.Tree, error) {
+	prev, err := s.treeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.getTreeCluster(ctx, tu.TreeClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.treeRepo.Update(ctx, id,
+		tree.WithTreeCluster(cluster),
+		tree.WithAge(tu.Age),
+		tree.WithHeightAboveSeaLevel(tu.HeightAboveSeaLevel),
+		tree.WithPlantingYear(tu.PlantingYear),
+		tree.WithSpecies(tu.Species),
+		tree.WithTreeNumber(tu.Number),
+		tree.WithLatitude(tu.Latitude),
+		tree.WithLongitude(tu.Longitude),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The position of the previous cluster changes if the tree moved away from it
+	if prev.TreeCluster != nil && (tu.TreeClusterID == nil || *tu.TreeClusterID != prev.TreeCluster.ID) {
+		if err := s.updateTreeClusterPosition(ctx, &prev.TreeCluster.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.updateTreeClusterPosition(ctx, tu.TreeClusterID); err != nil {
+		return nil, err
+	}
+
+	if err := utils.FlagMisplacedImages(ctx, s.treeRepo, t.ID, t.Images, t.Latitude, t.Longitude); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (s *TreeService) Delete(ctx context.Context, id int32) error {
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		t, err := s.treeRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.treeRepo.DeleteAndUnlinkImages(ctx, id); err != nil {
+			return err
+		}
+
+		if t.TreeCluster != nil {
+			return s.updateTreeClusterPosition(ctx, &t.TreeCluster.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *TreeService) GetImages(ctx context.Context, id int32) ([]*entities.Image, error) {
+	if _, err := s.treeRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	images, err := s.treeRepo.GetAllImagesByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return images, nil
+}
+
+func (s *TreeService) AddImages(ctx context.Context, id int32, imageIDs []int32) (*entities.Tree, error) {
+	t, err := s.treeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	images := t.Images
+	for _, imageID := range imageIDs {
+		if containsImage(images, imageID) {
+			continue
+		}
+
+		img, err := s.imageRepo.GetByID(ctx, imageID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		images = append(images, img)
+	}
+
+	t, err = s.treeRepo.UpdateWithImages(ctx, id, tree.WithImages(images))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := utils.FlagMisplacedImages(ctx, s.treeRepo, t.ID, t.Images, t.Latitude, t.Longitude); err != nil {
+		return nil, handleError(err)
+	}
+
+	return t, nil
+}
+
+func (s *TreeService) RemoveImage(ctx context.Context, id, imageID int32) (*entities.Tree, error) {
+	t, err := s.treeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if !containsImage(t.Images, imageID) {
+		return nil, service.NewError(service.NotFound, fmt.Sprintf("image %d is not linked to tree %d", imageID, id))
+	}
+
+	if err := s.treeRepo.UnlinkImage(ctx, id, imageID); err != nil {
+		return nil, handleError(err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *TreeService) validate(v any, plantingYear int32) error {
+	if err := s.validator.Struct(v); err != nil {
+		return service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if plantingYear > int32(time.Now().Year()) {
+		return service.NewError(service.BadRequest, "validation error: planting year must not be in the future")
+	}
+
+	return nil
+}
+
+func (s *TreeService) getTreeCluster(ctx context.Context, id *int32) (*entities.TreeCluster, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	cluster, err := s.treeClusterRepo.GetByID(ctx, *id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, service.NewError(service.NotFound, fmt.Sprintf("tree cluster with id %d not found", *id))
+		}
+		return nil, handleError(err)
+	}
+
+	return cluster, nil
+}
+
+// updateTreeClusterPosition recalculates the hull, center point and region of the tree cluster
+// from its linked trees, the same way the tree cluster service does on create and update.
+// A tree cluster without trees has no position anymore.
+func (s *TreeService) updateTreeClusterPosition(ctx context.Context, treeClusterID *int32) error {
+	if treeClusterID == nil {
+		return nil
+	}
+
+	if err := s.treeRepo.UpdateTreeClusterHulls(ctx, []int32{*treeClusterID}); err != nil {
+		return handleError(err)
+	}
+
+	trees, err := s.treeRepo.GetByTreeClusterID(ctx, *treeClusterID)
+	if err != nil {
+		return handleError(err)
+	}
+
+	var lat, long *float64
+	var region *entities.Region
+	if len(trees) > 0 {
+		treeIDs := make([]int32, len(trees))
+		for i, t := range trees {
+			treeIDs[i] = t.ID
+		}
+
+		centerLat, centerLong, err := s.treeRepo.GetCenterPoint(ctx, treeIDs)
+		if err != nil {
+			return handleError(err)
+		}
+
+		region, err = s.regionRepo.GetByPoint(ctx, centerLat, centerLong)
+		if err != nil {
+			return handleError(err)
+		}
+		lat, long = &centerLat, &centerLong
+	}
+
+	_, err = s.treeClusterRepo.Update(ctx, *treeClusterID,
+		treecluster.WithLatitude(lat),
+		treecluster.WithLongitude(long),
+		treecluster.WithRegion(region),
+	)
+	if err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func containsImage(images []*entities.Image, id int32) bool {
+	for _, img := range images {
+		if img.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrTreeNotFound) ||
+		errors.Is(err, storage.ErrTreeClusterNotFound) ||
+		errors.Is(err, storage.ErrImageNotFound)
+}
+
 func handleError(err error) error {
-	if errors.Is(err, storage.ErrEntityNotFound) {
+	// errors of steps within a transaction may already be mapped, e.g. a missing tree cluster
+	var svcErr service.Error
+	if errors.As(err, &svcErr) {
+		return svcErr
+	}
+
+	if isNotFound(err) {
 		return service.NewError(service.NotFound, err.Error())
 	}
 
+	if errors.Is(err, storage.ErrInvalidSortField) || errors.Is(err, storage.ErrInvalidGeometry) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
 	return service.NewError(service.InternalError, err.Error())
 }
 
@@ -0,0 +1,536 @@
+package tree
+
+import (
+	"context"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.TreeService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewTreeService(m.TreeRepo, m.SensorRepo, m.TreeClusterRepo, m.RegionRepo, m.ImageRepo, m.UnitOfWork), m
+}
+
+// newPostgresTestService returns the service with the postgres repositories, so the errors of the
+// repositories are the ones returned by the database instead of mocked ones
+func newPostgresTestService(t *testing.T) (service.TreeService, *storage.Repository) {
+	repo := postgres.NewRepository(testutils.SetupTestPool(t), &config.TreeClusterConfig{
+		Hull: config.HullConfig{Concavity: 1, Buffer: 5},
+	})
+	return NewTreeService(repo.Tree, repo.Sensor, repo.TreeCluster, repo.Region, repo.Image, repo.UnitOfWork), repo
+}
+
+func TestTreeService_GetAll(t *testing.T) {
+	t.Run("should return trees and total number of matching trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.TreeQuery{Query: entities.Query{Page: 2, Limit: 1}, Species: utils.P("Quercus robur")}
+		expected := []*entities.Tree{{ID: 2, Species: "Quercus robur"}}
+
+		// when
+		m.TreeRepo.EXPECT().GetAll(context.Background(), query).Return(expected, int64(2), nil)
+		result, total, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.Equal(t, int64(2), total)
+	})
+
+	t.Run("should return bad request for unknown sort field", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.TreeQuery{Query: entities.Query{SortBy: "height"}}
+
+		// when
+		m.TreeRepo.EXPECT().GetAll(context.Background(), query).Return(nil, int64(0), storage.ErrInvalidSortField)
+		result, _, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return trees within radius", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.TreeQuery{SpatialFilter: entities.SpatialFilter{
+			Radius: &entities.Radius{Center: entities.Coordinate{Latitude: 54.79, Longitude: 9.43}, Meters: 250},
+		}}
+		expected := []*entities.Tree{{ID: 1}}
+
+		// when
+		m.TreeRepo.EXPECT().GetAll(context.Background(), query).Return(expected, int64(1), nil)
+		result, total, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("should return bad request for invalid bounding box", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		query := entities.TreeQuery{SpatialFilter: entities.SpatialFilter{
+			BoundingBox: &entities.BoundingBox{
+				Min: entities.Coordinate{Latitude: 54.78, Longitude: 9.45},
+				Max: entities.Coordinate{Latitude: 54.80, Longitude: 9.40},
+			},
+		}}
+
+		// when
+		result, _, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestTreeService_GetNearby(t *testing.T) {
+	t.Run("should return nearest trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := &entities.NearbyTreeQuery{Latitude: 54.79, Longitude: 9.43, Limit: 2}
+		expected := []*entities.NearbyTree{{Tree: &entities.Tree{ID: 2}, Distance: 12.5}, {Tree: &entities.Tree{ID: 1}, Distance: 40}}
+
+		// when
+		m.TreeRepo.EXPECT().GetNearby(context.Background(), query).Return(expected, nil)
+		result, err := svc.GetNearby(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return bad request for invalid query", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.GetNearby(context.Background(), &entities.NearbyTreeQuery{Latitude: 91, Longitude: 9.43, Limit: 10})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestTreeService_Create(t *testing.T) {
+	cluster := &entities.TreeCluster{ID: 1}
+	region := &entities.Region{ID: 1, Name: "Mürwik"}
+
+	newTree := func() *entities.TreeCreate {
+		return &entities.TreeCreate{
+			TreeClusterID: utils.P(int32(1)),
+			PlantingYear:  2020,
+			Species:       "Quercus robur",
+			Number:        1005,
+			Latitude:      54.80,
+			Longitude:     9.44,
+		}
+	}
+
+	t.Run("should create tree and update tree cluster position", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.Tree{ID: 1, TreeCluster: cluster}
+
+		// when
+		m.RunInTx()
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(cluster, nil)
+		m.TreeRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{1}).Return(nil)
+		m.TreeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return([]*entities.Tree{expected}, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{1}).Return(54.80, 9.44, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.80, 9.44).Return(region, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything, mock.Anything).Return(cluster, nil)
+		result, err := svc.Create(context.Background(), newTree())
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should create tree without tree cluster", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.Tree{ID: 1}
+		req := newTree()
+		req.TreeClusterID = nil
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		result, err := svc.Create(context.Background(), req)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return error when tree cluster position can't be updated", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(cluster, nil)
+		m.TreeRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&entities.Tree{ID: 1}, nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{1}).Return(assert.AnError)
+		result, err := svc.Create(context.Background(), newTree())
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+
+	t.Run("should return bad request when coordinates are out of range", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		req := newTree()
+		req.Latitude = 91
+
+		// when
+		result, err := svc.Create(context.Background(), req)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when planting year is in the future", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		req := newTree()
+		req.PlantingYear = int32(time.Now().Year() + 1)
+
+		// when
+		result, err := svc.Create(context.Background(), req)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when tree cluster does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrTreeClusterNotFound)
+		result, err := svc.Create(context.Background(), newTree())
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestTreeService_Update(t *testing.T) {
+	oldCluster := &entities.TreeCluster{ID: 1}
+	newCluster := &entities.TreeCluster{ID: 2}
+
+	t.Run("should recalculate old and new tree cluster when tree moved", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		prev := &entities.Tree{ID: 10, TreeCluster: oldCluster}
+		updated := &entities.Tree{ID: 10, TreeCluster: newCluster}
+		req := &entities.TreeUpdate{TreeClusterID: utils.P(int32(2)), PlantingYear: 2010, Latitude: 54.8, Longitude: 9.4}
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(10)).Return(prev, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(newCluster, nil)
+		m.TreeRepo.EXPECT().Update(context.Background(), int32(10), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(updated, nil)
+
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{1}).Return(nil)
+		m.TreeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return([]*entities.Tree{{ID: 11}}, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{11}).Return(54.7, 9.3, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.7, 9.3).Return(nil, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything, mock.Anything).Return(oldCluster, nil)
+
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{2}).Return(nil)
+		m.TreeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(2)).Return([]*entities.Tree{updated}, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{10}).Return(54.8, 9.4, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.8, 9.4).Return(nil, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(2), mock.Anything, mock.Anything, mock.Anything).Return(newCluster, nil)
+
+		result, err := svc.Update(context.Background(), 10, req)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, updated, result)
+	})
+
+	t.Run("should return not found when tree does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(10)).Return(nil, storage.ErrTreeNotFound)
+		result, err := svc.Update(context.Background(), 10, &entities.TreeUpdate{})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestTreeService_Delete(t *testing.T) {
+	t.Run("should delete tree and remove position of tree cluster without trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		cluster := &entities.TreeCluster{ID: 1, Latitude: utils.P(54.8), Longitude: utils.P(9.4), Region: &entities.Region{ID: 1}}
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(10)).Return(&entities.Tree{ID: 10, TreeCluster: cluster}, nil)
+		m.TreeRepo.EXPECT().DeleteAndUnlinkImages(context.Background(), int32(10)).Return(nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterHulls(context.Background(), []int32{1}).Return(nil)
+		m.TreeRepo.EXPECT().GetByTreeClusterID(context.Background(), int32(1)).Return([]*entities.Tree{}, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ int32, fn ...entities.EntityFunc[entities.TreeCluster]) (*entities.TreeCluster, error) {
+				for _, f := range fn {
+					f(cluster)
+				}
+				return cluster, nil
+			})
+		err := svc.Delete(context.Background(), 10)
+
+		// then
+		assert.NoError(t, err)
+		assert.Nil(t, cluster.Latitude)
+		assert.Nil(t, cluster.Longitude)
+		assert.Nil(t, cluster.Region)
+	})
+
+	t.Run("should return error when delete failed", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(10)).Return(&entities.Tree{ID: 10}, nil)
+		m.TreeRepo.EXPECT().DeleteAndUnlinkImages(context.Background(), int32(10)).Return(assert.AnError)
+		err := svc.Delete(context.Background(), 10)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
+
+func TestTreeService_AddImages(t *testing.T) {
+	t.Run("should link new images and skip already linked ones", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		linked := &entities.Image{ID: 1}
+		added := &entities.Image{ID: 2}
+		expected := &entities.Tree{ID: 1, Images: []*entities.Image{linked, added}}
+
+		// when
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Tree{ID: 1, Images: []*entities.Image{linked}}, nil)
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(added, nil)
+		m.TreeRepo.EXPECT().UpdateWithImages(context.Background(), int32(1), mock.Anything).Return(expected, nil)
+		result, err := svc.AddImages(context.Background(), 1, []int32{1, 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should flag images taken far away from the tree", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		near := &entities.Image{ID: 1, Latitude: utils.P(54.8001), Longitude: utils.P(9.4401)}
+		far := &entities.Image{ID: 2, Latitude: utils.P(54.81), Longitude: utils.P(9.44)}
+		expected := &entities.Tree{ID: 1, Latitude: 54.80, Longitude: 9.44, Images: []*entities.Image{near, far}}
+
+		// when
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Tree{ID: 1}, nil)
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(near, nil)
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(far, nil)
+		m.TreeRepo.EXPECT().UpdateWithImages(context.Background(), int32(1), mock.Anything).Return(expected, nil)
+		m.TreeRepo.EXPECT().UpdateImageLocationMismatch(context.Background(), int32(1), int32(2), true).Return(nil)
+		result, err := svc.AddImages(context.Background(), 1, []int32{1, 2})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.False(t, result.Images[0].LocationMismatch)
+		assert.True(t, result.Images[1].LocationMismatch)
+	})
+
+	t.Run("should return not found when image does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Tree{ID: 1}, nil)
+		m.ImageRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(nil, storage.ErrImageNotFound)
+		result, err := svc.AddImages(context.Background(), 1, []int32{2})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestTreeService_RemoveImage(t *testing.T) {
+	t.Run("should return not found when image is not linked to tree", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Tree{ID: 1}, nil)
+		result, err := svc.RemoveImage(context.Background(), 1, 2)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestTreeService_NotFoundWithPostgres(t *testing.T) {
+	t.Run("should return not found if tree doesn't exist", func(t *testing.T) {
+		// given
+		svc, _ := newPostgresTestService(t)
+
+		// when
+		tree, err := svc.GetByID(context.Background(), 999999)
+
+		// then
+		assert.Nil(t, tree)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return not found on update if tree doesn't exist", func(t *testing.T) {
+		// given
+		svc, _ := newPostgresTestService(t)
+
+		// when
+		tree, err := svc.Update(context.Background(), 999999, &entities.TreeUpdate{Latitude: 54.8, Longitude: 9.4})
+
+		// then
+		assert.Nil(t, tree)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return not found on delete if tree doesn't exist", func(t *testing.T) {
+		// given
+		svc, _ := newPostgresTestService(t)
+
+		// when
+		err := svc.Delete(context.Background(), 999999)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should reject tree cluster that doesn't exist", func(t *testing.T) {
+		// given
+		svc, _ := newPostgresTestService(t)
+
+		// when
+		tree, err := svc.Create(context.Background(), &entities.TreeCreate{
+			TreeClusterID: utils.P(int32(999999)),
+			Latitude:      54.8,
+			Longitude:     9.4,
+		})
+
+		// then
+		assert.Nil(t, tree)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+		assert.ErrorContains(t, err, "tree cluster with id 999999 not found")
+	})
+}
+
+func TestTreeService_HullsWithPostgres(t *testing.T) {
+	t.Run("should recalculate hull of old and new tree cluster when tree moved", func(t *testing.T) {
+		// given
+		svc, repo := newPostgresTestService(t)
+		ctx := context.Background()
+
+		oldCluster, err := repo.TreeCluster.Create(ctx, treecluster.WithName("hull old"))
+		assert.NoError(t, err)
+		newCluster, err := repo.TreeCluster.Create(ctx, treecluster.WithName("hull new"))
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			_ = repo.TreeCluster.Delete(ctx, oldCluster.ID)
+			_ = repo.TreeCluster.Delete(ctx, newCluster.ID)
+		})
+
+		var trees []*entities.Tree
+		for i, pos := range []struct {
+			clusterID int32
+			long      float64
+		}{{oldCluster.ID, 9.40}, {oldCluster.ID, 9.41}, {newCluster.ID, 9.501}} {
+			tree, err := svc.Create(ctx, &entities.TreeCreate{
+				TreeClusterID: utils.P(pos.clusterID),
+				Number:        int32(910001 + i),
+				PlantingYear:  2010,
+				Latitude:      54.79,
+				Longitude:     pos.long,
+			})
+			assert.NoError(t, err)
+			trees = append(trees, tree)
+		}
+		t.Cleanup(func() {
+			for _, tree := range trees {
+				_ = svc.Delete(ctx, tree.ID)
+			}
+		})
+
+		// when
+		_, err = svc.Update(ctx, trees[1].ID, &entities.TreeUpdate{
+			TreeClusterID: utils.P(newCluster.ID),
+			Number:        trees[1].Number,
+			PlantingYear:  2010,
+			Latitude:      54.79,
+			Longitude:     9.41,
+		})
+
+		// then
+		assert.NoError(t, err)
+
+		oldCluster, err = repo.TreeCluster.GetByID(ctx, oldCluster.ID)
+		assert.NoError(t, err)
+		_, oldMax := longitudeRange(oldCluster.Hull)
+		assert.Less(t, oldMax, 9.405)
+
+		newCluster, err = repo.TreeCluster.GetByID(ctx, newCluster.ID)
+		assert.NoError(t, err)
+		newMin, newMax := longitudeRange(newCluster.Hull)
+		assert.Less(t, newMin, 9.41)
+		assert.Greater(t, newMax, 9.501)
+	})
+}
+
+// longitudeRange returns the smallest and largest longitude of the exterior ring
+func longitudeRange(p entities.Polygon) (minLong, maxLong float64) {
+	if len(p) == 0 || len(p[0]) == 0 {
+		return 0, 0
+	}
+
+	minLong, maxLong = p[0][0][0], p[0][0][0]
+	for _, pos := range p[0] {
+		minLong = min(minLong, pos[0])
+		maxLong = max(maxLong, pos[0])
+	}
+	return minLong, maxLong
+}
@@ -3,7 +3,9 @@ package treecluster
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/go-playground/validator/v10"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/service"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -14,23 +16,40 @@ type TreeClusterService struct {
 	treeClusterRepo storage.TreeClusterRepository
 	treeRepo        storage.TreeRepository
 	regionRepo      storage.RegionRepository
+	unitOfWork      storage.UnitOfWork
+	validator       *validator.Validate
 }
 
-func NewTreeClusterService(treeClusterRepo storage.TreeClusterRepository, treeRepo storage.TreeRepository, regionRepo storage.RegionRepository) service.TreeClusterService {
+func NewTreeClusterService(
+	treeClusterRepo storage.TreeClusterRepository,
+	treeRepo storage.TreeRepository,
+	regionRepo storage.RegionRepository,
+	unitOfWork storage.UnitOfWork,
+) service.TreeClusterService {
 	return &TreeClusterService{
 		treeClusterRepo: treeClusterRepo,
 		treeRepo:        treeRepo,
 		regionRepo:      regionRepo,
+		unitOfWork:      unitOfWork,
+		validator:       validator.New(),
 	}
 }
 
-func (s *TreeClusterService) GetAll(ctx context.Context) ([]*domain.TreeCluster, error) {
-	treeClusters, err := s.treeClusterRepo.GetAll(ctx)
+func (s *TreeClusterService) GetAll(ctx context.Context, query domain.TreeClusterQuery) ([]*domain.TreeCluster, int64, error) {
+	if err := s.validator.Struct(query); err != nil {
+		return nil, 0, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if err := query.SpatialFilter.Validate(); err != nil {
+		return nil, 0, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	treeClusters, total, err := s.treeClusterRepo.GetAll(ctx, query)
 	if err != nil {
-		return nil, handleError(err)
+		return nil, 0, handleError(err)
 	}
 
-	return treeClusters, nil
+	return treeClusters, total, nil
 }
 
 func (s *TreeClusterService) GetByID(ctx context.Context, id int32) (*domain.TreeCluster, error) {
@@ -43,6 +62,101 @@ func (s *TreeClusterService) GetByID(ctx context.Context, id int32) (*domain.Tre
 }
 
 func (s *TreeClusterService) Create(ctx context.Context, tc *domain.TreeClusterCreate) (*domain.TreeCluster, error) {
+	var c *domain.TreeCluster
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		c, err = s.create(ctx, tc)
+		return err
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return c, nil
+}
+
+// Suggest proposes tree clusters for the trees without tree cluster. Nothing is written,
+// the suggestions are created with AcceptSuggestions.
+func (s *TreeClusterService) Suggest(ctx context.Context, query *domain.TreeClusterSuggestionQuery) ([]*domain.TreeClusterSuggestion, error) {
+	if err := s.validator.Struct(query); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	suggestions, err := s.treeRepo.GetClusterSuggestions(ctx, query)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	soilCondition := query.SoilCondition
+	if soilCondition == "" {
+		soilCondition = domain.TreeSoilConditionUnknown
+	}
+
+	for i, suggestion := range suggestions {
+		suggestion.Region, err = s.getRegionByPoint(ctx, suggestion.Latitude, suggestion.Longitude)
+		if err != nil {
+			return nil, err
+		}
+
+		suggestion.Name = fmt.Sprintf("Cluster %d", i+1)
+		if suggestion.Region != nil {
+			suggestion.Name = fmt.Sprintf("%s %d", suggestion.Region.Name, i+1)
+		}
+		suggestion.SoilCondition = soilCondition
+	}
+
+	return suggestions, nil
+}
+
+// AcceptSuggestions creates the tree clusters of the accepted suggestions in one transaction,
+// so either all or none of them are created. Suggestions only contain trees without tree cluster,
+// trees that were linked to a tree cluster in the meantime are rejected instead of moved.
+func (s *TreeClusterService) AcceptSuggestions(ctx context.Context, tcs []*domain.TreeClusterCreate) ([]*domain.TreeCluster, error) {
+	if len(tcs) == 0 {
+		return nil, service.NewError(service.BadRequest, "no tree clusters to create")
+	}
+
+	seen := make(map[int32]bool)
+	treeIDs := make([]int32, 0)
+	for _, tc := range tcs {
+		if len(tc.TreeIDs) == 0 {
+			return nil, service.NewError(service.BadRequest, fmt.Sprintf("tree cluster %q has no trees", tc.Name))
+		}
+		for _, id := range tc.TreeIDs {
+			if seen[*id] {
+				return nil, service.NewError(service.BadRequest, fmt.Sprintf("tree %d is part of several tree clusters", *id))
+			}
+			seen[*id] = true
+			treeIDs = append(treeIDs, *id)
+		}
+	}
+
+	clusters := make([]*domain.TreeCluster, len(tcs))
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		linked, err := s.treeRepo.GetTreeClusterIDs(ctx, treeIDs)
+		if err != nil {
+			return err
+		}
+		if len(linked) > 0 {
+			return service.NewError(service.BadRequest, fmt.Sprintf("trees are already part of the tree clusters %v", linked))
+		}
+
+		for i, tc := range tcs {
+			var err error
+			if clusters[i], err = s.create(ctx, tc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return clusters, nil
+}
+
+func (s *TreeClusterService) create(ctx context.Context, tc *domain.TreeClusterCreate) (*domain.TreeCluster, error) {
 	treeIDs := make([]int32, len(tc.TreeIDs))
 	fn := make([]domain.EntityFunc[domain.TreeCluster], 0)
 
@@ -57,15 +171,20 @@ func (s *TreeClusterService) Create(ctx context.Context, tc *domain.TreeClusterC
 		fn = append(fn, geomFn...)
 	}
 
-	fn = append(fn, treecluster.WithName(tc.Name), treecluster.WithAddress(tc.Address), treecluster.WithDescription(tc.Description))
+	fn = append(fn,
+		treecluster.WithName(tc.Name),
+		treecluster.WithAddress(tc.Address),
+		treecluster.WithDescription(tc.Description),
+		treecluster.WithSoilCondition(tc.SoilCondition),
+	)
 
 	c, err := s.treeClusterRepo.Create(ctx, fn...)
 	if err != nil {
-		return nil, handleError(err)
+		return nil, err
 	}
 
-	if err = s.treeRepo.UpdateTreeClusterID(ctx, treeIDs, &c.ID); err != nil {
-		return nil, handleError(err)
+	if err := s.treeRepo.UpdateTreeClusterID(ctx, treeIDs, &c.ID); err != nil {
+		return nil, err
 	}
 
 	return c, nil
@@ -75,11 +194,6 @@ func (s *TreeClusterService) Update(ctx context.Context, id int32, tc *domain.Tr
 	treeIDs := make([]int32, len(tc.TreeIDs))
 	fn := make([]domain.EntityFunc[domain.TreeCluster], 0)
 
-	// TODO: Add a transaction to undo this change if an error occurs.
-	if err := s.treeRepo.UnlinkTreeClusterID(ctx, id); err != nil {
-		return nil, handleError(err)
-	}
-
 	if len(tc.TreeIDs) > 0 {
 		for i, id := range tc.TreeIDs {
 			treeIDs[i] = *id
@@ -99,12 +213,21 @@ func (s *TreeClusterService) Update(ctx context.Context, id int32, tc *domain.Tr
 		treecluster.WithSoilCondition(tc.SoilCondition),
 	)
 
-	c, err := s.treeClusterRepo.Update(ctx, id, fn...)
-	if err != nil {
-		return nil, handleError(err)
-	}
+	var c *domain.TreeCluster
+	err := s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeRepo.UnlinkTreeClusterID(ctx, id); err != nil {
+			return err
+		}
+
+		var err error
+		c, err = s.treeClusterRepo.Update(ctx, id, fn...)
+		if err != nil {
+			return err
+		}
 
-	if err = s.treeRepo.UpdateTreeClusterID(ctx, treeIDs, &c.ID); err != nil {
+		return s.treeRepo.UpdateTreeClusterID(ctx, treeIDs, &c.ID)
+	})
+	if err != nil {
 		return nil, handleError(err)
 	}
 
@@ -117,13 +240,13 @@ func (s *TreeClusterService) Delete(ctx context.Context, id int32) error {
 		return handleError(err)
 	}
 
-	// TODO: Add a transaction to undo this change if an error occurs.
-	err = s.treeRepo.UnlinkTreeClusterID(ctx, id)
-	if err != nil {
-		return handleError(err)
-	}
+	err = s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.treeRepo.UnlinkTreeClusterID(ctx, id); err != nil {
+			return err
+		}
 
-	err = s.treeClusterRepo.Delete(ctx, id)
+		return s.treeClusterRepo.Delete(ctx, id)
+	})
 	if err != nil {
 		return handleError(err)
 	}
@@ -132,14 +255,24 @@ func (s *TreeClusterService) Delete(ctx context.Context, id int32) error {
 }
 
 func (s *TreeClusterService) Ready() bool {
-	return s.treeClusterRepo != nil
+	return s.treeClusterRepo != nil && s.unitOfWork != nil
 }
 
 func handleError(err error) error {
+	// errors of steps within a transaction may already be mapped, e.g. a rejected suggestion
+	var svcErr service.Error
+	if errors.As(err, &svcErr) {
+		return svcErr
+	}
+
 	if errors.Is(err, storage.ErrEntityNotFound) {
 		return service.NewError(service.NotFound, err.Error())
 	}
 
+	if errors.Is(err, storage.ErrInvalidSortField) || errors.Is(err, storage.ErrInvalidGeometry) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
 	return service.NewError(service.InternalError, err.Error())
 }
 
@@ -0,0 +1,252 @@
+package treecluster
+
+import (
+	"context"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.TreeClusterService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewTreeClusterService(m.TreeClusterRepo, m.TreeRepo, m.RegionRepo, m.UnitOfWork), m
+}
+
+func TestTreeClusterService_GetAll(t *testing.T) {
+	t.Run("should filter tree clusters by watering status", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		query := entities.TreeClusterQuery{
+			Query:          entities.Query{Page: 1, Limit: 25},
+			WateringStatus: utils.P(entities.TreeClusterWateringStatusBad),
+		}
+		expected := []*entities.TreeCluster{{ID: 1, WateringStatus: entities.TreeClusterWateringStatusBad}}
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetAll(context.Background(), query).Return(expected, int64(1), nil)
+		result, total, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("should return bad request for unknown watering status", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		query := entities.TreeClusterQuery{WateringStatus: utils.P(entities.TreeClusterWateringStatus("dry"))}
+
+		// when
+		result, _, err := svc.GetAll(context.Background(), query)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestTreeClusterService_Create(t *testing.T) {
+	t.Run("should create tree cluster without trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.TreeCluster{ID: 1, Name: "Cluster"}
+
+		// when
+		m.RunInTx()
+		m.TreeClusterRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterID(context.Background(), []int32{}, &expected.ID).Return(nil)
+		result, err := svc.Create(context.Background(), &entities.TreeClusterCreate{Name: "Cluster"})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestTreeClusterService_Update(t *testing.T) {
+	t.Run("should unlink old trees and link new trees in one transaction", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		treeID := int32(2)
+		region := &entities.Region{ID: 1}
+		expected := &entities.TreeCluster{ID: 1}
+
+		// when
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{2}).Return(54.8, 9.4, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.8, 9.4).Return(region, nil)
+		m.RunInTx()
+		m.TreeRepo.EXPECT().UnlinkTreeClusterID(context.Background(), int32(1)).Return(nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterID(context.Background(), []int32{2}, &expected.ID).Return(nil)
+		result, err := svc.Update(context.Background(), 1, &entities.TreeClusterUpdate{TreeIDs: []*int32{&treeID}})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should not update tree cluster when unlinking trees fails", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().UnlinkTreeClusterID(context.Background(), int32(1)).Return(assert.AnError)
+		result, err := svc.Update(context.Background(), 1, &entities.TreeClusterUpdate{})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+}
+
+func TestTreeClusterService_Delete(t *testing.T) {
+	t.Run("should unlink trees and delete tree cluster", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+		m.RunInTx()
+		m.TreeRepo.EXPECT().UnlinkTreeClusterID(context.Background(), int32(1)).Return(nil)
+		m.TreeClusterRepo.EXPECT().Delete(context.Background(), int32(1)).Return(nil)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error when tree cluster could not be deleted", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+		m.RunInTx()
+		m.TreeRepo.EXPECT().UnlinkTreeClusterID(context.Background(), int32(1)).Return(nil)
+		m.TreeClusterRepo.EXPECT().Delete(context.Background(), int32(1)).Return(assert.AnError)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.InternalError)
+	})
+
+	t.Run("should return not found when tree cluster does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrEntityNotFound)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestTreeClusterService_Suggest(t *testing.T) {
+	t.Run("should name suggestions by region and assign soil condition", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		region := &entities.Region{ID: 1, Name: "Mürwik"}
+		query := &entities.TreeClusterSuggestionQuery{Distance: 30, MinSize: 3, SoilCondition: entities.TreeSoilConditionSandig}
+
+		// when
+		m.TreeRepo.EXPECT().GetClusterSuggestions(context.Background(), query).Return([]*entities.TreeClusterSuggestion{
+			{TreeIDs: []int32{1, 2, 3}, Latitude: 54.82, Longitude: 9.48},
+			{TreeIDs: []int32{4, 5, 6}, Latitude: 54.70, Longitude: 9.30},
+		}, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.82, 9.48).Return(region, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), 54.70, 9.30).Return(nil, nil)
+		result, err := svc.Suggest(context.Background(), query)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeClusterSuggestion{
+			{Name: "Mürwik 1", Region: region, Latitude: 54.82, Longitude: 9.48, SoilCondition: entities.TreeSoilConditionSandig, TreeIDs: []int32{1, 2, 3}},
+			{Name: "Cluster 2", Latitude: 54.70, Longitude: 9.30, SoilCondition: entities.TreeSoilConditionSandig, TreeIDs: []int32{4, 5, 6}},
+		}, result)
+	})
+
+	t.Run("should return bad request for invalid distance", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Suggest(context.Background(), &entities.TreeClusterSuggestionQuery{Distance: 0, MinSize: 3})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestTreeClusterService_AcceptSuggestions(t *testing.T) {
+	t.Run("should create all tree clusters in one transaction", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		first := &entities.TreeCluster{ID: 1}
+		second := &entities.TreeCluster{ID: 2}
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetTreeClusterIDs(context.Background(), []int32{1, 2, 3}).Return([]int32{}, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{1, 2}).Return(54.82, 9.48, nil)
+		m.TreeRepo.EXPECT().GetCenterPoint(context.Background(), []int32{3}).Return(54.70, 9.30, nil)
+		m.RegionRepo.EXPECT().GetByPoint(context.Background(), mock.Anything, mock.Anything).Return(nil, nil)
+		m.TreeClusterRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything).Return(first, nil).Once()
+		m.TreeClusterRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything).Return(second, nil).Once()
+		m.TreeRepo.EXPECT().UpdateTreeClusterID(context.Background(), []int32{1, 2}, &first.ID).Return(nil)
+		m.TreeRepo.EXPECT().UpdateTreeClusterID(context.Background(), []int32{3}, &second.ID).Return(nil)
+		result, err := svc.AcceptSuggestions(context.Background(), []*entities.TreeClusterCreate{
+			{Name: "Mürwik 1", TreeIDs: []*int32{utils.P(int32(1)), utils.P(int32(2))}},
+			{Name: "Mürwik 2", TreeIDs: []*int32{utils.P(int32(3))}},
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.TreeCluster{first, second}, result)
+	})
+
+	t.Run("should return bad request if a tree was linked to a tree cluster in the meantime", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.RunInTx()
+		m.TreeRepo.EXPECT().GetTreeClusterIDs(context.Background(), []int32{1, 2}).Return([]int32{7}, nil)
+		result, err := svc.AcceptSuggestions(context.Background(), []*entities.TreeClusterCreate{
+			{Name: "Mürwik 1", TreeIDs: []*int32{utils.P(int32(1)), utils.P(int32(2))}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+		assert.ErrorContains(t, err, "already part of the tree clusters [7]")
+	})
+
+	t.Run("should return bad request if a tree is part of several tree clusters", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.AcceptSuggestions(context.Background(), []*entities.TreeClusterCreate{
+			{Name: "Mürwik 1", TreeIDs: []*int32{utils.P(int32(1)), utils.P(int32(2))}},
+			{Name: "Mürwik 2", TreeIDs: []*int32{utils.P(int32(2))}},
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
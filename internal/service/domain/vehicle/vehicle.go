@@ -0,0 +1,205 @@
+package vehicle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/vehicle"
+)
+
+type VehicleService struct {
+	vehicleRepo storage.VehicleRepository
+	userRepo    storage.UserRepository
+	validator   *validator.Validate
+}
+
+func NewVehicleService(vehicleRepo storage.VehicleRepository, userRepo storage.UserRepository) service.VehicleService {
+	return &VehicleService{
+		vehicleRepo: vehicleRepo,
+		userRepo:    userRepo,
+		validator:   validator.New(),
+	}
+}
+
+func (s *VehicleService) GetAll(ctx context.Context, query domain.Query) ([]*domain.Vehicle, int64, error) {
+	vehicles, total, err := s.vehicleRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return vehicles, total, nil
+}
+
+func (s *VehicleService) GetByID(ctx context.Context, id int32) (*domain.Vehicle, error) {
+	v, err := s.vehicleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return v, nil
+}
+
+func (s *VehicleService) GetByPlate(ctx context.Context, plate string) (*domain.Vehicle, error) {
+	v, err := s.vehicleRepo.GetByPlate(ctx, strings.TrimSpace(plate))
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return v, nil
+}
+
+func (s *VehicleService) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Vehicle, error) {
+	vehicles, err := s.vehicleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return vehicles, nil
+}
+
+func (s *VehicleService) Create(ctx context.Context, vc *domain.VehicleCreate) (*domain.Vehicle, error) {
+	vc.NumberPlate = strings.TrimSpace(vc.NumberPlate)
+	if err := s.validator.Struct(vc); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if err := s.checkPlateIsUnique(ctx, 0, vc.NumberPlate); err != nil {
+		return nil, err
+	}
+
+	v, err := s.vehicleRepo.Create(ctx,
+		vehicle.WithNumberPlate(vc.NumberPlate),
+		vehicle.WithDescription(vc.Description),
+		vehicle.WithWaterCapacity(vc.WaterCapacity),
+	)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return v, nil
+}
+
+func (s *VehicleService) Update(ctx context.Context, id int32, vu *domain.VehicleUpdate) (*domain.Vehicle, error) {
+	vu.NumberPlate = strings.TrimSpace(vu.NumberPlate)
+	if err := s.validator.Struct(vu); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	if _, err := s.vehicleRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	if err := s.checkPlateIsUnique(ctx, id, vu.NumberPlate); err != nil {
+		return nil, err
+	}
+
+	v, err := s.vehicleRepo.Update(ctx, id,
+		vehicle.WithNumberPlate(vu.NumberPlate),
+		vehicle.WithDescription(vu.Description),
+		vehicle.WithWaterCapacity(vu.WaterCapacity),
+	)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return v, nil
+}
+
+func (s *VehicleService) Delete(ctx context.Context, id int32) error {
+	if _, err := s.vehicleRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.vehicleRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *VehicleService) GetAssignedUserIDs(ctx context.Context, id int32) ([]uuid.UUID, error) {
+	if _, err := s.vehicleRepo.GetByID(ctx, id); err != nil {
+		return nil, handleError(err)
+	}
+
+	userIDs, err := s.vehicleRepo.GetUserIDs(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return userIDs, nil
+}
+
+func (s *VehicleService) AssignToUser(ctx context.Context, id int32, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return service.NewError(service.BadRequest, "user id is required")
+	}
+
+	if _, err := s.vehicleRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	// users are managed in keycloak, the database can't ensure that the assigned user exists
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.vehicleRepo.AssignToUser(ctx, id, userID); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *VehicleService) UnassignFromUser(ctx context.Context, id int32, userID uuid.UUID) error {
+	if _, err := s.vehicleRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.vehicleRepo.UnassignFromUser(ctx, id, userID); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *VehicleService) Ready() bool {
+	return s.vehicleRepo != nil
+}
+
+// checkPlateIsUnique returns a bad request error if another vehicle than the one with the given id
+// is already registered with the number plate
+func (s *VehicleService) checkPlateIsUnique(ctx context.Context, id int32, plate string) error {
+	existing, err := s.vehicleRepo.GetByPlate(ctx, plate)
+	if err != nil {
+		if errors.Is(err, storage.ErrVehicleNotFound) {
+			return nil
+		}
+		return handleError(err)
+	}
+
+	if existing.ID != id {
+		return service.NewError(service.BadRequest, fmt.Sprintf("vehicle with number plate %s already exists", plate))
+	}
+
+	return nil
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) || errors.Is(err, storage.ErrVehicleNotFound) || errors.Is(err, storage.ErrUserNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrDuplicateEntity) || errors.Is(err, storage.ErrInvalidSortField) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
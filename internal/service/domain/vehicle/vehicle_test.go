@@ -0,0 +1,231 @@
+package vehicle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	storageMock "github.com/green-ecolution/green-ecolution-backend/internal/storage/_mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestVehicleService_Create(t *testing.T) {
+	t.Run("should create vehicle with trimmed number plate", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+		expected := &entities.Vehicle{ID: 1, NumberPlate: "FL ZB 123", WaterCapacity: 2000}
+
+		// when
+		repo.EXPECT().GetByPlate(context.Background(), "FL ZB 123").Return(nil, storage.ErrVehicleNotFound)
+		repo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		result, err := svc.Create(context.Background(), &entities.VehicleCreate{NumberPlate: " FL ZB 123 ", WaterCapacity: 2000})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return bad request when number plate already exists", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByPlate(context.Background(), "FL ZB 123").Return(&entities.Vehicle{ID: 2}, nil)
+		result, err := svc.Create(context.Background(), &entities.VehicleCreate{NumberPlate: "FL ZB 123", WaterCapacity: 2000})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when water capacity is not positive", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.VehicleCreate{NumberPlate: "FL ZB 123"})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when unique constraint is violated", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByPlate(context.Background(), "FL ZB 123").Return(nil, storage.ErrVehicleNotFound)
+		repo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything).Return(nil, storage.ErrDuplicateEntity)
+		result, err := svc.Create(context.Background(), &entities.VehicleCreate{NumberPlate: "FL ZB 123", WaterCapacity: 2000})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestVehicleService_Update(t *testing.T) {
+	t.Run("should update vehicle keeping its own number plate", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+		existing := &entities.Vehicle{ID: 1, NumberPlate: "FL ZB 123"}
+		expected := &entities.Vehicle{ID: 1, NumberPlate: "FL ZB 123", WaterCapacity: 3000}
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(existing, nil)
+		repo.EXPECT().GetByPlate(context.Background(), "FL ZB 123").Return(existing, nil)
+		repo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything, mock.Anything).Return(expected, nil)
+		result, err := svc.Update(context.Background(), 1, &entities.VehicleUpdate{NumberPlate: "FL ZB 123", WaterCapacity: 3000})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return bad request when number plate belongs to another vehicle", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		repo.EXPECT().GetByPlate(context.Background(), "FL ZB 456").Return(&entities.Vehicle{ID: 2}, nil)
+		result, err := svc.Update(context.Background(), 1, &entities.VehicleUpdate{NumberPlate: "FL ZB 456", WaterCapacity: 3000})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when vehicle does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrVehicleNotFound)
+		result, err := svc.Update(context.Background(), 1, &entities.VehicleUpdate{NumberPlate: "FL ZB 123", WaterCapacity: 3000})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestVehicleService_Delete(t *testing.T) {
+	t.Run("should delete vehicle", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		repo.EXPECT().Delete(context.Background(), int32(1)).Return(nil)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found when vehicle does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrVehicleNotFound)
+		err := svc.Delete(context.Background(), 1)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestVehicleService_AssignToUser(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("should assign vehicle to user", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewVehicleService(repo, userRepo)
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		userRepo.EXPECT().GetByID(context.Background(), userID).Return(&entities.User{ID: userID}, nil)
+		repo.EXPECT().AssignToUser(context.Background(), int32(1), userID).Return(nil)
+		err := svc.AssignToUser(context.Background(), 1, userID)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found when user does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		userRepo := storageMock.NewMockUserRepository(t)
+		svc := NewVehicleService(repo, userRepo)
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		userRepo.EXPECT().GetByID(context.Background(), userID).Return(nil, storage.ErrUserNotFound)
+		err := svc.AssignToUser(context.Background(), 1, userID)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return bad request when user id is empty", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		err := svc.AssignToUser(context.Background(), 1, uuid.Nil)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when vehicle does not exist", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrVehicleNotFound)
+		err := svc.AssignToUser(context.Background(), 1, userID)
+
+		// then
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
+
+func TestVehicleService_GetAssignedUserIDs(t *testing.T) {
+	t.Run("should return ids of assigned users", func(t *testing.T) {
+		// given
+		repo := storageMock.NewMockVehicleRepository(t)
+		svc := NewVehicleService(repo, storageMock.NewMockUserRepository(t))
+		expected := []uuid.UUID{uuid.New(), uuid.New()}
+
+		// when
+		repo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		repo.EXPECT().GetUserIDs(context.Background(), int32(1)).Return(expected, nil)
+		result, err := svc.GetAssignedUserIDs(context.Background(), 1)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
@@ -0,0 +1,172 @@
+package wateringevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringevent"
+)
+
+type WateringEventService struct {
+	wateringEventRepo storage.WateringEventRepository
+	treeClusterRepo   storage.TreeClusterRepository
+	vehicleRepo       storage.VehicleRepository
+	unitOfWork        storage.UnitOfWork
+	validator         *validator.Validate
+}
+
+func NewWateringEventService(
+	wateringEventRepo storage.WateringEventRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	vehicleRepo storage.VehicleRepository,
+	unitOfWork storage.UnitOfWork,
+) service.WateringEventService {
+	return &WateringEventService{
+		wateringEventRepo: wateringEventRepo,
+		treeClusterRepo:   treeClusterRepo,
+		vehicleRepo:       vehicleRepo,
+		unitOfWork:        unitOfWork,
+		validator:         validator.New(),
+	}
+}
+
+func (s *WateringEventService) GetAll(ctx context.Context, query domain.WateringEventQuery) ([]*domain.WateringEvent, int64, error) {
+	if err := validateTimeRange(query); err != nil {
+		return nil, 0, err
+	}
+
+	events, total, err := s.wateringEventRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return events, total, nil
+}
+
+func (s *WateringEventService) GetTotalWaterAmount(ctx context.Context, query domain.WateringEventQuery) (float64, error) {
+	if err := validateTimeRange(query); err != nil {
+		return 0, err
+	}
+
+	total, err := s.wateringEventRepo.GetTotalWaterAmount(ctx, query)
+	if err != nil {
+		return 0, handleError(err)
+	}
+
+	return total, nil
+}
+
+func (s *WateringEventService) GetByID(ctx context.Context, id int32) (*domain.WateringEvent, error) {
+	we, err := s.wateringEventRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return we, nil
+}
+
+// Create records the watering and sets the last watering of the tree cluster. The watering status of the
+// cluster is reset to good until new sensor data arrives. Events recorded afterwards for an earlier
+// watering are kept in the log without changing the tree cluster.
+func (s *WateringEventService) Create(ctx context.Context, wec *domain.WateringEventCreate) (*domain.WateringEvent, error) {
+	if err := s.validator.Struct(wec); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	wateredAt := wec.WateredAt
+	if wateredAt.IsZero() {
+		wateredAt = time.Now()
+	}
+
+	if wateredAt.After(time.Now()) {
+		return nil, service.NewError(service.BadRequest, "watering can not be in the future")
+	}
+
+	tc, err := s.treeClusterRepo.GetByID(ctx, wec.TreeClusterID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if wec.VehicleID != nil {
+		if _, err := s.vehicleRepo.GetByID(ctx, *wec.VehicleID); err != nil {
+			return nil, handleError(err)
+		}
+	}
+
+	var we *domain.WateringEvent
+	err = s.unitOfWork.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		we, err = s.wateringEventRepo.Create(ctx,
+			wateringevent.WithTreeClusterID(tc.ID),
+			wateringevent.WithVehicleID(wec.VehicleID),
+			wateringevent.WithUserID(wec.UserID),
+			wateringevent.WithWateredAt(wateredAt),
+			wateringevent.WithWaterAmount(wec.WaterAmount),
+		)
+		if err != nil {
+			return err
+		}
+
+		if tc.LastWatered != nil && !wateredAt.After(*tc.LastWatered) {
+			return nil
+		}
+
+		_, err = s.treeClusterRepo.Update(ctx, tc.ID,
+			treecluster.WithLastWatered(wateredAt),
+			treecluster.WithWateringStatus(domain.TreeClusterWateringStatusGood),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return we, nil
+}
+
+// Delete removes the event from the log, the last watering of the tree cluster is not changed
+func (s *WateringEventService) Delete(ctx context.Context, id int32) error {
+	if _, err := s.wateringEventRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.wateringEventRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *WateringEventService) Ready() bool {
+	return s.wateringEventRepo != nil && s.treeClusterRepo != nil && s.vehicleRepo != nil && s.unitOfWork != nil
+}
+
+func validateTimeRange(query domain.WateringEventQuery) error {
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return service.NewError(service.BadRequest, "from must be before to")
+	}
+
+	return nil
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrWateringEventNotFound) ||
+		errors.Is(err, storage.ErrVehicleNotFound) ||
+		errors.Is(err, storage.ErrTreeClusterNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrInvalidSortField) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
@@ -0,0 +1,133 @@
+package wateringevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.WateringEventService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	return NewWateringEventService(m.WateringEventRepo, m.TreeClusterRepo, m.VehicleRepo, m.UnitOfWork), m
+}
+
+func TestWateringEventService_Create(t *testing.T) {
+	wateredAt := time.Date(2024, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	t.Run("should record watering and update last watering of tree cluster", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		expected := &entities.WateringEvent{ID: 1, TreeClusterID: 1, WateredAt: wateredAt, WaterAmount: 240}
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.TreeCluster{ID: 1}, nil)
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(&entities.Vehicle{ID: 2}, nil)
+		m.RunInTx()
+		m.WateringEventRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expected, nil)
+		m.TreeClusterRepo.EXPECT().Update(context.Background(), int32(1), mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, _ int32, fn ...entities.EntityFunc[entities.TreeCluster]) (*entities.TreeCluster, error) {
+				tc := &entities.TreeCluster{WateringStatus: entities.TreeClusterWateringStatusBad}
+				for _, f := range fn {
+					f(tc)
+				}
+				assert.Equal(t, wateredAt, *tc.LastWatered)
+				assert.Equal(t, entities.TreeClusterWateringStatusGood, tc.WateringStatus)
+				return tc, nil
+			})
+		result, err := svc.Create(context.Background(), &entities.WateringEventCreate{
+			TreeClusterID: 1,
+			VehicleID:     utils.P(int32(2)),
+			WateredAt:     wateredAt,
+			WaterAmount:   240,
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should not change tree cluster when watering is older than last watering", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		lastWatered := wateredAt.Add(time.Hour)
+		expected := &entities.WateringEvent{ID: 1, TreeClusterID: 1, WateredAt: wateredAt, WaterAmount: 240}
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.TreeCluster{ID: 1, LastWatered: &lastWatered}, nil)
+		m.RunInTx()
+		m.WateringEventRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(expected, nil)
+		result, err := svc.Create(context.Background(), &entities.WateringEventCreate{TreeClusterID: 1, WateredAt: wateredAt, WaterAmount: 240})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should return not found when tree cluster does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrTreeClusterNotFound)
+		result, err := svc.Create(context.Background(), &entities.WateringEventCreate{TreeClusterID: 1, WaterAmount: 240})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return bad request when no water was given", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.WateringEventCreate{TreeClusterID: 1})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when watering is in the future", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.WateringEventCreate{
+			TreeClusterID: 1,
+			WateredAt:     time.Now().Add(time.Hour),
+			WaterAmount:   240,
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestWateringEventService_GetAll(t *testing.T) {
+	t.Run("should return bad request when time range is empty", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, -1)
+
+		// when
+		result, total, err := svc.GetAll(context.Background(), entities.WateringEventQuery{From: &from, To: &to})
+
+		// then
+		assert.Nil(t, result)
+		assert.Zero(t, total)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
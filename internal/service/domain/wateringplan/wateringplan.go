@@ -0,0 +1,206 @@
+package wateringplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringplan"
+)
+
+// statusTransitions lists the states a plan may change to from its current state.
+// Finished and cancelled plans are final.
+var statusTransitions = map[domain.WateringPlanStatus][]domain.WateringPlanStatus{
+	domain.WateringPlanStatusPlanned:   {domain.WateringPlanStatusPlanned, domain.WateringPlanStatusActive, domain.WateringPlanStatusCancelled},
+	domain.WateringPlanStatusActive:    {domain.WateringPlanStatusActive, domain.WateringPlanStatusFinished, domain.WateringPlanStatusCancelled},
+	domain.WateringPlanStatusFinished:  {domain.WateringPlanStatusFinished},
+	domain.WateringPlanStatusCancelled: {domain.WateringPlanStatusCancelled},
+}
+
+type WateringPlanService struct {
+	wateringPlanRepo storage.WateringPlanRepository
+	treeClusterRepo  storage.TreeClusterRepository
+	vehicleRepo      storage.VehicleRepository
+	userRepo         storage.UserRepository
+	waterPerTree     float64
+	validator        *validator.Validate
+}
+
+func NewWateringPlanService(
+	wateringPlanRepo storage.WateringPlanRepository,
+	treeClusterRepo storage.TreeClusterRepository,
+	vehicleRepo storage.VehicleRepository,
+	userRepo storage.UserRepository,
+	cfg *config.WateringConfig,
+) service.WateringPlanService {
+	return &WateringPlanService{
+		wateringPlanRepo: wateringPlanRepo,
+		treeClusterRepo:  treeClusterRepo,
+		vehicleRepo:      vehicleRepo,
+		userRepo:         userRepo,
+		waterPerTree:     cfg.WaterPerTree,
+		validator:        validator.New(),
+	}
+}
+
+func (s *WateringPlanService) GetAll(ctx context.Context, query domain.Query) ([]*domain.WateringPlan, int64, error) {
+	plans, total, err := s.wateringPlanRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, 0, handleError(err)
+	}
+
+	return plans, total, nil
+}
+
+func (s *WateringPlanService) GetByID(ctx context.Context, id int32) (*domain.WateringPlan, error) {
+	wp, err := s.wateringPlanRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return wp, nil
+}
+
+func (s *WateringPlanService) Create(ctx context.Context, wpc *domain.WateringPlanCreate) (*domain.WateringPlan, error) {
+	if err := s.validator.Struct(wpc); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	fn, err := s.prepareLinks(ctx, wpc.VehicleID, wpc.UserIDs, wpc.TreeClusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fn = append(fn,
+		wateringplan.WithDate(wpc.Date),
+		wateringplan.WithDescription(wpc.Description),
+		wateringplan.WithStatus(domain.WateringPlanStatusPlanned),
+	)
+
+	wp, err := s.wateringPlanRepo.Create(ctx, fn...)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return wp, nil
+}
+
+func (s *WateringPlanService) Update(ctx context.Context, id int32, wpu *domain.WateringPlanUpdate) (*domain.WateringPlan, error) {
+	if err := s.validator.Struct(wpu); err != nil {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("validation error: %s", err.Error()))
+	}
+
+	current, err := s.wateringPlanRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	if !slices.Contains(statusTransitions[current.Status], wpu.Status) {
+		return nil, service.NewError(service.BadRequest, fmt.Sprintf("watering plan can not change from %s to %s", current.Status, wpu.Status))
+	}
+
+	fn, err := s.prepareLinks(ctx, wpu.VehicleID, wpu.UserIDs, wpu.TreeClusterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	fn = append(fn,
+		wateringplan.WithDate(wpu.Date),
+		wateringplan.WithDescription(wpu.Description),
+		wateringplan.WithStatus(wpu.Status),
+	)
+
+	wp, err := s.wateringPlanRepo.Update(ctx, id, fn...)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	return wp, nil
+}
+
+func (s *WateringPlanService) Delete(ctx context.Context, id int32) error {
+	if _, err := s.wateringPlanRepo.GetByID(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	if err := s.wateringPlanRepo.Delete(ctx, id); err != nil {
+		return handleError(err)
+	}
+
+	return nil
+}
+
+func (s *WateringPlanService) Ready() bool {
+	return s.wateringPlanRepo != nil && s.treeClusterRepo != nil && s.vehicleRepo != nil
+}
+
+// prepareLinks loads the vehicle and the tree clusters of a plan, checks that the users exist and
+// calculates the water required to water all trees of the clusters
+func (s *WateringPlanService) prepareLinks(
+	ctx context.Context,
+	vehicleID int32,
+	userIDs []uuid.UUID,
+	treeClusterIDs []int32,
+) ([]domain.EntityFunc[domain.WateringPlan], error) {
+	if slices.Contains(userIDs, uuid.Nil) {
+		return nil, service.NewError(service.BadRequest, "user ids must not be empty")
+	}
+
+	vehicle, err := s.vehicleRepo.GetByID(ctx, vehicleID)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	// users are managed in keycloak, the database can't ensure that the users of the plan exist
+	for _, userID := range userIDs {
+		if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+			return nil, handleError(err)
+		}
+	}
+
+	treeClusters := make([]*domain.TreeCluster, len(treeClusterIDs))
+	totalWater := 0.0
+	for i, tcID := range treeClusterIDs {
+		tc, err := s.treeClusterRepo.GetByID(ctx, tcID)
+		if err != nil {
+			return nil, handleError(err)
+		}
+
+		if tc.Archived {
+			return nil, service.NewError(service.BadRequest, fmt.Sprintf("tree cluster %d is archived", tcID))
+		}
+
+		treeClusters[i] = tc
+		totalWater += float64(len(tc.Trees)) * s.waterPerTree
+	}
+
+	return []domain.EntityFunc[domain.WateringPlan]{
+		wateringplan.WithVehicle(vehicle),
+		wateringplan.WithUserIDs(userIDs),
+		wateringplan.WithTreeClusters(treeClusters),
+		wateringplan.WithTotalWaterRequired(totalWater),
+	}, nil
+}
+
+func handleError(err error) error {
+	if errors.Is(err, storage.ErrEntityNotFound) ||
+		errors.Is(err, storage.ErrWateringPlanNotFound) ||
+		errors.Is(err, storage.ErrVehicleNotFound) ||
+		errors.Is(err, storage.ErrTreeClusterNotFound) ||
+		errors.Is(err, storage.ErrUserNotFound) {
+		return service.NewError(service.NotFound, err.Error())
+	}
+
+	if errors.Is(err, storage.ErrInvalidSortField) {
+		return service.NewError(service.BadRequest, err.Error())
+	}
+
+	return service.NewError(service.InternalError, err.Error())
+}
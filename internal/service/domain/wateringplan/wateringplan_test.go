@@ -0,0 +1,171 @@
+package wateringplan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service"
+	"github.com/green-ecolution/green-ecolution-backend/internal/service/domain/servicetest"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestService(t *testing.T) (service.WateringPlanService, servicetest.Mocks) {
+	m := servicetest.NewMocks(t)
+	cfg := &config.WateringConfig{WaterPerTree: 80}
+	return NewWateringPlanService(m.WateringPlanRepo, m.TreeClusterRepo, m.VehicleRepo, m.UserRepo, cfg), m
+}
+
+func TestWateringPlanService_Create(t *testing.T) {
+	input := &entities.WateringPlanCreate{
+		Date:           time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC),
+		VehicleID:      1,
+		UserIDs:        []uuid.UUID{uuid.New()},
+		TreeClusterIDs: []int32{2, 1},
+	}
+
+	t.Run("should create planned watering plan with water required for all trees", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+		vehicle := &entities.Vehicle{ID: 1}
+		cluster1 := &entities.TreeCluster{ID: 1, Trees: []*entities.Tree{{ID: 1}}}
+		cluster2 := &entities.TreeCluster{ID: 2, Trees: []*entities.Tree{{ID: 2}, {ID: 3}}}
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(vehicle, nil)
+		m.UserRepo.EXPECT().GetByID(context.Background(), input.UserIDs[0]).Return(&entities.User{ID: input.UserIDs[0]}, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(cluster2, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(cluster1, nil)
+		m.WateringPlanRepo.EXPECT().Create(context.Background(), mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, fn ...entities.EntityFunc[entities.WateringPlan]) (*entities.WateringPlan, error) {
+				wp := &entities.WateringPlan{}
+				for _, f := range fn {
+					f(wp)
+				}
+				return wp, nil
+			})
+		result, err := svc.Create(context.Background(), input)
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, entities.WateringPlanStatusPlanned, result.Status)
+		assert.Equal(t, 240.0, result.TotalWaterRequired)
+		assert.Equal(t, vehicle, result.Vehicle)
+		assert.Equal(t, []*entities.TreeCluster{cluster2, cluster1}, result.TreeClusters)
+	})
+
+	t.Run("should return bad request when tree cluster is archived", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		m.UserRepo.EXPECT().GetByID(context.Background(), input.UserIDs[0]).Return(&entities.User{ID: input.UserIDs[0]}, nil)
+		m.TreeClusterRepo.EXPECT().GetByID(context.Background(), int32(2)).Return(&entities.TreeCluster{ID: 2, Archived: true}, nil)
+		result, err := svc.Create(context.Background(), input)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when vehicle does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrVehicleNotFound)
+		result, err := svc.Create(context.Background(), input)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return not found when user does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.VehicleRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(&entities.Vehicle{ID: 1}, nil)
+		m.UserRepo.EXPECT().GetByID(context.Background(), input.UserIDs[0]).Return(nil, storage.ErrUserNotFound)
+		result, err := svc.Create(context.Background(), input)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+
+	t.Run("should return bad request when a user is assigned twice", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+		userID := uuid.New()
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.WateringPlanCreate{
+			Date:           input.Date,
+			VehicleID:      1,
+			UserIDs:        []uuid.UUID{userID, userID},
+			TreeClusterIDs: []int32{1},
+		})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return bad request when no date is given", func(t *testing.T) {
+		// given
+		svc, _ := newTestService(t)
+
+		// when
+		result, err := svc.Create(context.Background(), &entities.WateringPlanCreate{VehicleID: 1})
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+}
+
+func TestWateringPlanService_Update(t *testing.T) {
+	input := &entities.WateringPlanUpdate{
+		Date:           time.Date(2024, 10, 16, 0, 0, 0, 0, time.UTC),
+		Status:         entities.WateringPlanStatusActive,
+		VehicleID:      1,
+		UserIDs:        []uuid.UUID{uuid.New()},
+		TreeClusterIDs: []int32{1},
+	}
+
+	t.Run("should reject status change of finished watering plan", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.WateringPlanRepo.EXPECT().GetByID(context.Background(), int32(1)).
+			Return(&entities.WateringPlan{ID: 1, Status: entities.WateringPlanStatusFinished}, nil)
+		result, err := svc.Update(context.Background(), 1, input)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.BadRequest)
+	})
+
+	t.Run("should return not found when watering plan does not exist", func(t *testing.T) {
+		// given
+		svc, m := newTestService(t)
+
+		// when
+		m.WateringPlanRepo.EXPECT().GetByID(context.Background(), int32(1)).Return(nil, storage.ErrWateringPlanNotFound)
+		result, err := svc.Update(context.Background(), 1, input)
+
+		// then
+		assert.Nil(t, result)
+		servicetest.AssertErrorCode(t, err, service.NotFound)
+	})
+}
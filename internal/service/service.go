@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"reflect"
 
+	"github.com/google/uuid"
 	domain "github.com/green-ecolution/green-ecolution-backend/internal/entities"
 )
 
@@ -55,8 +57,19 @@ type MqttService interface {
 
 type TreeService interface {
 	Service
-	GetAll(ctx context.Context) ([]*domain.Tree, error)
+	// GetAll returns the requested page of trees and the total number of trees matching the query
+	GetAll(ctx context.Context, query domain.TreeQuery) ([]*domain.Tree, int64, error)
 	GetByID(ctx context.Context, id int32) (*domain.Tree, error)
+	// GetNearby returns the trees closest to a point with their distance in meters
+	GetNearby(ctx context.Context, query *domain.NearbyTreeQuery) ([]*domain.NearbyTree, error)
+	Create(ctx context.Context, tree *domain.TreeCreate) (*domain.Tree, error)
+	Update(ctx context.Context, id int32, tree *domain.TreeUpdate) (*domain.Tree, error)
+	Delete(ctx context.Context, id int32) error
+	GetImages(ctx context.Context, id int32) ([]*domain.Image, error)
+	AddImages(ctx context.Context, id int32, imageIDs []int32) (*domain.Tree, error)
+	RemoveImage(ctx context.Context, id, imageID int32) (*domain.Tree, error)
+	// Import creates or updates the trees of a tree inventory file, matched by tree number
+	Import(ctx context.Context, ti *domain.TreeImport) (*domain.TreeImportResult, error)
 }
 
 type AuthService interface {
@@ -66,21 +79,117 @@ type AuthService interface {
 	ClientTokenCallback(ctx context.Context, loginCallback *domain.LoginCallback) (*domain.ClientToken, error)
 	Register(ctx context.Context, user *domain.RegisterUser) (*domain.User, error)
 	RetrospectToken(ctx context.Context, token string) (*domain.IntroSpectTokenResult, error)
+	GetAllUsers(ctx context.Context, query domain.UserQuery) ([]*domain.User, int64, error)
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	UpdateUser(ctx context.Context, id uuid.UUID, user *domain.UserUpdate) (*domain.User, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	GetUserRoles(ctx context.Context, id uuid.UUID) ([]*domain.Role, error)
+	AddUserRoles(ctx context.Context, id uuid.UUID, roles []string) error
+	RemoveUserRoles(ctx context.Context, id uuid.UUID, roles []string) error
+}
+
+type RoleService interface {
+	Service
+	GetAll(ctx context.Context) ([]*domain.Role, error)
+	GetByName(ctx context.Context, name string) (*domain.Role, error)
+	GetUsers(ctx context.Context, name string) ([]*domain.User, error)
+}
+
+type WateringPlanService interface {
+	Service
+	GetAll(ctx context.Context, query domain.Query) ([]*domain.WateringPlan, int64, error)
+	GetByID(ctx context.Context, id int32) (*domain.WateringPlan, error)
+	Create(ctx context.Context, wpc *domain.WateringPlanCreate) (*domain.WateringPlan, error)
+	Update(ctx context.Context, id int32, wpu *domain.WateringPlanUpdate) (*domain.WateringPlan, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+type WateringEventService interface {
+	Service
+	GetAll(ctx context.Context, query domain.WateringEventQuery) ([]*domain.WateringEvent, int64, error)
+	// GetTotalWaterAmount returns the liters of all events matching the filters of the query
+	GetTotalWaterAmount(ctx context.Context, query domain.WateringEventQuery) (float64, error)
+	GetByID(ctx context.Context, id int32) (*domain.WateringEvent, error)
+	// Create records a watering and updates when the tree cluster was watered last
+	Create(ctx context.Context, wec *domain.WateringEventCreate) (*domain.WateringEvent, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+type RouteService interface {
+	Service
+	// Calculate returns a watering tour visiting the tree clusters with the vehicle
+	Calculate(ctx context.Context, rc *domain.RouteCreate) (*domain.Route, error)
+	// CalculateForWateringPlan returns the watering tour for the vehicle and tree clusters of the plan
+	CalculateForWateringPlan(ctx context.Context, id int32) (*domain.Route, error)
 }
 
 type RegionService interface {
 	Service
-	GetAll(ctx context.Context) ([]*domain.Region, error)
+	GetAll(ctx context.Context, query domain.Query) ([]*domain.Region, int64, error)
 	GetByID(ctx context.Context, id int32) (*domain.Region, error)
+	Create(ctx context.Context, rc *domain.RegionCreate) (*domain.Region, error)
+	Update(ctx context.Context, id int32, ru *domain.RegionUpdate) (*domain.Region, error)
+	Delete(ctx context.Context, id int32) error
 }
 
 type TreeClusterService interface {
 	Service
-	GetAll(ctx context.Context) ([]*domain.TreeCluster, error)
+	GetAll(ctx context.Context, query domain.TreeClusterQuery) ([]*domain.TreeCluster, int64, error)
 	GetByID(ctx context.Context, id int32) (*domain.TreeCluster, error)
 	Create(ctx context.Context, tc *domain.TreeClusterCreate) (*domain.TreeCluster, error)
 	Update(ctx context.Context, id int32, tc *domain.TreeClusterUpdate) (*domain.TreeCluster, error)
 	Delete(ctx context.Context, id int32) error
+	// Suggest proposes tree clusters for the trees without tree cluster by grouping them by location
+	Suggest(ctx context.Context, query *domain.TreeClusterSuggestionQuery) ([]*domain.TreeClusterSuggestion, error)
+	// AcceptSuggestions creates all tree clusters at once
+	AcceptSuggestions(ctx context.Context, tcs []*domain.TreeClusterCreate) ([]*domain.TreeCluster, error)
+}
+
+type FlowerbedService interface {
+	Service
+	GetAll(ctx context.Context, query domain.Query) ([]*domain.Flowerbed, int64, error)
+	GetByID(ctx context.Context, id int32) (*domain.Flowerbed, error)
+	Create(ctx context.Context, fc *domain.FlowerbedCreate) (*domain.Flowerbed, error)
+	Update(ctx context.Context, id int32, fu *domain.FlowerbedUpdate) (*domain.Flowerbed, error)
+	Archive(ctx context.Context, id int32) error
+	Delete(ctx context.Context, id int32) error
+	GetSensor(ctx context.Context, id int32) (*domain.Sensor, error)
+	AddSensor(ctx context.Context, id, sensorID int32) (*domain.Flowerbed, error)
+	RemoveSensor(ctx context.Context, id, sensorID int32) (*domain.Flowerbed, error)
+	GetImages(ctx context.Context, id int32) ([]*domain.Image, error)
+	AddImages(ctx context.Context, id int32, imageIDs []int32) (*domain.Flowerbed, error)
+	RemoveImage(ctx context.Context, id, imageID int32) (*domain.Flowerbed, error)
+}
+
+type ImageService interface {
+	Service
+	GetAll(ctx context.Context, query domain.Query) ([]*domain.Image, int64, error)
+	GetByID(ctx context.Context, id int32) (*domain.Image, error)
+	Upload(ctx context.Context, upload *domain.ImageUpload) (*domain.Image, error)
+	// Download returns the image stored under the object key together with its content.
+	// The caller has to close the returned reader.
+	Download(ctx context.Context, key string) (*domain.Image, io.ReadCloser, error)
+	Delete(ctx context.Context, id int32) error
+}
+
+type VehicleService interface {
+	Service
+	GetAll(ctx context.Context, query domain.Query) ([]*domain.Vehicle, int64, error)
+	GetByID(ctx context.Context, id int32) (*domain.Vehicle, error)
+	GetByPlate(ctx context.Context, plate string) (*domain.Vehicle, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Vehicle, error)
+	Create(ctx context.Context, vc *domain.VehicleCreate) (*domain.Vehicle, error)
+	Update(ctx context.Context, id int32, vu *domain.VehicleUpdate) (*domain.Vehicle, error)
+	Delete(ctx context.Context, id int32) error
+	GetAssignedUserIDs(ctx context.Context, id int32) ([]uuid.UUID, error)
+	AssignToUser(ctx context.Context, id int32, userID uuid.UUID) error
+	UnassignFromUser(ctx context.Context, id int32, userID uuid.UUID) error
+}
+
+type TileService interface {
+	Service
+	// GetTile returns the Mapbox vector tile of a map layer, an empty tile if it has no features
+	GetTile(ctx context.Context, query *domain.TileQuery) ([]byte, error)
 }
 
 type Service interface {
@@ -88,12 +197,20 @@ type Service interface {
 }
 
 type Services struct {
-	InfoService        InfoService
-	MqttService        MqttService
-	TreeService        TreeService
-	AuthService        AuthService
-	RegionService      RegionService
-	TreeClusterService TreeClusterService
+	InfoService          InfoService
+	MqttService          MqttService
+	TreeService          TreeService
+	AuthService          AuthService
+	RegionService        RegionService
+	TreeClusterService   TreeClusterService
+	FlowerbedService     FlowerbedService
+	VehicleService       VehicleService
+	ImageService         ImageService
+	RoleService          RoleService
+	WateringPlanService  WateringPlanService
+	RouteService         RouteService
+	WateringEventService WateringEventService
+	TileService          TileService
 }
 
 func (s *Services) AllServicesReady() bool {
@@ -16,13 +16,29 @@ func TestAllServiceReady(t *testing.T) {
 		authSvc := serviceMock.NewMockAuthService(t)
 		regionSvc := serviceMock.NewMockRegionService(t)
 		treeClusterSvc := serviceMock.NewMockTreeClusterService(t)
+		flowerbedSvc := serviceMock.NewMockFlowerbedService(t)
+		vehicleSvc := serviceMock.NewMockVehicleService(t)
+		imageSvc := serviceMock.NewMockImageService(t)
+		roleSvc := serviceMock.NewMockRoleService(t)
+		wateringPlanSvc := serviceMock.NewMockWateringPlanService(t)
+		routeSvc := serviceMock.NewMockRouteService(t)
+		wateringEventSvc := serviceMock.NewMockWateringEventService(t)
+		tileSvc := serviceMock.NewMockTileService(t)
 		svc := Services{
-			InfoService:        infoSvc,
-			MqttService:        mqttSvc,
-			TreeService:        treeSvc,
-			AuthService:        authSvc,
-			RegionService:      regionSvc,
-			TreeClusterService: treeClusterSvc,
+			InfoService:          infoSvc,
+			MqttService:          mqttSvc,
+			TreeService:          treeSvc,
+			AuthService:          authSvc,
+			RegionService:        regionSvc,
+			TreeClusterService:   treeClusterSvc,
+			FlowerbedService:     flowerbedSvc,
+			VehicleService:       vehicleSvc,
+			ImageService:         imageSvc,
+			RoleService:          roleSvc,
+			WateringPlanService:  wateringPlanSvc,
+			RouteService:         routeSvc,
+			WateringEventService: wateringEventSvc,
+			TileService:          tileSvc,
 		}
 
 		// when
@@ -32,6 +48,14 @@ func TestAllServiceReady(t *testing.T) {
 		authSvc.EXPECT().Ready().Return(true)
 		regionSvc.EXPECT().Ready().Return(true)
 		treeClusterSvc.EXPECT().Ready().Return(true)
+		flowerbedSvc.EXPECT().Ready().Return(true)
+		vehicleSvc.EXPECT().Ready().Return(true)
+		imageSvc.EXPECT().Ready().Return(true)
+		roleSvc.EXPECT().Ready().Return(true)
+		wateringPlanSvc.EXPECT().Ready().Return(true)
+		routeSvc.EXPECT().Ready().Return(true)
+		wateringEventSvc.EXPECT().Ready().Return(true)
+		tileSvc.EXPECT().Ready().Return(true)
 
 		ready := svc.AllServicesReady()
 
@@ -2,6 +2,7 @@ package keycloak
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/Nerzal/gocloak/v13"
 	"github.com/green-ecolution/green-ecolution-backend/config"
@@ -28,3 +29,28 @@ func loginRestAPIClient(ctx context.Context, baseURL, clientID, clientSecret, re
 	}
 	return token, nil
 }
+
+// loginAdmin logs the backend client into keycloak to use the admin rest api
+func loginAdmin(ctx context.Context, cfg *config.IdentityAuthConfig) (*gocloak.GoCloak, string, error) {
+	clientToken, err := loginRestAPIClient(ctx, cfg.KeyCloak.BaseURL, cfg.KeyCloak.ClientID, cfg.KeyCloak.ClientSecret, cfg.KeyCloak.Realm)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return gocloak.NewClient(cfg.KeyCloak.BaseURL), clientToken.AccessToken, nil
+}
+
+// handleError maps the status of a keycloak api error to the storage errors
+func handleError(err error, msg string, errNotFound error) error {
+	var apiErr *gocloak.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return errNotFound
+		case http.StatusConflict:
+			return storage.ErrDuplicateEntity
+		}
+	}
+
+	return errors.Wrap(err, msg)
+}
@@ -0,0 +1,89 @@
+package keycloak
+
+import (
+	"context"
+
+	"github.com/Nerzal/gocloak/v13"
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// usersPageSize is the number of users requested at once when loading the users of a role
+const usersPageSize = 100
+
+type RoleRepository struct {
+	cfg *config.IdentityAuthConfig
+}
+
+func NewRoleRepository(cfg *config.IdentityAuthConfig) storage.RoleRepository {
+	return &RoleRepository{
+		cfg: cfg,
+	}
+}
+
+func (r *RoleRepository) GetAll(ctx context.Context) ([]*entities.Role, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kcRoles, err := client.GetRealmRoles(ctx, accessToken, r.cfg.KeyCloak.Realm, gocloak.GetRoleParams{})
+	if err != nil {
+		return nil, handleError(err, "failed to get roles", storage.ErrRoleNotFound)
+	}
+
+	roles := make([]*entities.Role, 0, len(kcRoles))
+	for _, kcRole := range kcRoles {
+		roles = append(roles, keyCloakRoleToRole(kcRole))
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) GetByName(ctx context.Context, name string) (*entities.Role, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kcRole, err := client.GetRealmRole(ctx, accessToken, r.cfg.KeyCloak.Realm, name)
+	if err != nil {
+		return nil, handleError(err, "failed to get role", storage.ErrRoleNotFound)
+	}
+
+	return keyCloakRoleToRole(kcRole), nil
+}
+
+// GetUsers returns all users holding the realm role. Keycloak can't count them, so all pages are loaded.
+func (r *RoleRepository) GetUsers(ctx context.Context, name string) ([]*entities.User, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*entities.User, 0)
+	for first := 0; ; first += usersPageSize {
+		params := gocloak.GetUsersByRoleParams{
+			First: gocloak.IntP(first),
+			Max:   gocloak.IntP(usersPageSize),
+		}
+
+		kcUsers, err := client.GetUsersByRoleName(ctx, accessToken, r.cfg.KeyCloak.Realm, name, params)
+		if err != nil {
+			return nil, handleError(err, "failed to get users of role", storage.ErrRoleNotFound)
+		}
+
+		for _, kcUser := range kcUsers {
+			user, err := keyCloakUserToUser(kcUser)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+
+		if len(kcUsers) < usersPageSize {
+			return users, nil
+		}
+	}
+}
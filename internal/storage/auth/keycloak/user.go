@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/Nerzal/gocloak/v13"
 	"github.com/google/uuid"
@@ -27,37 +28,34 @@ func NewUserRepository(cfg *config.IdentityAuthConfig) storage.UserRepository {
 func (r *UserRepository) Create(ctx context.Context, user *entities.User, password string, roles *[]string) (*entities.User, error) {
 	slog.Debug("Creating user in keycloak", "user", user)
 	keyCloakUser := userToKeyCloakUser(user)
+	keyCloakUser.Enabled = gocloak.BoolP(true)
 
-	clientToken, err := loginRestAPIClient(ctx, r.cfg.KeyCloak.BaseURL, r.cfg.KeyCloak.ClientID, r.cfg.KeyCloak.ClientSecret, r.cfg.KeyCloak.Realm)
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	client := gocloak.NewClient(r.cfg.KeyCloak.BaseURL)
-	userID, err := client.CreateUser(ctx, clientToken.AccessToken, r.cfg.KeyCloak.Realm, *keyCloakUser)
+	userID, err := client.CreateUser(ctx, accessToken, r.cfg.KeyCloak.Realm, *keyCloakUser)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create user")
+		return nil, handleError(err, "failed to create user", storage.ErrUserNotFound)
 	}
 
-	if err = client.SetPassword(ctx, clientToken.AccessToken, userID, r.cfg.KeyCloak.Realm, password, false); err != nil {
+	if err = client.SetPassword(ctx, accessToken, userID, r.cfg.KeyCloak.Realm, password, false); err != nil {
 		return nil, errors.Wrap(err, "failed to set password")
 	}
 
-	kcRoles := make([]gocloak.Role, len(*roles))
-	for _, roleName := range *roles {
-		roleNameLowerCase := strings.ToLower(roleName)
-		roleKeyCloak, err := client.GetRealmRole(ctx, clientToken.AccessToken, r.cfg.KeyCloak.Realm, roleNameLowerCase)
+	if roles != nil && len(*roles) > 0 {
+		kcRoles, err := r.getRealmRoles(ctx, client, accessToken, *roles)
 		if err != nil {
-			return nil, errors.Wrap(err, fmt.Sprintf("failed to get role by name: '%v'", roleNameLowerCase))
+			return nil, err
 		}
-		kcRoles = append(kcRoles, *roleKeyCloak)
-	}
 
-	if err = client.AddRealmRoleToUser(ctx, clientToken.AccessToken, r.cfg.KeyCloak.Realm, userID, kcRoles); err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to roles to user. roles '%v'", kcRoles))
+		if err = client.AddRealmRoleToUser(ctx, accessToken, r.cfg.KeyCloak.Realm, userID, kcRoles); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to add roles to user. roles '%v'", *roles))
+		}
 	}
 
-	userKeyCloak, err := client.GetUserByID(ctx, clientToken.AccessToken, r.cfg.KeyCloak.Realm, userID)
+	userKeyCloak, err := client.GetUserByID(ctx, accessToken, r.cfg.KeyCloak.Realm, userID)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("failed to get created user by id: '%v'", userID))
 	}
@@ -65,6 +63,63 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User, passwo
 	return keyCloakUserToUser(userKeyCloak)
 }
 
+// GetAll returns one page of the users matching the search of the query. Keycloak always sorts users by username,
+// so sorting by other fields is not supported.
+func (r *UserRepository) GetAll(ctx context.Context, query entities.UserQuery) ([]*entities.User, int64, error) {
+	if query.SortBy != "" || query.SortDirection == entities.SortDesc {
+		return nil, 0, storage.ErrInvalidSortField
+	}
+
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := client.GetUserCount(ctx, accessToken, r.cfg.KeyCloak.Realm, gocloak.GetUsersParams{Search: query.Search})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count users")
+	}
+
+	params := gocloak.GetUsersParams{
+		Search:              query.Search,
+		First:               gocloak.IntP(int(query.Offset())),
+		BriefRepresentation: gocloak.BoolP(false),
+	}
+	if query.Limit > 0 {
+		params.Max = gocloak.IntP(int(query.Limit))
+	}
+
+	kcUsers, err := client.GetUsers(ctx, accessToken, r.cfg.KeyCloak.Realm, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to get users")
+	}
+
+	users := make([]*entities.User, 0, len(kcUsers))
+	for _, kcUser := range kcUsers {
+		user, err := keyCloakUserToUser(kcUser)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	return users, int64(total), nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kcUser, err := client.GetUserByID(ctx, accessToken, r.cfg.KeyCloak.Realm, id.String())
+	if err != nil {
+		return nil, handleError(err, "failed to get user", storage.ErrUserNotFound)
+	}
+
+	return keyCloakUserToUser(kcUser)
+}
+
 func (r *UserRepository) GetByAccessToken(ctx context.Context, token string) (*entities.User, error) {
 	client := gocloak.NewClient(r.cfg.KeyCloak.BaseURL)
 	kUser, err := client.GetUserInfo(ctx, token, r.cfg.KeyCloak.Realm)
@@ -86,6 +141,106 @@ func (r *UserRepository) GetByAccessToken(ctx context.Context, token string) (*e
 	return user, nil
 }
 
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) (*entities.User, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// keycloak replaces the whole user, so the stored user is loaded to keep fields unknown to the app
+	kcUser, err := client.GetUserByID(ctx, accessToken, r.cfg.KeyCloak.Realm, user.ID.String())
+	if err != nil {
+		return nil, handleError(err, "failed to get user", storage.ErrUserNotFound)
+	}
+
+	kcUser.Username = gocloak.StringP(user.Username)
+	kcUser.FirstName = gocloak.StringP(user.FirstName)
+	kcUser.LastName = gocloak.StringP(user.LastName)
+	kcUser.Email = gocloak.StringP(user.Email)
+	kcUser.Enabled = gocloak.BoolP(user.Enabled)
+	setAttribute(kcUser, "employee_id", user.EmployeeID)
+	setAttribute(kcUser, "phone_number", user.PhoneNumber)
+
+	if err := client.UpdateUser(ctx, accessToken, r.cfg.KeyCloak.Realm, *kcUser); err != nil {
+		return nil, handleError(err, "failed to update user", storage.ErrUserNotFound)
+	}
+
+	kcUser, err = client.GetUserByID(ctx, accessToken, r.cfg.KeyCloak.Realm, user.ID.String())
+	if err != nil {
+		return nil, handleError(err, "failed to get updated user", storage.ErrUserNotFound)
+	}
+
+	return keyCloakUserToUser(kcUser)
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteUser(ctx, accessToken, r.cfg.KeyCloak.Realm, id.String()); err != nil {
+		return handleError(err, "failed to delete user", storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetRoles(ctx context.Context, id uuid.UUID) ([]*entities.Role, error) {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	kcRoles, err := client.GetRealmRolesByUserID(ctx, accessToken, r.cfg.KeyCloak.Realm, id.String())
+	if err != nil {
+		return nil, handleError(err, "failed to get roles of user", storage.ErrUserNotFound)
+	}
+
+	roles := make([]*entities.Role, 0, len(kcRoles))
+	for _, kcRole := range kcRoles {
+		roles = append(roles, keyCloakRoleToRole(kcRole))
+	}
+
+	return roles, nil
+}
+
+func (r *UserRepository) AddRoles(ctx context.Context, id uuid.UUID, roles []string) error {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	kcRoles, err := r.getRealmRoles(ctx, client, accessToken, roles)
+	if err != nil {
+		return err
+	}
+
+	if err := client.AddRealmRoleToUser(ctx, accessToken, r.cfg.KeyCloak.Realm, id.String(), kcRoles); err != nil {
+		return handleError(err, fmt.Sprintf("failed to add roles to user. roles '%v'", roles), storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) RemoveRoles(ctx context.Context, id uuid.UUID, roles []string) error {
+	client, accessToken, err := loginAdmin(ctx, r.cfg)
+	if err != nil {
+		return err
+	}
+
+	kcRoles, err := r.getRealmRoles(ctx, client, accessToken, roles)
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteRealmRoleFromUser(ctx, accessToken, r.cfg.KeyCloak.Realm, id.String(), kcRoles); err != nil {
+		return handleError(err, fmt.Sprintf("failed to remove roles from user. roles '%v'", roles), storage.ErrUserNotFound)
+	}
+
+	return nil
+}
+
 func (r *UserRepository) RemoveSession(ctx context.Context, refreshToken string) error {
 	client := gocloak.NewClient(r.cfg.KeyCloak.BaseURL)
 	if err := client.Logout(ctx, r.cfg.KeyCloak.Frontend.ClientID, r.cfg.KeyCloak.Frontend.ClientSecret, r.cfg.KeyCloak.Realm, refreshToken); err != nil {
@@ -94,17 +249,43 @@ func (r *UserRepository) RemoveSession(ctx context.Context, refreshToken string)
 	return nil
 }
 
+// getRealmRoles looks up the realm roles with the given names, which are compared in lower case
+func (r *UserRepository) getRealmRoles(ctx context.Context, client *gocloak.GoCloak, accessToken string, names []string) ([]gocloak.Role, error) {
+	kcRoles := make([]gocloak.Role, 0, len(names))
+	for _, roleName := range names {
+		roleNameLowerCase := strings.ToLower(roleName)
+		roleKeyCloak, err := client.GetRealmRole(ctx, accessToken, r.cfg.KeyCloak.Realm, roleNameLowerCase)
+		if err != nil {
+			return nil, handleError(err, fmt.Sprintf("failed to get role by name: '%v'", roleNameLowerCase), storage.ErrRoleNotFound)
+		}
+		kcRoles = append(kcRoles, *roleKeyCloak)
+	}
+
+	return kcRoles, nil
+}
+
 func keyCloakUserToUser(user *gocloak.User) (*entities.User, error) {
-	userID, err := uuid.Parse(*user.ID)
+	userID, err := uuid.Parse(gocloak.PString(user.ID))
 	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse user id: '%v'", *user.ID))
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse user id: '%v'", gocloak.PString(user.ID)))
+	}
+
+	var createdAt time.Time
+	if user.CreatedTimestamp != nil {
+		createdAt = time.UnixMilli(*user.CreatedTimestamp)
 	}
+
 	return &entities.User{
-		ID:        userID,
-		Username:  *user.Username,
-		FirstName: *user.FirstName,
-		LastName:  *user.LastName,
-		Email:     *user.Email,
+		ID:            userID,
+		CreatedAt:     createdAt,
+		Username:      gocloak.PString(user.Username),
+		FirstName:     gocloak.PString(user.FirstName),
+		LastName:      gocloak.PString(user.LastName),
+		Email:         gocloak.PString(user.Email),
+		EmployeeID:    getAttribute(user, "employee_id"),
+		PhoneNumber:   getAttribute(user, "phone_number"),
+		EmailVerified: gocloak.PBool(user.EmailVerified),
+		Enabled:       gocloak.PBool(user.Enabled),
 	}, nil
 }
 
@@ -118,7 +299,36 @@ func userToKeyCloakUser(user *entities.User) *gocloak.User {
 		FirstName:  gocloak.StringP(user.FirstName),
 		LastName:   gocloak.StringP(user.LastName),
 		Email:      gocloak.StringP(user.Email),
-		Enabled:    gocloak.BoolP(true),
+		Enabled:    gocloak.BoolP(user.Enabled),
 		Attributes: &attribute,
 	}
 }
+
+func keyCloakRoleToRole(role *gocloak.Role) *entities.Role {
+	return &entities.Role{
+		ID:          gocloak.PString(role.ID),
+		Name:        gocloak.PString(role.Name),
+		Description: gocloak.PString(role.Description),
+	}
+}
+
+func getAttribute(user *gocloak.User, key string) string {
+	if user.Attributes == nil {
+		return ""
+	}
+
+	values := (*user.Attributes)[key]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func setAttribute(user *gocloak.User, key, value string) {
+	if user.Attributes == nil {
+		user.Attributes = &map[string][]string{}
+	}
+
+	(*user.Attributes)[key] = []string{value}
+}
@@ -9,9 +9,11 @@ import (
 func NewRepository(cfg *config.IdentityAuthConfig) *storage.Repository {
 	authRepo := keycloak.NewKeycloakRepository(cfg)
 	userRepo := keycloak.NewUserRepository(cfg)
+	roleRepo := keycloak.NewRoleRepository(cfg)
 
 	return &storage.Repository{
 		Auth: authRepo,
 		User: userRepo,
+		Role: roleRepo,
 	}
 }
@@ -0,0 +1,100 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// BlobRepository stores objects as files in a directory on the local filesystem
+type BlobRepository struct {
+	path    string
+	baseURL string
+}
+
+func NewBlobRepository(cfg *config.LocalObjectStorageConfig) (*BlobRepository, error) {
+	if err := os.MkdirAll(cfg.Path, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &BlobRepository{
+		path:    cfg.Path,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+	}, nil
+}
+
+func (r *BlobRepository) Upload(_ context.Context, key, _ string, body io.Reader, _ int64) error {
+	path, err := r.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a failed upload never leaves a partial object behind
+	tmp, err := os.CreateTemp(r.path, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (r *BlobRepository) Download(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := r.filePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, storage.ErrBlobNotFound
+		}
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (r *BlobRepository) Delete(_ context.Context, key string) error {
+	path, err := r.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (r *BlobRepository) URL(key string) string {
+	return r.baseURL + "/" + url.PathEscape(key)
+}
+
+// filePath returns the path of the file for the key. Keys must not contain path separators
+// to prevent access to files outside of the storage directory.
+func (r *BlobRepository) filePath(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", storage.ErrInvalidBlobKey
+	}
+
+	return filepath.Join(r.path, key), nil
+}
@@ -3,6 +3,7 @@ package local
 import (
 	"github.com/green-ecolution/green-ecolution-backend/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/local/blob"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/local/info"
 )
 
@@ -12,7 +13,17 @@ func NewRepository(cfg *config.Config) (*storage.Repository, error) {
 		return nil, err
 	}
 
-	return &storage.Repository{
+	repo := &storage.Repository{
 		Info: infoRepo,
-	}, nil
+	}
+
+	if cfg.Storage.Type == "local" {
+		blobRepo, err := blob.NewBlobRepository(&cfg.Storage.Local)
+		if err != nil {
+			return nil, err
+		}
+		repo.Blob = blobRepo
+	}
+
+	return repo, nil
 }
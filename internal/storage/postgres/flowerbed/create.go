@@ -2,24 +2,28 @@ package flowerbed
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 )
 
 func defaultFlowerbed() *entities.Flowerbed {
 	return &entities.Flowerbed{
-		Sensor:         &entities.Sensor{},
+		Sensor:         nil,
 		Size:           0,
 		Description:    "",
 		NumberOfPlants: 0,
 		MoistureLevel:  0,
-		Region:         &entities.Region{},
+		Region:         nil,
 		Address:        "",
 		Latitude:       0,
 		Longitude:      0,
 		Images:         make([]*entities.Image, 0),
 		Archived:       false,
+		Geometry:       nil,
 	}
 }
 
@@ -40,12 +44,17 @@ func (r *FlowerbedRepository) Create(ctx context.Context, fFn ...entities.Entity
 }
 
 func (r *FlowerbedRepository) CreateAndLinkImages(ctx context.Context, fFn ...entities.EntityFunc[entities.Flowerbed]) (*entities.Flowerbed, error) {
-	entity, err := r.Create(ctx, fFn...)
-	if err != nil {
-		return nil, err
-	}
+	var entity *entities.Flowerbed
+	err := r.store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		entity, err = r.Create(ctx, fFn...)
+		if err != nil {
+			return err
+		}
 
-	if err := r.handleImages(ctx, entity.ID, entity.Images); err != nil {
+		return r.handleImages(ctx, entity.ID, entity.Images)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -53,16 +62,21 @@ func (r *FlowerbedRepository) CreateAndLinkImages(ctx context.Context, fFn ...en
 }
 
 func (r *FlowerbedRepository) createEntity(ctx context.Context, entity *entities.Flowerbed) (*int32, error) {
+	if err := validateLocation(entity); err != nil {
+		return nil, err
+	}
+
 	args := sqlc.CreateFlowerbedParams{
-		SensorID:       &entity.Sensor.ID,
+		SensorID:       sensorID(entity),
 		Size:           entity.Size,
 		Description:    entity.Description,
 		NumberOfPlants: entity.NumberOfPlants,
 		MoistureLevel:  entity.MoistureLevel,
-		RegionID:       &entity.Region.ID,
+		RegionID:       regionID(entity),
 		Address:        entity.Address,
 		Latitude:       entity.Latitude,
 		Longitude:      entity.Longitude,
+		StGeomfromtext: mapper.MapPolygonToWKT(entity.Geometry),
 	}
 
 	id, err := r.store.CreateFlowerbed(ctx, &args)
@@ -73,9 +87,23 @@ func (r *FlowerbedRepository) createEntity(ctx context.Context, entity *entities
 	return &id, nil
 }
 
+func sensorID(f *entities.Flowerbed) *int32 {
+	if f.Sensor == nil {
+		return nil
+	}
+	return &f.Sensor.ID
+}
+
+func regionID(f *entities.Flowerbed) *int32 {
+	if f.Region == nil {
+		return nil
+	}
+	return &f.Region.ID
+}
+
 func (r *FlowerbedRepository) handleImages(ctx context.Context, flowerbedID int32, images []*entities.Image) error {
 	for _, img := range images {
-		err := r.linkImages(ctx, flowerbedID, img.ID)
+		err := r.linkImage(ctx, flowerbedID, img)
 		if err != nil {
 			return err
 		}
@@ -84,10 +112,22 @@ func (r *FlowerbedRepository) handleImages(ctx context.Context, flowerbedID int3
 	return nil
 }
 
-func (r *FlowerbedRepository) linkImages(ctx context.Context, flowerbedID, imgID int32) error {
+// linkImage links the image to the flowerbed, the location mismatch flag of the image is kept
+// because the links are recreated on every update of the images
+func (r *FlowerbedRepository) linkImage(ctx context.Context, flowerbedID int32, img *entities.Image) error {
 	params := sqlc.LinkFlowerbedImageParams{
-		FlowerbedID: flowerbedID,
-		ImageID:     imgID,
+		FlowerbedID:      flowerbedID,
+		ImageID:          img.ID,
+		LocationMismatch: img.LocationMismatch,
 	}
 	return r.store.LinkFlowerbedImage(ctx, &params)
 }
+
+// validateLocation checks the center of the flowerbed, the outline is stored as given
+func validateLocation(f *entities.Flowerbed) error {
+	if _, err := entities.NewCoordinate(f.Latitude, f.Longitude); err != nil {
+		return fmt.Errorf("%w: %w", storage.ErrInvalidGeometry, err)
+	}
+
+	return nil
+}
@@ -111,6 +111,12 @@ func WithArchived(archived bool) entities.EntityFunc[entities.Flowerbed] {
 	}
 }
 
+func WithGeometry(geometry entities.Polygon) entities.EntityFunc[entities.Flowerbed] {
+	return func(f *entities.Flowerbed) {
+		f.Geometry = geometry
+	}
+}
+
 func WithImagesIDs(imagesIDs []int32) entities.EntityFunc[entities.Flowerbed] {
 	return func(f *entities.Flowerbed) {
 		for _, id := range imagesIDs {
@@ -130,18 +136,20 @@ func (r *FlowerbedRepository) Delete(ctx context.Context, id int32) error {
 }
 
 func (r *FlowerbedRepository) DeleteAndUnlinkImages(ctx context.Context, id int32) error {
-	images, err := r.GetAllImagesByID(ctx, id)
-	if err != nil {
-		return r.store.HandleError(errors.Wrap(err, "failed to get images"))
-	}
+	return r.store.WithTx(ctx, func(ctx context.Context) error {
+		images, err := r.GetAllImagesByID(ctx, id)
+		if err != nil {
+			return r.store.HandleError(errors.Wrap(err, "failed to get images"))
+		}
 
-	for _, img := range images {
-		if err := r.UnlinkImage(ctx, id, img.ID); err != nil {
-			return r.store.HandleError(errors.Wrap(err, "failed to unlink images"))
+		for _, img := range images {
+			if err := r.UnlinkImage(ctx, id, img.ID); err != nil {
+				return r.store.HandleError(errors.Wrap(err, "failed to unlink images"))
+			}
 		}
-	}
 
-	return r.Delete(ctx, id)
+		return r.Delete(ctx, id)
+	})
 }
 
 func (r *FlowerbedRepository) UnlinkImage(ctx context.Context, id, imageID int32) error {
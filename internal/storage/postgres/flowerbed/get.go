@@ -2,6 +2,8 @@ package flowerbed
 
 import (
 	"context"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -9,47 +11,48 @@ import (
 	"github.com/pkg/errors"
 )
 
-func (r *FlowerbedRepository) GetAll(ctx context.Context) ([]*entities.Flowerbed, error) {
-	row, err := r.store.GetAllFlowerbeds(ctx)
+func (r *FlowerbedRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Flowerbed, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "size", "created_at", "updated_at")
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, 0, err
 	}
 
-	data := r.mapper.FromSqlList(row)
-	for _, f := range data {
-		f.Sensor, err = r.GetSensorByFlowerbedID(ctx, f.ID)
-		if err != nil {
-			return nil, err
-		}
+	total, err := r.store.CountFlowerbeds(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
 
-		f.Images, err = r.GetAllImagesByID(ctx, f.ID)
-		if err != nil {
-			return nil, err
-		}
+	rows, err := r.store.GetAllFlowerbeds(ctx, &sqlc.GetAllFlowerbedsParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
 
-		f.Region, err = r.GetRegionByFlowerbedID(ctx, f.ID)
-		if err != nil {
-			return nil, err
+	data := r.mapper.FromSqlList(rows)
+	for _, f := range data {
+		if err := r.mapFields(ctx, f); err != nil {
+			return nil, 0, err
 		}
 	}
 
-	return data, nil
+	return data, total, nil
 }
 
 func (r *FlowerbedRepository) GetByID(ctx context.Context, id int32) (*entities.Flowerbed, error) {
 	row, err := r.store.GetFlowerbedByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrFlowerbedNotFound
+		}
 		return nil, r.store.HandleError(err)
 	}
 
 	data := r.mapper.FromSql(row)
-	data.Sensor, err = r.GetSensorByFlowerbedID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	data.Images, err = r.GetAllImagesByID(ctx, id)
-	if err != nil {
+	if err := r.mapFields(ctx, data); err != nil {
 		return nil, err
 	}
 
@@ -57,12 +60,18 @@ func (r *FlowerbedRepository) GetByID(ctx context.Context, id int32) (*entities.
 }
 
 func (r *FlowerbedRepository) GetAllImagesByID(ctx context.Context, flowerbedID int32) ([]*entities.Image, error) {
-	row, err := r.store.GetAllImagesByFlowerbedID(ctx, flowerbedID)
+	rows, err := r.store.GetAllImagesByFlowerbedID(ctx, flowerbedID)
 	if err != nil {
 		return nil, r.store.HandleError(err)
 	}
 
-	return r.imgMapper.FromSqlList(row), nil
+	images := make([]*entities.Image, len(rows))
+	for i, row := range rows {
+		images[i] = r.imgMapper.FromSql(&row.Image)
+		images[i].LocationMismatch = row.LocationMismatch
+	}
+
+	return images, nil
 }
 
 func (r *FlowerbedRepository) GetSensorByFlowerbedID(ctx context.Context, flowerbedID int32) (*entities.Sensor, error) {
@@ -88,3 +97,46 @@ func (r *FlowerbedRepository) GetRegionByFlowerbedID(ctx context.Context, flower
 
 	return r.regionMapper.FromSql(row), nil
 }
+
+// Map sensor, images and region entity to domain flowerbed. A missing sensor or region is not an error.
+func (r *FlowerbedRepository) mapFields(ctx context.Context, f *entities.Flowerbed) error {
+	var err error
+
+	f.Sensor, err = r.GetSensorByFlowerbedID(ctx, f.ID)
+	if err != nil && !errors.Is(err, storage.ErrSensorNotFound) {
+		return err
+	}
+
+	f.Images, err = r.GetAllImagesByID(ctx, f.ID)
+	if err != nil {
+		return err
+	}
+
+	f.Region, err = r.GetRegionByFlowerbedID(ctx, f.ID)
+	if err != nil && !errors.Is(err, storage.ErrRegionNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// GetByRegionOrArea returns the flowerbeds linked to the region or located inside the area.
+// Sensor and images are not loaded and the region only carries its id.
+func (r *FlowerbedRepository) GetByRegionOrArea(ctx context.Context, regionID int32, area entities.MultiPolygon) ([]*entities.Flowerbed, error) {
+	rows, err := r.store.GetFlowerbedsByRegionOrArea(ctx, &sqlc.GetFlowerbedsByRegionOrAreaParams{
+		RegionID: regionID,
+		Wkt:      area.WKT(),
+	})
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	data := r.mapper.FromSqlList(rows)
+	for i, row := range rows {
+		if row.RegionID != nil {
+			data[i].Region = &entities.Region{ID: *row.RegionID}
+		}
+	}
+
+	return data, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
 	"github.com/pkg/errors"
 )
 
@@ -27,12 +28,15 @@ func (r *FlowerbedRepository) Update(ctx context.Context, id int32, fFn ...entit
 }
 
 func (r *FlowerbedRepository) UpdateWithImages(ctx context.Context, id int32, fFn ...entities.EntityFunc[entities.Flowerbed]) (*entities.Flowerbed, error) {
-	f, err := r.Update(ctx, id, fFn...)
-	if err != nil {
-		return nil, err
-	}
+	err := r.store.WithTx(ctx, func(ctx context.Context) error {
+		f, err := r.Update(ctx, id, fFn...)
+		if err != nil {
+			return err
+		}
 
-	if err := r.updateImages(ctx, f); err != nil {
+		return r.updateImages(ctx, f)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -40,17 +44,22 @@ func (r *FlowerbedRepository) UpdateWithImages(ctx context.Context, id int32, fF
 }
 
 func (r *FlowerbedRepository) updateEntity(ctx context.Context, f *entities.Flowerbed) error {
+	if err := validateLocation(f); err != nil {
+		return err
+	}
+
 	args := sqlc.UpdateFlowerbedParams{
 		ID:             f.ID,
-		SensorID:       &f.Sensor.ID,
+		SensorID:       sensorID(f),
 		Size:           f.Size,
 		Description:    f.Description,
 		NumberOfPlants: f.NumberOfPlants,
 		MoistureLevel:  f.MoistureLevel,
-		RegionID:       &f.Region.ID,
+		RegionID:       regionID(f),
 		Address:        f.Address,
 		Latitude:       f.Latitude,
 		Longitude:      f.Longitude,
+		StGeomfromtext: mapper.MapPolygonToWKT(f.Geometry),
 	}
 
 	return r.store.UpdateFlowerbed(ctx, &args)
@@ -62,10 +71,21 @@ func (r *FlowerbedRepository) updateImages(ctx context.Context, f *entities.Flow
 	}
 
 	for _, img := range f.Images {
-		if r.linkImages(ctx, f.ID, img.ID) != nil {
+		if r.linkImage(ctx, f.ID, img) != nil {
 			return errors.New("error linking image")
 		}
 	}
 
 	return nil
 }
+
+// UpdateImageLocationMismatch flags the link between the flowerbed and the image if the image was taken far away from the flowerbed
+func (r *FlowerbedRepository) UpdateImageLocationMismatch(ctx context.Context, id, imageID int32, mismatch bool) error {
+	params := sqlc.UpdateFlowerbedImageLocationMismatchParams{
+		FlowerbedID:      id,
+		ImageID:          imageID,
+		LocationMismatch: mismatch,
+	}
+
+	return r.store.UpdateFlowerbedImageLocationMismatch(ctx, &params)
+}
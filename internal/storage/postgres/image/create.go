@@ -5,13 +5,16 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 func defaultImage() *entities.Image {
 	return &entities.Image{
-		URL:      "",
-		Filename: nil,
-		MimeType: nil,
+		URL:       "",
+		Filename:  nil,
+		MimeType:  nil,
+		ObjectKey: nil,
 	}
 }
 
@@ -23,7 +26,7 @@ func (r *ImageRepository) Create(ctx context.Context, iFn ...entities.EntityFunc
 
 	id, err := r.createEntity(ctx, entity)
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, err
 	}
 
 	entity.ID = *id
@@ -31,10 +34,20 @@ func (r *ImageRepository) Create(ctx context.Context, iFn ...entities.EntityFunc
 }
 
 func (r *ImageRepository) createEntity(ctx context.Context, image *entities.Image) (*int32, error) {
+	thumbnails, err := mapper.MapThumbnailsToJSON(image.Thumbnails)
+	if err != nil {
+		return nil, err
+	}
+
 	args := sqlc.CreateImageParams{
-		Url:      image.URL,
-		Filename: image.Filename,
-		MimeType: image.MimeType,
+		Url:        image.URL,
+		Filename:   image.Filename,
+		MimeType:   image.MimeType,
+		ObjectKey:  image.ObjectKey,
+		CapturedAt: utils.TimeToPgTimestamp(image.CapturedAt),
+		Latitude:   image.Latitude,
+		Longitude:  image.Longitude,
+		Thumbnails: thumbnails,
 	}
 
 	id, err := r.store.CreateImage(ctx, &args)
@@ -2,22 +2,57 @@ package image
 
 import (
 	"context"
+	"errors"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/jackc/pgx/v5"
 )
 
-func (r *ImageRepository) GetAll(ctx context.Context) ([]*entities.Image, error) {
-	rows, err := r.store.GetAllImages(ctx)
+func (r *ImageRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Image, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "created_at", "updated_at")
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, 0, err
+	}
+
+	total, err := r.store.CountImages(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllImages(ctx, &sqlc.GetAllImagesParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
 	}
 
-	return r.mapper.FromSqlList(rows), nil
+	return r.mapper.FromSqlList(rows), total, nil
 }
 
 func (r *ImageRepository) GetByID(ctx context.Context, id int32) (*entities.Image, error) {
 	row, err := r.store.GetImageByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrImageNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *ImageRepository) GetByObjectKey(ctx context.Context, key string) (*entities.Image, error) {
+	row, err := r.store.GetImageByObjectKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrImageNotFound
+		}
 		return nil, r.store.HandleError(err)
 	}
 
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -53,6 +54,46 @@ func WithMimeType(mimeType *string) entities.EntityFunc[entities.Image] {
 	}
 }
 
+func WithObjectKey(key *string) entities.EntityFunc[entities.Image] {
+	return func(i *entities.Image) {
+		slog.Debug("updating object key", "object key", key)
+		i.ObjectKey = key
+	}
+}
+
+func WithCapturedAt(capturedAt *time.Time) entities.EntityFunc[entities.Image] {
+	return func(i *entities.Image) {
+		slog.Debug("updating captured at", "captured at", capturedAt)
+		i.CapturedAt = capturedAt
+	}
+}
+
+func WithLocation(latitude, longitude *float64) entities.EntityFunc[entities.Image] {
+	return func(i *entities.Image) {
+		slog.Debug("updating location", "latitude", latitude, "longitude", longitude)
+		i.Latitude = latitude
+		i.Longitude = longitude
+	}
+}
+
+func WithThumbnails(thumbnails []*entities.ImageThumbnail) entities.EntityFunc[entities.Image] {
+	return func(i *entities.Image) {
+		slog.Debug("updating thumbnails", "thumbnails", len(thumbnails))
+		i.Thumbnails = thumbnails
+	}
+}
+
+// Delete removes the image and all links to trees and flowerbeds
 func (r *ImageRepository) Delete(ctx context.Context, id int32) error {
-	return r.store.DeleteImage(ctx, id)
+	return r.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.store.UnlinkImageFromAllTrees(ctx, id); err != nil {
+			return r.store.HandleError(err)
+		}
+
+		if err := r.store.UnlinkImageFromAllFlowerbeds(ctx, id); err != nil {
+			return r.store.HandleError(err)
+		}
+
+		return r.store.HandleError(r.store.DeleteImage(ctx, id))
+	})
 }
@@ -5,12 +5,14 @@ import (
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 )
 
 func (r *ImageRepository) Update(ctx context.Context, id int32, iFn ...entities.EntityFunc[entities.Image]) (*entities.Image, error) {
 	entity, err := r.GetByID(ctx, id)
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, err
 	}
 
 	for _, fn := range iFn {
@@ -18,18 +20,28 @@ func (r *ImageRepository) Update(ctx context.Context, id int32, iFn ...entities.
 	}
 
 	if err := r.updateEntity(ctx, entity); err != nil {
-		return nil, err
+		return nil, r.store.HandleError(err)
 	}
 
 	return r.GetByID(ctx, entity.ID)
 }
 
 func (r *ImageRepository) updateEntity(ctx context.Context, image *entities.Image) error {
+	thumbnails, err := mapper.MapThumbnailsToJSON(image.Thumbnails)
+	if err != nil {
+		return err
+	}
+
 	params := sqlc.UpdateImageParams{
-		ID:       image.ID,
-		Url:      image.URL,
-		Filename: image.Filename,
-		MimeType: image.MimeType,
+		ID:         image.ID,
+		Url:        image.URL,
+		Filename:   image.Filename,
+		MimeType:   image.MimeType,
+		ObjectKey:  image.ObjectKey,
+		CapturedAt: utils.TimeToPgTimestamp(image.CapturedAt),
+		Latitude:   image.Latitude,
+		Longitude:  image.Longitude,
+		Thumbnails: thumbnails,
 	}
 
 	return r.store.UpdateImage(ctx, &params)
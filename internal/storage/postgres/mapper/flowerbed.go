@@ -8,6 +8,7 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapGeometryToPolygon
 type InternalFlowerbedRepoMapper interface {
 	// goverter:ignore Sensor Images Region
 	FromSql(src *sqlc.Flowerbed) *entities.Flowerbed
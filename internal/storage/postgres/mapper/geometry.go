@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/twpayne/go-geos"
+)
+
+func MapGeometryToPolygon(g *geos.Geom) entities.Polygon {
+	if g == nil || g.IsEmpty() || g.TypeID() != geos.TypeIDPolygon {
+		return nil
+	}
+
+	polygon := make(entities.Polygon, 0, g.NumInteriorRings()+1)
+	polygon = append(polygon, g.ExteriorRing().CoordSeq().ToCoords())
+	for i := 0; i < g.NumInteriorRings(); i++ {
+		polygon = append(polygon, g.InteriorRing(i).CoordSeq().ToCoords())
+	}
+
+	return polygon
+}
+
+// MapGeometryToMultiPolygon returns the polygons of a MultiPolygon geometry. A single Polygon results in a MultiPolygon with one polygon.
+func MapGeometryToMultiPolygon(g *geos.Geom) entities.MultiPolygon {
+	if g == nil || g.IsEmpty() {
+		return nil
+	}
+
+	switch g.TypeID() {
+	case geos.TypeIDPolygon:
+		return entities.MultiPolygon{MapGeometryToPolygon(g)}
+	case geos.TypeIDMultiPolygon:
+		multiPolygon := make(entities.MultiPolygon, 0, g.NumGeometries())
+		for i := 0; i < g.NumGeometries(); i++ {
+			multiPolygon = append(multiPolygon, MapGeometryToPolygon(g.Geometry(i)))
+		}
+		return multiPolygon
+	default:
+		return nil
+	}
+}
+
+// MapPolygonToWKT returns the polygon as WKT to be used with ST_GeomFromText. An empty polygon results in nil.
+func MapPolygonToWKT(p entities.Polygon) *string {
+	if len(p) == 0 {
+		return nil
+	}
+
+	wkt := geos.NewPolygon(p).ToWKT()
+	return &wkt
+}
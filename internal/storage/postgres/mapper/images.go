@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
+	"log/slog"
+
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 )
@@ -8,8 +11,63 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
+// goverter:extend MapThumbnails
 type InternalImageRepoMapper interface {
 	// goverter:map Url URL
+	// goverter:ignore LocationMismatch
 	FromSql(src *sqlc.Image) *entities.Image
 	FromSqlList(src []*sqlc.Image) []*entities.Image
 }
+
+// imageThumbnail is the representation of a thumbnail in the thumbnails jsonb column of an image
+type imageThumbnail struct {
+	Size      string `json:"size"`
+	Width     int32  `json:"width"`
+	Height    int32  `json:"height"`
+	URL       string `json:"url"`
+	ObjectKey string `json:"object_key"`
+}
+
+func MapThumbnails(src []byte) []*entities.ImageThumbnail {
+	if len(src) == 0 {
+		return nil
+	}
+
+	var thumbnails []imageThumbnail
+	if err := json.Unmarshal(src, &thumbnails); err != nil {
+		slog.Error("failed to unmarshal image thumbnails", "error", err)
+		return nil
+	}
+
+	result := make([]*entities.ImageThumbnail, len(thumbnails))
+	for i, t := range thumbnails {
+		result[i] = &entities.ImageThumbnail{
+			Size:      entities.ImageThumbnailSize(t.Size),
+			Width:     t.Width,
+			Height:    t.Height,
+			URL:       t.URL,
+			ObjectKey: t.ObjectKey,
+		}
+	}
+
+	return result
+}
+
+func MapThumbnailsToJSON(src []*entities.ImageThumbnail) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	thumbnails := make([]imageThumbnail, len(src))
+	for i, t := range src {
+		thumbnails[i] = imageThumbnail{
+			Size:      string(t.Size),
+			Width:     t.Width,
+			Height:    t.Height,
+			URL:       t.URL,
+			ObjectKey: t.ObjectKey,
+		}
+	}
+
+	return json.Marshal(thumbnails)
+}
@@ -7,6 +7,7 @@ import (
 
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend MapGeometryToMultiPolygon
 type InternalRegionRepoMapper interface {
 	FromSql(src *sqlc.Region) *entities.Region
 	FromSqlList(src []*sqlc.Region) []*entities.Region
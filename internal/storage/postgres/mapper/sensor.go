@@ -15,6 +15,8 @@ import (
 // goverter:extend MapSensorStatus
 type InternalSensorRepoMapper interface {
 	// goverter:ignore Data
+	// goverter:map DevEui DevEUI
+	// goverter:map JoinEui JoinEUI
 	FromSql(src *sqlc.Sensor) *entities.Sensor
 	FromSqlList(src []*sqlc.Sensor) []*entities.Sensor
 
@@ -22,10 +24,13 @@ type InternalSensorRepoMapper interface {
 	FromSqlSensorData(src *sqlc.SensorDatum) *entities.SensorData
 
 	FromDomainSensorData(src *entities.MqttPayload) *mqtt.MqttPayload
+	ToDomainSensorData(src *mqtt.MqttPayload) *entities.MqttPayload
 }
 
-func MapSensorData(src []byte) (*entities.MqttPayload, error) {
-	var payload entities.MqttPayload
+// MapSensorData decodes the stored json payload. The payload is stored in the
+// snake case format of the mqtt entities and has to be mapped to the domain afterwards.
+func MapSensorData(src []byte) (*mqtt.MqttPayload, error) {
+	var payload mqtt.MqttPayload
 	err := json.Unmarshal(src, &payload)
 	if err != nil {
 		return nil, err
@@ -8,7 +8,7 @@ import (
 // goverter:converter
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
 // goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTimePtr
-// goverter:extend MapWateringStatus MapSoilCondition
+// goverter:extend MapWateringStatus MapSoilCondition MapGeometryToPolygon
 // goverter:ignoreMissing
 type InternalTreeClusterRepoMapper interface {
 	FromSql(*sqlc.TreeCluster) *entities.TreeCluster
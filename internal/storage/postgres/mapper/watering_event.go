@@ -0,0 +1,14 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgUUIDToUUIDPtr
+type InternalWateringEventRepoMapper interface {
+	FromSql(*sqlc.WateringEvent) *entities.WateringEvent
+	FromSqlList([]*sqlc.WateringEvent) []*entities.WateringEvent
+}
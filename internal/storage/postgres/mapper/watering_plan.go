@@ -0,0 +1,20 @@
+package mapper
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+)
+
+// goverter:converter
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgTimestampToTime
+// goverter:extend github.com/green-ecolution/green-ecolution-backend/internal/utils:PgDateToTime
+// goverter:extend MapWateringPlanStatus
+type InternalWateringPlanRepoMapper interface {
+	// goverter:ignore Vehicle UserIDs TreeClusters
+	FromSql(*sqlc.WateringPlan) *entities.WateringPlan
+	FromSqlList([]*sqlc.WateringPlan) []*entities.WateringPlan
+}
+
+func MapWateringPlanStatus(status sqlc.WateringPlanStatus) entities.WateringPlanStatus {
+	return entities.WateringPlanStatus(status)
+}
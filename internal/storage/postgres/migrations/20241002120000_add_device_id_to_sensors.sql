@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE sensors
+ADD COLUMN device_id TEXT;
+
+-- +goose Down
+ALTER TABLE sensors
+DROP COLUMN device_id;
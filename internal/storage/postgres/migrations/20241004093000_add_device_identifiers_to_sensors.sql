@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sensors ADD COLUMN dev_eui TEXT;
+ALTER TABLE sensors ADD COLUMN join_eui TEXT;
+ALTER TABLE sensors ADD COLUMN application_id TEXT;
+
+ALTER TABLE sensors ADD CONSTRAINT sensors_device_id_key UNIQUE (device_id);
+ALTER TABLE sensors ADD CONSTRAINT sensors_dev_eui_key UNIQUE (dev_eui);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE sensors DROP CONSTRAINT IF EXISTS sensors_dev_eui_key;
+ALTER TABLE sensors DROP CONSTRAINT IF EXISTS sensors_device_id_key;
+
+ALTER TABLE sensors DROP COLUMN application_id;
+ALTER TABLE sensors DROP COLUMN join_eui;
+ALTER TABLE sensors DROP COLUMN dev_eui;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE vehicles ADD CONSTRAINT vehicles_number_plate_key UNIQUE (number_plate);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE vehicles DROP CONSTRAINT IF EXISTS vehicles_number_plate_key;
+-- +goose StatementEnd
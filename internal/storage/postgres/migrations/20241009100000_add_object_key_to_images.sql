@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE images ADD COLUMN object_key TEXT;
+ALTER TABLE images ADD CONSTRAINT images_object_key_key UNIQUE (object_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE images DROP CONSTRAINT IF EXISTS images_object_key_key;
+ALTER TABLE images DROP COLUMN IF EXISTS object_key;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE images ADD COLUMN captured_at TIMESTAMP;
+ALTER TABLE images ADD COLUMN latitude FLOAT;
+ALTER TABLE images ADD COLUMN longitude FLOAT;
+ALTER TABLE images ADD COLUMN location_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE images ADD COLUMN thumbnails JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE images DROP COLUMN IF EXISTS thumbnails;
+ALTER TABLE images DROP COLUMN IF EXISTS location_mismatch;
+ALTER TABLE images DROP COLUMN IF EXISTS longitude;
+ALTER TABLE images DROP COLUMN IF EXISTS latitude;
+ALTER TABLE images DROP COLUMN IF EXISTS captured_at;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE watering_plan_status AS ENUM ('planned', 'active', 'finished', 'cancelled');
+
+CREATE TABLE IF NOT EXISTS watering_plans (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  date DATE NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  status watering_plan_status NOT NULL DEFAULT 'planned',
+  total_water_required FLOAT NOT NULL DEFAULT 0,
+  vehicle_id INT,
+  FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS watering_plan_users (
+  watering_plan_id INT NOT NULL,
+  user_id UUID NOT NULL,
+  PRIMARY KEY (watering_plan_id, user_id),
+  FOREIGN KEY (watering_plan_id) REFERENCES watering_plans(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS watering_plan_tree_clusters (
+  watering_plan_id INT NOT NULL,
+  tree_cluster_id INT NOT NULL,
+  position INT NOT NULL,
+  PRIMARY KEY (watering_plan_id, tree_cluster_id),
+  FOREIGN KEY (watering_plan_id) REFERENCES watering_plans(id) ON DELETE CASCADE,
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_watering_plans_date ON watering_plans (date);
+
+CREATE TRIGGER update_watering_plans_updated_at
+BEFORE UPDATE ON watering_plans
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_watering_plans_updated_at ON watering_plans;
+DROP TABLE IF EXISTS watering_plan_tree_clusters;
+DROP TABLE IF EXISTS watering_plan_users;
+DROP TABLE IF EXISTS watering_plans;
+DROP TYPE IF EXISTS watering_plan_status;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS watering_events (
+  id SERIAL PRIMARY KEY,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  tree_cluster_id INT NOT NULL,
+  vehicle_id INT,
+  user_id UUID,
+  watered_at TIMESTAMP NOT NULL,
+  water_amount FLOAT NOT NULL,
+  FOREIGN KEY (tree_cluster_id) REFERENCES tree_clusters(id) ON DELETE CASCADE,
+  FOREIGN KEY (vehicle_id) REFERENCES vehicles(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_watering_events_tree_cluster_id ON watering_events (tree_cluster_id, watered_at);
+CREATE INDEX IF NOT EXISTS idx_watering_events_vehicle_id ON watering_events (vehicle_id, watered_at);
+CREATE INDEX IF NOT EXISTS idx_watering_events_watered_at ON watering_events (watered_at);
+
+CREATE TRIGGER update_watering_events_updated_at
+BEFORE UPDATE ON watering_events
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_watering_events_updated_at ON watering_events;
+DROP TABLE IF EXISTS watering_events;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tree_clusters DROP CONSTRAINT IF EXISTS tree_clusters_region_id_fkey;
+ALTER TABLE tree_clusters ADD CONSTRAINT tree_clusters_region_id_fkey
+  FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE SET NULL;
+
+ALTER TABLE flowerbeds DROP CONSTRAINT IF EXISTS flowerbeds_region_id_fkey;
+ALTER TABLE flowerbeds ADD CONSTRAINT flowerbeds_region_id_fkey
+  FOREIGN KEY (region_id) REFERENCES regions(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tree_clusters DROP CONSTRAINT IF EXISTS tree_clusters_region_id_fkey;
+ALTER TABLE tree_clusters ADD CONSTRAINT tree_clusters_region_id_fkey
+  FOREIGN KEY (region_id) REFERENCES regions(id);
+
+ALTER TABLE flowerbeds DROP CONSTRAINT IF EXISTS flowerbeds_region_id_fkey;
+ALTER TABLE flowerbeds ADD CONSTRAINT flowerbeds_region_id_fkey
+  FOREIGN KEY (region_id) REFERENCES regions(id);
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS trees_geometry_idx ON trees USING GIST (geometry);
+CREATE INDEX IF NOT EXISTS trees_geography_idx ON trees USING GIST ((geometry::geography));
+CREATE INDEX IF NOT EXISTS tree_clusters_geometry_idx ON tree_clusters USING GIST (geometry);
+CREATE INDEX IF NOT EXISTS tree_clusters_geography_idx ON tree_clusters USING GIST ((geometry::geography));
+CREATE INDEX IF NOT EXISTS flowerbeds_geometry_idx ON flowerbeds USING GIST (geometry);
+CREATE INDEX IF NOT EXISTS regions_geometry_idx ON regions USING GIST (geometry);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS regions_geometry_idx;
+DROP INDEX IF EXISTS flowerbeds_geometry_idx;
+DROP INDEX IF EXISTS tree_clusters_geography_idx;
+DROP INDEX IF EXISTS tree_clusters_geometry_idx;
+DROP INDEX IF EXISTS trees_geography_idx;
+DROP INDEX IF EXISTS trees_geometry_idx;
+-- +goose StatementEnd
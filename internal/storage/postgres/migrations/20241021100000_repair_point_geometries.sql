@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- points were partly built as POINT(latitude longitude), the latitude and longitude columns of trees are correct
+UPDATE trees SET geometry = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326);
+
+-- tree cluster locations are the centroid of their trees, which was calculated from the swapped tree points
+UPDATE tree_clusters SET
+  latitude = ST_Y(centroids.geometry),
+  longitude = ST_X(centroids.geometry),
+  geometry = centroids.geometry
+FROM (
+  SELECT tree_cluster_id, ST_Centroid(ST_Collect(geometry)) AS geometry
+  FROM trees
+  WHERE tree_cluster_id IS NOT NULL
+  GROUP BY tree_cluster_id
+) AS centroids
+WHERE tree_clusters.id = centroids.tree_cluster_id;
+
+UPDATE tree_clusters SET geometry = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)
+WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+  AND id NOT IN (SELECT tree_cluster_id FROM trees WHERE tree_cluster_id IS NOT NULL);
+
+UPDATE tree_clusters SET region_id = (
+  SELECT regions.id FROM regions WHERE ST_Contains(regions.geometry, tree_clusters.geometry) LIMIT 1
+)
+WHERE geometry IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- the swapped points are not restored
+SELECT 1;
+-- +goose StatementEnd
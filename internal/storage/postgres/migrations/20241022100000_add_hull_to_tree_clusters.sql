@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE tree_clusters ADD COLUMN hull GEOMETRY(Polygon, 4326);
+
+-- the area covered by existing clusters is the convex hull of their trees with the default buffer of 5 meters
+UPDATE tree_clusters SET hull = hulls.hull
+FROM (
+  SELECT tree_cluster_id, ST_Buffer(ST_ConvexHull(ST_Collect(geometry))::geography, 5)::geometry AS hull
+  FROM trees
+  WHERE tree_cluster_id IS NOT NULL
+  GROUP BY tree_cluster_id
+) AS hulls
+WHERE tree_clusters.id = hulls.tree_cluster_id;
+
+CREATE INDEX IF NOT EXISTS tree_clusters_hull_idx ON tree_clusters USING GIST (hull);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS tree_clusters_hull_idx;
+ALTER TABLE tree_clusters DROP COLUMN hull;
+-- +goose StatementEnd
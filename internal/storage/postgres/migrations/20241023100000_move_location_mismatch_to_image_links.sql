@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- an image can be linked to several trees and flowerbeds, so whether it was taken far away
+-- depends on the object it is linked to
+ALTER TABLE tree_images ADD COLUMN location_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE flowerbed_images ADD COLUMN location_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE tree_images SET location_mismatch = images.location_mismatch
+FROM images WHERE tree_images.image_id = images.id;
+
+UPDATE flowerbed_images SET location_mismatch = images.location_mismatch
+FROM images WHERE flowerbed_images.image_id = images.id;
+
+ALTER TABLE images DROP COLUMN location_mismatch;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE images ADD COLUMN location_mismatch BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE images SET location_mismatch = TRUE
+WHERE id IN (
+  SELECT image_id FROM tree_images WHERE location_mismatch
+  UNION
+  SELECT image_id FROM flowerbed_images WHERE location_mismatch
+);
+
+ALTER TABLE flowerbed_images DROP COLUMN location_mismatch;
+ALTER TABLE tree_images DROP COLUMN location_mismatch;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS flowerbeds_location_idx ON flowerbeds USING GIST ((ST_SetSRID(ST_MakePoint(longitude, latitude), 4326))) WHERE geometry IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS flowerbeds_location_idx;
+-- +goose StatementEnd
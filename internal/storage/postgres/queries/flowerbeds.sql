@@ -1,5 +1,18 @@
 -- name: GetAllFlowerbeds :many
-SELECT * FROM flowerbeds;
+SELECT * FROM flowerbeds
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'size' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN size END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'size' AND sqlc.arg(sort_desc)::BOOLEAN THEN size END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountFlowerbeds :one
+SELECT COUNT(*) FROM flowerbeds;
 
 -- name: GetFlowerbedByID :one
 SELECT * FROM flowerbeds WHERE id = $1;
@@ -8,7 +21,7 @@ SELECT * FROM flowerbeds WHERE id = $1;
 SELECT sensors.* FROM sensors JOIN flowerbeds ON sensors.id = flowerbeds.sensor_id WHERE flowerbeds.id = $1;
 
 -- name: GetAllImagesByFlowerbedID :many
-SELECT images.* FROM images JOIN flowerbed_images ON images.id = flowerbed_images.image_id WHERE flowerbed_images.flowerbed_id = $1;
+SELECT sqlc.embed(images), flowerbed_images.location_mismatch FROM images JOIN flowerbed_images ON images.id = flowerbed_images.image_id WHERE flowerbed_images.flowerbed_id = $1;
 
 -- name: GetRegionByFlowerbedID :one
 SELECT regions.* FROM regions JOIN flowerbeds ON regions.id = flowerbeds.region_id WHERE flowerbeds.id = $1;
@@ -21,7 +34,10 @@ INSERT INTO flowerbeds (
 ) RETURNING id;
 
 -- name: LinkFlowerbedImage :exec
-INSERT INTO flowerbed_images (flowerbed_id, image_id) VALUES ($1, $2);
+INSERT INTO flowerbed_images (flowerbed_id, image_id, location_mismatch) VALUES ($1, $2, $3);
+
+-- name: UpdateFlowerbedImageLocationMismatch :exec
+UPDATE flowerbed_images SET location_mismatch = $3 WHERE flowerbed_id = $1 AND image_id = $2;
 
 -- name: UnlinkFlowerbedImage :exec
 DELETE FROM flowerbed_images WHERE flowerbed_id = $1 AND image_id = $2;
@@ -55,3 +71,9 @@ WHERE id = $1;
 
 -- name: DeleteFlowerbed :exec
 DELETE FROM flowerbeds WHERE id = $1;
+
+-- name: GetFlowerbedsByRegionOrArea :many
+SELECT * FROM flowerbeds
+WHERE region_id = @region_id::int
+  OR ST_Within(ST_SetSRID(ST_MakePoint(longitude, latitude), 4326), ST_GeomFromText(@wkt::text, 4326))
+ORDER BY id;
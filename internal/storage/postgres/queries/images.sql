@@ -1,20 +1,44 @@
 -- name: GetAllImages :many
-SELECT * FROM images;
+SELECT * FROM images
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountImages :one
+SELECT COUNT(*) FROM images;
 
 -- name: GetImageByID :one
 SELECT * FROM images WHERE id = $1;
 
+-- name: GetImageByObjectKey :one
+SELECT * FROM images
+WHERE object_key = sqlc.arg(object_key)::TEXT
+  OR thumbnails @> jsonb_build_array(jsonb_build_object('object_key', sqlc.arg(object_key)::TEXT))
+LIMIT 1;
+
 -- name: CreateImage :one
 INSERT INTO images (
-  url, filename, mime_type
+  url, filename, mime_type, object_key, captured_at, latitude, longitude, thumbnails
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6, $7, $8
 ) RETURNING id;
 
 -- name: UpdateImage :exec
 UPDATE images SET
-  url = $2, filename = $3, mime_type = $4
+  url = $2, filename = $3, mime_type = $4, object_key = $5, captured_at = $6,
+  latitude = $7, longitude = $8, thumbnails = $9
 WHERE id = $1;
 
+-- name: UnlinkImageFromAllTrees :exec
+DELETE FROM tree_images WHERE image_id = $1;
+
+-- name: UnlinkImageFromAllFlowerbeds :exec
+DELETE FROM flowerbed_images WHERE image_id = $1;
+
 -- name: DeleteImage :exec
 DELETE FROM images WHERE id = $1;
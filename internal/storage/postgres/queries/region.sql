@@ -1,5 +1,18 @@
 -- name: GetAllRegions :many
-SELECT * FROM regions;
+SELECT * FROM regions
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'name' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'name' AND sqlc.arg(sort_desc)::BOOLEAN THEN name END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountRegions :one
+SELECT COUNT(*) FROM regions;
 
 -- name: GetRegionById :one
 SELECT * FROM regions WHERE id = $1;
@@ -19,3 +32,14 @@ DELETE FROM regions WHERE id = $1;
 -- name: GetRegionByPoint :one
 SELECT * FROM regions WHERE ST_Contains(geometry, ST_GeomFromText($1, 4326));
 
+
+-- name: GetGeometryValidity :one
+SELECT ST_IsValid(input.geom)::boolean AS valid, ST_IsValidReason(input.geom)::text AS reason
+FROM (SELECT ST_GeomFromText(@wkt::text, 4326) AS geom) AS input;
+
+-- name: GetOverlappingRegions :many
+SELECT * FROM regions
+WHERE id <> @exclude_id::int
+  AND ST_Intersects(geometry, ST_GeomFromText(@wkt::text, 4326))
+  AND NOT ST_Touches(geometry, ST_GeomFromText(@wkt::text, 4326))
+ORDER BY id;
@@ -1,25 +1,49 @@
 -- name: GetAllSensors :many
-SELECT * FROM sensors;
+SELECT * FROM sensors
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountSensors :one
+SELECT COUNT(*) FROM sensors;
 
 -- name: GetSensorByID :one
 SELECT * FROM sensors WHERE id = $1;
 
+-- name: GetSensorByDeviceID :one
+SELECT * FROM sensors WHERE device_id = $1;
+
+-- name: GetSensorByDevEUI :one
+SELECT * FROM sensors WHERE dev_eui = $1;
+
 -- name: GetSensorByStatus :many
 SELECT * FROM sensors WHERE status = $1;
 
 -- name: GetSensorDataBySensorID :many
 SELECT * FROM sensor_data WHERE sensor_id = $1;
 
+-- name: GetLatestSensorDataBySensorID :one
+SELECT * FROM sensor_data WHERE sensor_id = $1 ORDER BY created_at DESC, id DESC LIMIT 1;
+
 -- name: CreateSensor :one
 INSERT INTO sensors (
-  status
+  status, device_id, dev_eui, join_eui, application_id
 ) VALUES (
-  $1
+  $1, $2, $3, $4, $5
 ) RETURNING id;
 
 -- name: UpdateSensor :exec
 UPDATE sensors SET
-  status = $2
+  status = $2,
+  device_id = $3,
+  dev_eui = $4,
+  join_eui = $5,
+  application_id = $6
 WHERE id = $1;
 
 -- name: InsertSensorData :exec
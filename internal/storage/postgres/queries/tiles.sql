@@ -0,0 +1,82 @@
+-- name: GetTreeTile :one
+WITH bounds AS (
+  SELECT ST_TileEnvelope(@z::int, @x::int, @y::int) AS geom
+), features AS (
+  SELECT
+    ST_AsMVTGeom(ST_Transform(trees.geometry, 3857), bounds.geom) AS geom,
+    trees.id,
+    trees.tree_number AS number,
+    trees.species,
+    trees.planting_year,
+    trees.age,
+    trees.tree_cluster_id,
+    tree_clusters.watering_status::text AS watering_status
+  FROM trees
+  JOIN bounds ON ST_Intersects(trees.geometry, ST_Transform(bounds.geom, 4326))
+  LEFT JOIN tree_clusters ON tree_clusters.id = trees.tree_cluster_id
+  WHERE NOT (@exclude_archived::boolean AND COALESCE(tree_clusters.archived, FALSE))
+)
+SELECT COALESCE(ST_AsMVT(features.*, 'trees', 4096, 'geom', 'id'), '')::bytea AS tile FROM features;
+
+-- name: GetTreeClusterTile :one
+WITH bounds AS (
+  SELECT ST_TileEnvelope(@z::int, @x::int, @y::int) AS geom
+), features AS (
+  SELECT
+    ST_AsMVTGeom(ST_Transform(tree_clusters.geometry, 3857), bounds.geom) AS geom,
+    tree_clusters.id,
+    tree_clusters.name,
+    tree_clusters.watering_status::text AS watering_status,
+    tree_clusters.moisture_level,
+    tree_clusters.soil_condition::text AS soil_condition,
+    tree_clusters.address,
+    tree_clusters.archived,
+    tree_clusters.region_id
+  FROM tree_clusters
+  JOIN bounds ON ST_Intersects(tree_clusters.geometry, ST_Transform(bounds.geom, 4326))
+  WHERE NOT (@exclude_archived::boolean AND tree_clusters.archived)
+)
+SELECT COALESCE(ST_AsMVT(features.*, 'clusters', 4096, 'geom', 'id'), '')::bytea AS tile FROM features;
+
+-- name: GetFlowerbedTile :one
+WITH bounds AS (
+  SELECT ST_TileEnvelope(@z::int, @x::int, @y::int) AS geom
+), flowerbed_geometries AS (
+  -- both parts are filtered by the bounding box of the tile first, so the spatial indexes are used
+  SELECT flowerbeds.*, flowerbeds.geometry AS shape
+  FROM flowerbeds, bounds
+  WHERE flowerbeds.geometry && ST_Transform(bounds.geom, 4326)
+  UNION ALL
+  SELECT flowerbeds.*, ST_SetSRID(ST_MakePoint(flowerbeds.longitude, flowerbeds.latitude), 4326) AS shape
+  FROM flowerbeds, bounds
+  WHERE flowerbeds.geometry IS NULL
+    AND ST_SetSRID(ST_MakePoint(flowerbeds.longitude, flowerbeds.latitude), 4326) && ST_Transform(bounds.geom, 4326)
+), features AS (
+  SELECT
+    ST_AsMVTGeom(ST_Transform(flowerbed_geometries.shape, 3857), bounds.geom) AS geom,
+    flowerbed_geometries.id,
+    flowerbed_geometries.description,
+    flowerbed_geometries.size,
+    flowerbed_geometries.number_of_plants,
+    flowerbed_geometries.moisture_level,
+    flowerbed_geometries.address,
+    flowerbed_geometries.archived,
+    flowerbed_geometries.region_id
+  FROM flowerbed_geometries
+  JOIN bounds ON ST_Intersects(flowerbed_geometries.shape, ST_Transform(bounds.geom, 4326))
+  WHERE NOT (@exclude_archived::boolean AND flowerbed_geometries.archived)
+)
+SELECT COALESCE(ST_AsMVT(features.*, 'flowerbeds', 4096, 'geom', 'id'), '')::bytea AS tile FROM features;
+
+-- name: GetRegionTile :one
+WITH bounds AS (
+  SELECT ST_TileEnvelope(@z::int, @x::int, @y::int) AS geom
+), features AS (
+  SELECT
+    ST_AsMVTGeom(ST_Transform(regions.geometry, 3857), bounds.geom) AS geom,
+    regions.id,
+    regions.name
+  FROM regions
+  JOIN bounds ON ST_Intersects(regions.geometry, ST_Transform(bounds.geom, 4326))
+)
+SELECT COALESCE(ST_AsMVT(features.*, 'regions', 4096, 'geom', 'id'), '')::bytea AS tile FROM features;
@@ -1,5 +1,34 @@
 -- name: GetAllTreeClusters :many
-SELECT * FROM tree_clusters;
+SELECT * FROM tree_clusters
+WHERE (sqlc.narg(min_longitude)::FLOAT8 IS NULL OR geometry && ST_MakeEnvelope(
+    sqlc.narg(min_longitude)::FLOAT8, sqlc.narg(min_latitude)::FLOAT8,
+    sqlc.narg(max_longitude)::FLOAT8, sqlc.narg(max_latitude)::FLOAT8, 4326))
+  AND (sqlc.narg(radius)::FLOAT8 IS NULL OR ST_DWithin(geometry::geography,
+    ST_SetSRID(ST_MakePoint(sqlc.narg(center_longitude)::FLOAT8, sqlc.narg(center_latitude)::FLOAT8), 4326)::geography,
+    sqlc.narg(radius)::FLOAT8))
+  AND (sqlc.narg(area)::TEXT IS NULL OR ST_Intersects(geometry, ST_GeomFromText(sqlc.narg(area)::TEXT, 4326)))
+  AND (sqlc.narg(watering_status)::TEXT IS NULL OR watering_status::TEXT = sqlc.narg(watering_status)::TEXT)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'name' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'name' AND sqlc.arg(sort_desc)::BOOLEAN THEN name END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountTreeClusters :one
+SELECT COUNT(*) FROM tree_clusters
+WHERE (sqlc.narg(min_longitude)::FLOAT8 IS NULL OR geometry && ST_MakeEnvelope(
+    sqlc.narg(min_longitude)::FLOAT8, sqlc.narg(min_latitude)::FLOAT8,
+    sqlc.narg(max_longitude)::FLOAT8, sqlc.narg(max_latitude)::FLOAT8, 4326))
+  AND (sqlc.narg(radius)::FLOAT8 IS NULL OR ST_DWithin(geometry::geography,
+    ST_SetSRID(ST_MakePoint(sqlc.narg(center_longitude)::FLOAT8, sqlc.narg(center_latitude)::FLOAT8), 4326)::geography,
+    sqlc.narg(radius)::FLOAT8))
+  AND (sqlc.narg(area)::TEXT IS NULL OR ST_Intersects(geometry, ST_GeomFromText(sqlc.narg(area)::TEXT, 4326)))
+  AND (sqlc.narg(watering_status)::TEXT IS NULL OR watering_status::TEXT = sqlc.narg(watering_status)::TEXT);
 
 -- name: GetTreeClusterByID :one
 SELECT * FROM tree_clusters WHERE id = $1;
@@ -24,7 +53,14 @@ INSERT INTO tree_clusters (
 UPDATE tree_clusters SET
   latitude = $2,
   longitude = $3,
-  geometry = ST_SetSRID(ST_MakePoint($2, $3), 4326)
+  geometry = ST_GeomFromText($4, 4326)
+WHERE id = $1;
+
+-- name: RemoveTreeClusterLocation :exec
+UPDATE tree_clusters SET
+  latitude = NULL,
+  longitude = NULL,
+  geometry = NULL
 WHERE id = $1;
 
 -- name: UpdateTreeCluster :exec
@@ -48,3 +84,24 @@ WHERE id = $1;
 -- name: DeleteTreeCluster :exec
 DELETE FROM tree_clusters WHERE id = $1;
 
+
+-- name: GetTreeClustersByRegionOrArea :many
+SELECT * FROM tree_clusters
+WHERE region_id = @region_id::int
+  OR ST_Within(geometry, ST_GeomFromText(@wkt::text, 4326))
+ORDER BY id;
+
+-- name: UpdateTreeClusterHulls :exec
+UPDATE tree_clusters SET hull = CASE WHEN GeometryType(hulls.hull) = 'POLYGON' THEN hulls.hull END
+FROM (
+  SELECT tree_clusters.id,
+    ST_Buffer(
+      ST_ConcaveHull(ST_Collect(trees.geometry), sqlc.arg(concavity)::float8)::geography,
+      sqlc.arg(buffer)::float8
+    )::geometry AS hull
+  FROM tree_clusters
+  LEFT JOIN trees ON trees.tree_cluster_id = tree_clusters.id
+  WHERE tree_clusters.id = ANY(sqlc.arg(ids)::int[])
+  GROUP BY tree_clusters.id
+) AS hulls
+WHERE tree_clusters.id = hulls.id;
@@ -1,14 +1,58 @@
 -- name: GetAllTrees :many
-SELECT * FROM trees;
+SELECT * FROM trees
+WHERE (sqlc.narg(species)::TEXT IS NULL OR species = sqlc.narg(species)::TEXT)
+  AND (sqlc.narg(min_age)::INT IS NULL OR age >= sqlc.narg(min_age)::INT)
+  AND (sqlc.narg(max_age)::INT IS NULL OR age <= sqlc.narg(max_age)::INT)
+  AND (sqlc.narg(tree_cluster_id)::INT IS NULL OR tree_cluster_id = sqlc.narg(tree_cluster_id)::INT)
+  AND (sqlc.narg(min_longitude)::FLOAT8 IS NULL OR geometry && ST_MakeEnvelope(
+    sqlc.narg(min_longitude)::FLOAT8, sqlc.narg(min_latitude)::FLOAT8,
+    sqlc.narg(max_longitude)::FLOAT8, sqlc.narg(max_latitude)::FLOAT8, 4326))
+  AND (sqlc.narg(radius)::FLOAT8 IS NULL OR ST_DWithin(geometry::geography,
+    ST_SetSRID(ST_MakePoint(sqlc.narg(center_longitude)::FLOAT8, sqlc.narg(center_latitude)::FLOAT8), 4326)::geography,
+    sqlc.narg(radius)::FLOAT8))
+  AND (sqlc.narg(area)::TEXT IS NULL OR ST_Intersects(geometry, ST_GeomFromText(sqlc.narg(area)::TEXT, 4326)))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'number' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN tree_number END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'number' AND sqlc.arg(sort_desc)::BOOLEAN THEN tree_number END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'species' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN species END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'species' AND sqlc.arg(sort_desc)::BOOLEAN THEN species END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'planting_year' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN planting_year END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'planting_year' AND sqlc.arg(sort_desc)::BOOLEAN THEN planting_year END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'age' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN age END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'age' AND sqlc.arg(sort_desc)::BOOLEAN THEN age END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountTrees :one
+SELECT COUNT(*) FROM trees
+WHERE (sqlc.narg(species)::TEXT IS NULL OR species = sqlc.narg(species)::TEXT)
+  AND (sqlc.narg(min_age)::INT IS NULL OR age >= sqlc.narg(min_age)::INT)
+  AND (sqlc.narg(max_age)::INT IS NULL OR age <= sqlc.narg(max_age)::INT)
+  AND (sqlc.narg(tree_cluster_id)::INT IS NULL OR tree_cluster_id = sqlc.narg(tree_cluster_id)::INT)
+  AND (sqlc.narg(min_longitude)::FLOAT8 IS NULL OR geometry && ST_MakeEnvelope(
+    sqlc.narg(min_longitude)::FLOAT8, sqlc.narg(min_latitude)::FLOAT8,
+    sqlc.narg(max_longitude)::FLOAT8, sqlc.narg(max_latitude)::FLOAT8, 4326))
+  AND (sqlc.narg(radius)::FLOAT8 IS NULL OR ST_DWithin(geometry::geography,
+    ST_SetSRID(ST_MakePoint(sqlc.narg(center_longitude)::FLOAT8, sqlc.narg(center_latitude)::FLOAT8), 4326)::geography,
+    sqlc.narg(radius)::FLOAT8))
+  AND (sqlc.narg(area)::TEXT IS NULL OR ST_Intersects(geometry, ST_GeomFromText(sqlc.narg(area)::TEXT, 4326)));
 
 -- name: GetTreeByID :one
 SELECT * FROM trees WHERE id = $1;
 
+-- name: GetTreeBySensorID :one
+SELECT * FROM trees WHERE sensor_id = $1;
+
 -- name: GetTreesByTreeClusterID :many
 SELECT * FROM trees WHERE tree_cluster_id = $1;
 
 -- name: GetAllImagesByTreeID :many
-SELECT images.* FROM images JOIN tree_images ON images.id = tree_images.image_id WHERE tree_images.tree_id = $1;
+SELECT sqlc.embed(images), tree_images.location_mismatch FROM images JOIN tree_images ON images.id = tree_images.image_id WHERE tree_images.tree_id = $1;
 
 -- name: GetSensorByTreeID :one
 SELECT sensors.* FROM sensors JOIN trees ON sensors.id = trees.sensor_id WHERE trees.id = $1;
@@ -40,13 +84,20 @@ WHERE id = $1;
 -- name: UpdateTreeClusterID :exec
 UPDATE trees SET tree_cluster_id = $2 WHERE id = ANY($1::int[]);
 
+-- name: GetTreeClusterIDsOfTrees :many
+SELECT DISTINCT tree_cluster_id::int FROM trees
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND tree_cluster_id IS NOT NULL;
+
 -- name: LinkTreeImage :exec
 INSERT INTO tree_images (
-  tree_id, image_id
+  tree_id, image_id, location_mismatch
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 );
 
+-- name: UpdateTreeImageLocationMismatch :exec
+UPDATE tree_images SET location_mismatch = $3 WHERE tree_id = $1 AND image_id = $2;
+
 -- name: UnlinkTreeImage :exec
 DELETE FROM tree_images WHERE tree_id = $1 AND image_id = $2;
 
@@ -66,3 +117,77 @@ UPDATE trees SET tree_cluster_id = NULL WHERE tree_cluster_id = $1;
 
 -- name: CalculateGroupedCentroids :one
 SELECT ST_AsText(ST_Centroid(ST_Collect(geometry)))::text AS centroid FROM trees WHERE id = ANY($1::int[]);
+
+-- name: GetTreesByTreeNumbers :many
+SELECT * FROM trees WHERE tree_number = ANY(@tree_numbers::int[]) ORDER BY id;
+
+-- name: CreateTrees :many
+INSERT INTO trees (
+  age, height_above_sea_level, planting_year, species, tree_number, latitude, longitude, geometry
+)
+SELECT u.age, u.height_above_sea_level, u.planting_year, u.species, u.tree_number, u.latitude, u.longitude,
+  ST_GeomFromText(u.geometry, 4326)
+FROM (
+  SELECT
+    unnest(@ages::int[]) AS age,
+    unnest(@heights_above_sea_level::float8[]) AS height_above_sea_level,
+    unnest(@planting_years::int[]) AS planting_year,
+    unnest(@species::text[]) AS species,
+    unnest(@tree_numbers::int[]) AS tree_number,
+    unnest(@latitudes::float8[]) AS latitude,
+    unnest(@longitudes::float8[]) AS longitude,
+    unnest(@geometries::text[]) AS geometry
+) AS u
+RETURNING id;
+
+-- name: UpdateTrees :exec
+UPDATE trees SET
+  age = u.age,
+  height_above_sea_level = u.height_above_sea_level,
+  planting_year = u.planting_year,
+  species = u.species,
+  latitude = u.latitude,
+  longitude = u.longitude,
+  geometry = ST_GeomFromText(u.geometry, 4326)
+FROM (
+  SELECT
+    unnest(@ids::int[]) AS id,
+    unnest(@ages::int[]) AS age,
+    unnest(@heights_above_sea_level::float8[]) AS height_above_sea_level,
+    unnest(@planting_years::int[]) AS planting_year,
+    unnest(@species::text[]) AS species,
+    unnest(@latitudes::float8[]) AS latitude,
+    unnest(@longitudes::float8[]) AS longitude,
+    unnest(@geometries::text[]) AS geometry
+) AS u
+WHERE trees.id = u.id;
+
+-- name: GetNearbyTrees :many
+SELECT sqlc.embed(trees),
+  ST_Distance(geometry::geography, ST_SetSRID(ST_MakePoint(@longitude::float8, @latitude::float8), 4326)::geography)::float8 AS distance
+FROM trees
+WHERE geometry IS NOT NULL
+ORDER BY geometry::geography <-> ST_SetSRID(ST_MakePoint(@longitude::float8, @latitude::float8), 4326)::geography
+LIMIT @row_limit::int;
+
+-- name: GetTreeClusterSuggestions :many
+WITH candidates AS (
+  SELECT trees.id, trees.geometry FROM trees
+  WHERE trees.tree_cluster_id IS NULL AND trees.geometry IS NOT NULL
+    AND (sqlc.narg(region_id)::INT IS NULL OR EXISTS (
+      SELECT 1 FROM regions WHERE regions.id = sqlc.narg(region_id)::INT AND ST_Within(trees.geometry, regions.geometry)))
+), scale AS (
+  SELECT 1 / cos(radians(avg(ST_Y(geometry)))) AS factor FROM candidates
+), clustered AS (
+  SELECT candidates.id, candidates.geometry,
+    ST_ClusterDBSCAN(ST_Transform(candidates.geometry, 3857), sqlc.arg(distance)::float8 * scale.factor, sqlc.arg(min_size)::int) OVER () AS cluster
+  FROM candidates, scale
+)
+SELECT
+  array_agg(id ORDER BY id)::int[] AS tree_ids,
+  ST_Y(ST_Centroid(ST_Collect(geometry)))::float8 AS latitude,
+  ST_X(ST_Centroid(ST_Collect(geometry)))::float8 AS longitude
+FROM clustered
+WHERE cluster IS NOT NULL
+GROUP BY cluster
+ORDER BY min(id);
@@ -1,5 +1,20 @@
 -- name: GetAllVehicles :many
-SELECT * FROM vehicles;
+SELECT * FROM vehicles
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'number_plate' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN number_plate END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'number_plate' AND sqlc.arg(sort_desc)::BOOLEAN THEN number_plate END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'water_capacity' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN water_capacity END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'water_capacity' AND sqlc.arg(sort_desc)::BOOLEAN THEN water_capacity END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountVehicles :one
+SELECT COUNT(*) FROM vehicles;
 
 -- name: GetVehicleByID :one
 SELECT * FROM vehicles WHERE id = $1;
@@ -23,3 +38,18 @@ WHERE id = $1;
 
 -- name: DeleteVehicle :exec
 DELETE FROM vehicles WHERE id = $1;
+
+-- name: GetVehiclesByUserID :many
+SELECT vehicles.* FROM vehicles JOIN user_vehicles ON vehicles.id = user_vehicles.vehicle_id WHERE user_vehicles.user_id = $1;
+
+-- name: GetUserIDsByVehicleID :many
+SELECT user_id FROM user_vehicles WHERE vehicle_id = $1;
+
+-- name: AssignVehicleToUser :exec
+INSERT INTO user_vehicles (user_id, vehicle_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: UnassignVehicleFromUser :exec
+DELETE FROM user_vehicles WHERE user_id = $1 AND vehicle_id = $2;
+
+-- name: UnassignAllUsersFromVehicle :exec
+DELETE FROM user_vehicles WHERE vehicle_id = $1;
@@ -0,0 +1,43 @@
+-- name: GetAllWateringEvents :many
+SELECT * FROM watering_events
+WHERE (sqlc.narg(tree_cluster_id)::INT IS NULL OR tree_cluster_id = sqlc.narg(tree_cluster_id)::INT)
+  AND (sqlc.narg(vehicle_id)::INT IS NULL OR vehicle_id = sqlc.narg(vehicle_id)::INT)
+  AND (sqlc.narg(watered_from)::TIMESTAMP IS NULL OR watered_at >= sqlc.narg(watered_from)::TIMESTAMP)
+  AND (sqlc.narg(watered_to)::TIMESTAMP IS NULL OR watered_at < sqlc.narg(watered_to)::TIMESTAMP)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'watered_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN watered_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'watered_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN watered_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'water_amount' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN water_amount END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'water_amount' AND sqlc.arg(sort_desc)::BOOLEAN THEN water_amount END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountWateringEvents :one
+SELECT COUNT(*) FROM watering_events
+WHERE (sqlc.narg(tree_cluster_id)::INT IS NULL OR tree_cluster_id = sqlc.narg(tree_cluster_id)::INT)
+  AND (sqlc.narg(vehicle_id)::INT IS NULL OR vehicle_id = sqlc.narg(vehicle_id)::INT)
+  AND (sqlc.narg(watered_from)::TIMESTAMP IS NULL OR watered_at >= sqlc.narg(watered_from)::TIMESTAMP)
+  AND (sqlc.narg(watered_to)::TIMESTAMP IS NULL OR watered_at < sqlc.narg(watered_to)::TIMESTAMP);
+
+-- name: SumWateringEventsWaterAmount :one
+SELECT COALESCE(SUM(water_amount), 0)::FLOAT FROM watering_events
+WHERE (sqlc.narg(tree_cluster_id)::INT IS NULL OR tree_cluster_id = sqlc.narg(tree_cluster_id)::INT)
+  AND (sqlc.narg(vehicle_id)::INT IS NULL OR vehicle_id = sqlc.narg(vehicle_id)::INT)
+  AND (sqlc.narg(watered_from)::TIMESTAMP IS NULL OR watered_at >= sqlc.narg(watered_from)::TIMESTAMP)
+  AND (sqlc.narg(watered_to)::TIMESTAMP IS NULL OR watered_at < sqlc.narg(watered_to)::TIMESTAMP);
+
+-- name: GetWateringEventByID :one
+SELECT * FROM watering_events WHERE id = $1;
+
+-- name: CreateWateringEvent :one
+INSERT INTO watering_events (
+  tree_cluster_id, vehicle_id, user_id, watered_at, water_amount
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id;
+
+-- name: DeleteWateringEvent :exec
+DELETE FROM watering_events WHERE id = $1;
@@ -0,0 +1,60 @@
+-- name: GetAllWateringPlans :many
+SELECT * FROM watering_plans
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'date' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN date END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'date' AND sqlc.arg(sort_desc)::BOOLEAN THEN date END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'status' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN status END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'status' AND sqlc.arg(sort_desc)::BOOLEAN THEN status END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN created_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'created_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN created_at END DESC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND NOT sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::TEXT = 'updated_at' AND sqlc.arg(sort_desc)::BOOLEAN THEN updated_at END DESC,
+  CASE WHEN sqlc.arg(sort_desc)::BOOLEAN AND sqlc.arg(sort_by)::TEXT IN ('', 'id') THEN id END DESC,
+  id ASC
+LIMIT sqlc.narg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountWateringPlans :one
+SELECT COUNT(*) FROM watering_plans;
+
+-- name: GetWateringPlanByID :one
+SELECT * FROM watering_plans WHERE id = $1;
+
+-- name: CreateWateringPlan :one
+INSERT INTO watering_plans (
+  date, description, status, total_water_required, vehicle_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id;
+
+-- name: UpdateWateringPlan :exec
+UPDATE watering_plans SET
+  date = $2,
+  description = $3,
+  status = $4,
+  total_water_required = $5,
+  vehicle_id = $6
+WHERE id = $1;
+
+-- name: DeleteWateringPlan :exec
+DELETE FROM watering_plans WHERE id = $1;
+
+-- name: GetUserIDsByWateringPlanID :many
+SELECT user_id FROM watering_plan_users WHERE watering_plan_id = $1;
+
+-- name: AddUserToWateringPlan :exec
+INSERT INTO watering_plan_users (watering_plan_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;
+
+-- name: RemoveAllUsersFromWateringPlan :exec
+DELETE FROM watering_plan_users WHERE watering_plan_id = $1;
+
+-- name: GetTreeClustersByWateringPlanID :many
+SELECT tree_clusters.* FROM tree_clusters
+JOIN watering_plan_tree_clusters ON tree_clusters.id = watering_plan_tree_clusters.tree_cluster_id
+WHERE watering_plan_tree_clusters.watering_plan_id = $1
+ORDER BY watering_plan_tree_clusters.position;
+
+-- name: AddTreeClusterToWateringPlan :exec
+INSERT INTO watering_plan_tree_clusters (watering_plan_id, tree_cluster_id, position) VALUES ($1, $2, $3);
+
+-- name: RemoveAllTreeClustersFromWateringPlan :exec
+DELETE FROM watering_plan_tree_clusters WHERE watering_plan_id = $1;
@@ -28,9 +28,20 @@ func (r *RegionRepository) Create(ctx context.Context, vFn ...entities.EntityFun
 	return r.GetByID(ctx, *id)
 }
 
+// geometryWKT returns the geometry as WKT or nil to store a region without geometry
+func geometryWKT(geometry entities.MultiPolygon) *string {
+	if len(geometry) == 0 {
+		return nil
+	}
+
+	wkt := geometry.WKT()
+	return &wkt
+}
+
 func (r *RegionRepository) createEntity(ctx context.Context, entity *entities.Region) (*int32, error) {
 	args := sqlc.CreateRegionParams{
-		Name: entity.Name,
+		Name:           entity.Name,
+		StGeomfromtext: geometryWKT(entity.Geometry),
 	}
 
 	id, err := r.store.CreateRegion(ctx, &args)
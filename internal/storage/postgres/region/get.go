@@ -4,25 +4,44 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/jackc/pgx/v5"
 )
 
-func (r *RegionRepository) GetAll(ctx context.Context) ([]*entities.Region, error) {
-	rows, err := r.store.GetAllRegions(ctx)
+func (r *RegionRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Region, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "name", "created_at", "updated_at")
 	if err != nil {
-		fmt.Printf("error: %v\n", err)
-		return nil, err
+		return nil, 0, err
 	}
-	fmt.Printf("rows: %v\n", rows)
 
-	return r.mapper.FromSqlList(rows), nil
+	total, err := r.store.CountRegions(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllRegions(ctx, &sqlc.GetAllRegionsParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), total, nil
 }
 
 func (r *RegionRepository) GetByID(ctx context.Context, id int32) (*entities.Region, error) {
 	row, err := r.store.GetRegionById(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrRegionNotFound
+		}
 		return nil, err
 	}
 
@@ -32,6 +51,9 @@ func (r *RegionRepository) GetByID(ctx context.Context, id int32) (*entities.Reg
 func (r *RegionRepository) GetByName(ctx context.Context, plate string) (*entities.Region, error) {
 	row, err := r.store.GetRegionByName(ctx, plate)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrRegionNotFound
+		}
 		return nil, err
 	}
 
@@ -39,8 +61,12 @@ func (r *RegionRepository) GetByName(ctx context.Context, plate string) (*entiti
 }
 
 func (r *RegionRepository) GetByPoint(ctx context.Context, latitude, longitude float64) (*entities.Region, error) {
-	p := fmt.Sprintf("POINT(%f %f)", longitude, latitude)
-	region, err := r.store.GetRegionByPoint(ctx, p)
+	location, err := entities.NewCoordinate(latitude, longitude)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", storage.ErrInvalidGeometry, err)
+	}
+
+	region, err := r.store.GetRegionByPoint(ctx, location.WKT())
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil
@@ -50,3 +76,32 @@ func (r *RegionRepository) GetByPoint(ctx context.Context, latitude, longitude f
 
 	return r.mapper.FromSql(region), nil
 }
+
+// CheckGeometry lets PostGIS validate the geometry, e.g. that the rings of a polygon don't intersect
+// themselves. An invalid geometry is reported as storage.ErrInvalidGeometry with the reason.
+func (r *RegionRepository) CheckGeometry(ctx context.Context, geometry entities.MultiPolygon) error {
+	row, err := r.store.GetGeometryValidity(ctx, geometry.WKT())
+	if err != nil {
+		return r.store.HandleError(err)
+	}
+
+	if !row.Valid {
+		return fmt.Errorf("%w: %s", storage.ErrInvalidGeometry, row.Reason)
+	}
+
+	return nil
+}
+
+// GetOverlapping returns the regions whose area overlaps the geometry. Regions only sharing
+// a border are not overlapping. The region with excludeID, e.g. the region being updated, is ignored.
+func (r *RegionRepository) GetOverlapping(ctx context.Context, geometry entities.MultiPolygon, excludeID int32) ([]*entities.Region, error) {
+	rows, err := r.store.GetOverlappingRegions(ctx, &sqlc.GetOverlappingRegionsParams{
+		ExcludeID: excludeID,
+		Wkt:       geometry.WKT(),
+	})
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
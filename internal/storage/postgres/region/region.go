@@ -2,6 +2,7 @@ package region
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
@@ -39,6 +40,14 @@ func WithName(name string) entities.EntityFunc[entities.Region] {
 	}
 }
 
+func WithGeometry(geometry entities.MultiPolygon) entities.EntityFunc[entities.Region] {
+	return func(v *entities.Region) {
+		slog.Debug("updating geometry", "polygons", len(geometry))
+		v.Geometry = geometry
+	}
+}
+
+// Delete removes the region, tree clusters and flowerbeds in the region are unlinked by the database
 func (r *RegionRepository) Delete(ctx context.Context, id int32) error {
 	return r.store.DeleteRegion(ctx, id)
 }
@@ -12,11 +12,11 @@ INSERT INTO user_vehicles (user_id, vehicle_id) VALUES ('d2563a8e-a608-4039-8718
 
 INSERT INTO tree_clusters (id, name, watering_status, moisture_level, region_id, address, description, soil_condition, latitude, longitude, geometry)
 VALUES 
-  (1, 'Gruppe: Solitüde Strand', 'good', 0.75, 1, 'Solitüde Strand', 'Alle Bäume am Strand', 'sandig', 54.820940, 9.489022, ST_SetSRID(ST_MakePoint(54.820940, 9.489022), 4326)),
-  (2, 'Gruppe: Sankt-Jürgen-Platz', 'moderate', 0.5, 1, 'Ulmenstraße', 'Bäume beim Sankt-Jürgen-Platz', 'schluffig', 54.78805731048199, 9.44400186680097, ST_SetSRID(ST_MakePoint(54.78805731048199, 9.44400186680097), 4326));
+  (1, 'Gruppe: Solitüde Strand', 'good', 0.75, 1, 'Solitüde Strand', 'Alle Bäume am Strand', 'sandig', 54.820940, 9.489022, ST_SetSRID(ST_MakePoint(9.489022, 54.820940), 4326)),
+  (2, 'Gruppe: Sankt-Jürgen-Platz', 'moderate', 0.5, 1, 'Ulmenstraße', 'Bäume beim Sankt-Jürgen-Platz', 'schluffig', 54.78805731048199, 9.44400186680097, ST_SetSRID(ST_MakePoint(9.44400186680097, 54.78805731048199), 4326));
 ALTER SEQUENCE tree_clusters_id_seq RESTART WITH 3;
 
-INSERT INTO sensors (id, status) VALUES (1, 'online');
+INSERT INTO sensors (id, status, device_id, dev_eui, application_id) VALUES (1, 'online', 'tree-sensor', '0004A30B001C1B2A', 'green-ecolution');
 INSERT INTO sensors (id, status) VALUES (2, 'offline');
 INSERT INTO sensors (id, status) VALUES (3, 'unknown');
 INSERT INTO sensors (id, status) VALUES (4, 'online');
@@ -24,13 +24,13 @@ ALTER SEQUENCE sensors_id_seq RESTART WITH 5;
 
 INSERT INTO trees (tree_cluster_id, sensor_id, age, height_above_sea_level, planting_year, species, tree_number, latitude, longitude, geometry)
 VALUES 
-  (1, 1, 3, 10.0, 2021, 'Quercus robur', 1, 54.82124518093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(54.82124518093376, 9.485702120628517), 4326)),
-  (1, NULL, 2, 11.0, 2022, 'Quercus robur', 1, 54.8215076622281, 9.487153277881877, ST_SetSRID(ST_MakePoint(54.8215076622281, 9.487153277881877), 4326)),
-  (1, NULL, 1, 13.3, 2023, 'Quercus robur', 1, 54.82078826498143, 9.489684366114483, ST_SetSRID(ST_MakePoint(54.82078826498143, 9.489684366114483), 4326)),
-  (2, 2, 4, 10.0, 2020, 'Quercus robur', 1, 54.78780993841013, 9.444052105200551, ST_SetSRID(ST_MakePoint(54.78780993841013, 9.444052105200551), 4326)),
-  (2, NULL, 3, 11.0, 2021, 'Quercus robur', 1, 54.78836553796373, 9.444075995492044, ST_SetSRID(ST_MakePoint(54.78836553796373, 9.444075995492044), 4326)),
-  (2, NULL, 2, 13.3, 2022, 'Quercus robur', 1, 54.787768612518455, 9.443996361187065, ST_SetSRID(ST_MakePoint(54.787768612518455, 9.443996361187065), 4326)),
-  (2, NULL, 2, 13.3, 2022, 'Quercus robur', 1, 54.77933725347423, 9.426465409018832, ST_SetSRID(ST_MakePoint(54.77933725347423, 9.426465409018832), 4326));
+  (1, 1, 3, 10.0, 2021, 'Quercus robur', 1, 54.82124518093376, 9.485702120628517, ST_SetSRID(ST_MakePoint(9.485702120628517, 54.82124518093376), 4326)),
+  (1, NULL, 2, 11.0, 2022, 'Quercus robur', 1, 54.8215076622281, 9.487153277881877, ST_SetSRID(ST_MakePoint(9.487153277881877, 54.8215076622281), 4326)),
+  (1, NULL, 1, 13.3, 2023, 'Quercus robur', 1, 54.82078826498143, 9.489684366114483, ST_SetSRID(ST_MakePoint(9.489684366114483, 54.82078826498143), 4326)),
+  (2, 2, 4, 10.0, 2020, 'Quercus robur', 1, 54.78780993841013, 9.444052105200551, ST_SetSRID(ST_MakePoint(9.444052105200551, 54.78780993841013), 4326)),
+  (2, NULL, 3, 11.0, 2021, 'Quercus robur', 1, 54.78836553796373, 9.444075995492044, ST_SetSRID(ST_MakePoint(9.444075995492044, 54.78836553796373), 4326)),
+  (2, NULL, 2, 13.3, 2022, 'Quercus robur', 1, 54.787768612518455, 9.443996361187065, ST_SetSRID(ST_MakePoint(9.443996361187065, 54.787768612518455), 4326)),
+  (2, NULL, 2, 13.3, 2022, 'Quercus robur', 1, 54.77933725347423, 9.426465409018832, ST_SetSRID(ST_MakePoint(9.426465409018832, 54.77933725347423), 4326));
 
 INSERT INTO sensor_data (sensor_id, data)
 VALUES 
@@ -28,7 +28,7 @@ func (r *SensorRepository) Create(ctx context.Context, sFn ...entities.EntityFun
 	}
 
 	entity.ID = id
-	_, err = r.InsertSensorData(ctx, entity.Data)
+	_, err = r.InsertSensorData(ctx, id, entity.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,7 @@ func (r *SensorRepository) Create(ctx context.Context, sFn ...entities.EntityFun
 	return r.GetByID(ctx, id)
 }
 
-func (r *SensorRepository) InsertSensorData(ctx context.Context, data []*entities.SensorData) ([]*entities.SensorData, error) {
+func (r *SensorRepository) InsertSensorData(ctx context.Context, sensorID int32, data []*entities.SensorData) ([]*entities.SensorData, error) {
 	for _, d := range data {
 		mqttData := r.mapper.FromDomainSensorData(d.Data)
 		raw, err := json.Marshal(mqttData)
@@ -45,7 +45,7 @@ func (r *SensorRepository) InsertSensorData(ctx context.Context, data []*entitie
 		}
 
 		params := &sqlc.InsertSensorDataParams{
-			SensorID: d.ID,
+			SensorID: sensorID,
 			Data:     raw,
 		}
 
@@ -59,5 +59,13 @@ func (r *SensorRepository) InsertSensorData(ctx context.Context, data []*entitie
 }
 
 func (r *SensorRepository) createEntity(ctx context.Context, sensor *entities.Sensor) (int32, error) {
-	return r.store.CreateSensor(ctx, sqlc.SensorStatus(sensor.Status))
+	args := sqlc.CreateSensorParams{
+		Status:        sqlc.SensorStatus(sensor.Status),
+		DeviceID:      sensor.DeviceID,
+		DevEui:        sensor.DevEUI,
+		JoinEui:       sensor.JoinEUI,
+		ApplicationID: sensor.ApplicationID,
+	}
+
+	return r.store.CreateSensor(ctx, &args)
 }
@@ -2,26 +2,71 @@ package sensor
 
 import (
 	"context"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 )
 
-func (r *SensorRepository) GetAll(ctx context.Context) ([]*entities.Sensor, error) {
-	rows, err := r.store.GetAllSensors(ctx)
+func (r *SensorRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Sensor, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "created_at", "updated_at")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return r.mapper.FromSqlList(rows), nil
+	total, err := r.store.CountSensors(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllSensors(ctx, &sqlc.GetAllSensorsParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), total, nil
 }
 
 func (r *SensorRepository) GetByID(ctx context.Context, id int32) (*entities.Sensor, error) {
 	row, err := r.store.GetSensorByID(ctx, id)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSensorNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *SensorRepository) GetByDeviceID(ctx context.Context, deviceID string) (*entities.Sensor, error) {
+	row, err := r.store.GetSensorByDeviceID(ctx, &deviceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSensorNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *SensorRepository) GetByDevEUI(ctx context.Context, devEUI string) (*entities.Sensor, error) {
+	row, err := r.store.GetSensorByDevEUI(ctx, &devEUI)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSensorNotFound
+		}
+		return nil, r.store.HandleError(err)
 	}
 
 	return r.mapper.FromSql(row), nil
@@ -54,13 +99,35 @@ func (r *SensorRepository) GetSensorDataByID(ctx context.Context, id int32) ([]*
 	domainData := make([]*entities.SensorData, len(rows))
 
 	for i, row := range rows {
-		domainData[i] = r.mapper.FromSqlSensorData(row)
-		data, err := mapper.MapSensorData(row.Data)
+		data, err := r.mapSensorData(row)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to map sensor data")
+			return nil, err
 		}
-		domainData[i].Data = data
+		domainData[i] = data
 	}
 
 	return domainData, nil
 }
+
+func (r *SensorRepository) GetLatestSensorDataByID(ctx context.Context, id int32) (*entities.SensorData, error) {
+	row, err := r.store.GetLatestSensorDataBySensorID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrSensorDataNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapSensorData(row)
+}
+
+func (r *SensorRepository) mapSensorData(row *sqlc.SensorDatum) (*entities.SensorData, error) {
+	data := r.mapper.FromSqlSensorData(row)
+	payload, err := mapper.MapSensorData(row.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to map sensor data")
+	}
+	data.Data = r.mapper.ToDomainSensorData(payload)
+
+	return data, nil
+}
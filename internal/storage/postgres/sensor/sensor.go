@@ -38,6 +38,30 @@ func WithStatus(status entities.SensorStatus) entities.EntityFunc[entities.Senso
 	}
 }
 
+func WithDeviceID(deviceID string) entities.EntityFunc[entities.Sensor] {
+	return func(s *entities.Sensor) {
+		s.DeviceID = &deviceID
+	}
+}
+
+func WithDevEUI(devEUI string) entities.EntityFunc[entities.Sensor] {
+	return func(s *entities.Sensor) {
+		s.DevEUI = &devEUI
+	}
+}
+
+func WithJoinEUI(joinEUI string) entities.EntityFunc[entities.Sensor] {
+	return func(s *entities.Sensor) {
+		s.JoinEUI = &joinEUI
+	}
+}
+
+func WithApplicationID(applicationID string) entities.EntityFunc[entities.Sensor] {
+	return func(s *entities.Sensor) {
+		s.ApplicationID = &applicationID
+	}
+}
+
 func WithData(data []*entities.SensorData) entities.EntityFunc[entities.Sensor] {
 	return func(s *entities.Sensor) {
 		s.Data = data
@@ -22,7 +22,7 @@ func (r *SensorRepository) Update(ctx context.Context, id int32, sFn ...entities
 	}
 
 	if len(entity.Data) > 0 {
-		_, err := r.InsertSensorData(ctx, entity.Data)
+		_, err := r.InsertSensorData(ctx, entity.ID, entity.Data)
 		if err != nil {
 			return nil, err
 		}
@@ -33,8 +33,12 @@ func (r *SensorRepository) Update(ctx context.Context, id int32, sFn ...entities
 
 func (r *SensorRepository) updateEntity(ctx context.Context, sensor *entities.Sensor) error {
 	params := sqlc.UpdateSensorParams{
-		ID:     sensor.ID,
-		Status: sqlc.SensorStatus(sensor.Status),
+		ID:            sensor.ID,
+		Status:        sqlc.SensorStatus(sensor.Status),
+		DeviceID:      sensor.DeviceID,
+		DevEui:        sensor.DevEUI,
+		JoinEui:       sensor.JoinEUI,
+		ApplicationID: sensor.ApplicationID,
 	}
 
 	return r.store.UpdateSensor(ctx, &params)
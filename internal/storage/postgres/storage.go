@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"github.com/green-ecolution/green-ecolution-backend/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/flowerbed"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/image"
@@ -8,14 +9,17 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/region"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/sensor"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tile"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/tree"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/treecluster"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/vehicle"
-	"github.com/jackc/pgx/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringevent"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/wateringplan"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewRepository(conn *pgx.Conn) *storage.Repository {
-	s := store.NewStore(conn)
+func NewRepository(pool *pgxpool.Pool, cfg *config.TreeClusterConfig) *storage.Repository {
+	s := store.NewStore(pool)
 
 	treeMappers := tree.NewTreeRepositoryMappers(
 		&mapper.InternalTreeRepoMapperImpl{},
@@ -23,7 +27,7 @@ func NewRepository(conn *pgx.Conn) *storage.Repository {
 		&mapper.InternalSensorRepoMapperImpl{},
 		&mapper.InternalTreeClusterRepoMapperImpl{},
 	)
-	treeRepo := tree.NewTreeRepository(s, treeMappers)
+	treeRepo := tree.NewTreeRepository(s, treeMappers, &cfg.Hull)
 
 	tcMappers := treecluster.NewTreeClusterRepositoryMappers(
 		&mapper.InternalTreeClusterRepoMapperImpl{},
@@ -61,13 +65,31 @@ func NewRepository(conn *pgx.Conn) *storage.Repository {
 	)
 	regionRepo := region.NewRegionRepository(s, regionMappers)
 
+	wateringPlanMappers := wateringplan.NewWateringPlanRepositoryMappers(
+		&mapper.InternalWateringPlanRepoMapperImpl{},
+		&mapper.InternalVehicleRepoMapperImpl{},
+		&mapper.InternalTreeClusterRepoMapperImpl{},
+	)
+	wateringPlanRepo := wateringplan.NewWateringPlanRepository(s, wateringPlanMappers)
+
+	wateringEventMappers := wateringevent.NewWateringEventRepositoryMappers(
+		&mapper.InternalWateringEventRepoMapperImpl{},
+	)
+	wateringEventRepo := wateringevent.NewWateringEventRepository(s, wateringEventMappers)
+
+	tileRepo := tile.NewTileRepository(s)
+
 	return &storage.Repository{
-		Tree:        treeRepo,
-		TreeCluster: treeClusterRepo,
-		Image:       imageRepo,
-		Vehicle:     vehicleRepo,
-		Sensor:      sensorRepo,
-		Flowerbed:   flowerbedRepo,
-		Region:      regionRepo,
+		Tree:          treeRepo,
+		TreeCluster:   treeClusterRepo,
+		Image:         imageRepo,
+		Vehicle:       vehicleRepo,
+		Sensor:        sensorRepo,
+		Flowerbed:     flowerbedRepo,
+		Region:        regionRepo,
+		WateringPlan:  wateringPlanRepo,
+		WateringEvent: wateringEventRepo,
+		Tile:          tileRepo,
+		UnitOfWork:    s,
 	}
 }
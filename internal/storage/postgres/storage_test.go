@@ -0,0 +1,45 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewRepository_NotFound checks the not found errors of the repositories sharing one store,
+// every repository has to return its own error regardless of the order they were created in
+func TestNewRepository_NotFound(t *testing.T) {
+	repo := postgres.NewRepository(testutils.SetupTestPool(t), &config.TreeClusterConfig{})
+	ctx := context.Background()
+	const missingID = 999999
+
+	tests := []struct {
+		name     string
+		get      func() error
+		expected error
+	}{
+		{"tree", func() error { _, err := repo.Tree.GetByID(ctx, missingID); return err }, storage.ErrTreeNotFound},
+		{"tree cluster", func() error { _, err := repo.TreeCluster.GetByID(ctx, missingID); return err }, storage.ErrTreeClusterNotFound},
+		{"flowerbed", func() error { _, err := repo.Flowerbed.GetByID(ctx, missingID); return err }, storage.ErrFlowerbedNotFound},
+		{"vehicle", func() error { _, err := repo.Vehicle.GetByID(ctx, missingID); return err }, storage.ErrVehicleNotFound},
+		{"image", func() error { _, err := repo.Image.GetByID(ctx, missingID); return err }, storage.ErrImageNotFound},
+		{"region", func() error { _, err := repo.Region.GetByID(ctx, missingID); return err }, storage.ErrRegionNotFound},
+		{"watering plan", func() error { _, err := repo.WateringPlan.GetByID(ctx, missingID); return err }, storage.ErrWateringPlanNotFound},
+		{"watering event", func() error { _, err := repo.WateringEvent.GetByID(ctx, missingID); return err }, storage.ErrWateringEventNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run("should return not found error of "+tt.name, func(t *testing.T) {
+			// when
+			err := tt.get()
+
+			// then
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"slices"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+// SortParams returns the sort arguments of the list queries. The id is always sortable,
+// all other fields have to be supported by the ORDER BY clause of the query.
+func SortParams(query entities.Query, fields ...string) (sortBy string, desc bool, err error) {
+	if query.SortBy != "" && query.SortBy != "id" && !slices.Contains(fields, query.SortBy) {
+		return "", false, storage.ErrInvalidSortField
+	}
+
+	switch query.SortDirection {
+	case "", entities.SortAsc:
+		return query.SortBy, false, nil
+	case entities.SortDesc:
+		return query.SortBy, true, nil
+	default:
+		return "", false, storage.ErrInvalidSortField
+	}
+}
+
+// LimitParam returns the limit argument of the list queries, which is nil if all rows are requested
+func LimitParam(query entities.Query) *int32 {
+	if query.Limit <= 0 {
+		return nil
+	}
+
+	return &query.Limit
+}
+
+// SpatialParams are the arguments of the spatial filters of the list queries, which are nil
+// if the filter is not applied
+type SpatialParams struct {
+	MinLongitude    *float64
+	MinLatitude     *float64
+	MaxLongitude    *float64
+	MaxLatitude     *float64
+	CenterLongitude *float64
+	CenterLatitude  *float64
+	Radius          *float64
+	Area            *string
+}
+
+func SpatialFilterParams(filter entities.SpatialFilter) SpatialParams {
+	var params SpatialParams
+	if b := filter.BoundingBox; b != nil {
+		params.MinLongitude = &b.Min.Longitude
+		params.MinLatitude = &b.Min.Latitude
+		params.MaxLongitude = &b.Max.Longitude
+		params.MaxLatitude = &b.Max.Latitude
+	}
+
+	if r := filter.Radius; r != nil {
+		params.CenterLongitude = &r.Center.Longitude
+		params.CenterLatitude = &r.Center.Latitude
+		params.Radius = &r.Meters
+	}
+
+	if len(filter.Area) > 0 {
+		area := filter.Area.WKT()
+		params.Area = &area
+	}
+
+	return params
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
 
@@ -22,15 +24,18 @@ const (
 	Vehicle     EntityType = "vehicle"
 )
 
+// pgUniqueViolation is the postgres error code for a violated unique constraint
+const pgUniqueViolation = "23505"
+
 type Store struct {
 	*sqlc.Queries
-	db         *pgx.Conn
+	db         *pgxpool.Pool
 	entityType EntityType
 }
 
-func NewStore(db *pgx.Conn) *Store {
+func NewStore(db *pgxpool.Pool) *Store {
 	return &Store{
-		Queries: sqlc.New(db),
+		Queries: sqlc.New(&txAwareDB{pool: db}),
 		db:      db,
 	}
 }
@@ -45,6 +50,13 @@ func (s *Store) HandleError(err error) error {
 	}
 
 	slog.Error("An Error occurred in database operation", "error", err, "entityType", s.entityType)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		slog.Error("Unique constraint violated", "error", err, "constraint", pgErr.ConstraintName)
+		return storage.ErrDuplicateEntity
+	}
+
 	switch err {
 	case pgx.ErrNoRows:
 		switch s.entityType {
@@ -57,6 +69,9 @@ func (s *Store) HandleError(err error) error {
 		case Flowerbed:
 			slog.Error("Flowerbed not found", "error", err, "stack", errors.WithStack(err))
 			return storage.ErrFlowerbedNotFound
+		case Vehicle:
+			slog.Error("Vehicle not found", "error", err, "stack", errors.WithStack(err))
+			return storage.ErrVehicleNotFound
 		case TreeCluster:
 			slog.Error("TreeCluster not found", "error", err, "stack", errors.WithStack(err))
 			return storage.ErrTreeClusterNotFound
@@ -83,24 +98,43 @@ func (s *Store) HandleError(err error) error {
 	}
 }
 
-func (s *Store) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
-	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
+// WithTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+// All queries of the store using the context passed to fn are part of the transaction. If the context
+// already carries a transaction, a nested transaction is started using a savepoint.
+func (s *Store) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	var tx pgx.Tx
+	if outer, ok := txFromContext(ctx); ok {
+		tx, err = outer.Begin(ctx)
+	} else {
+		tx, err = s.db.BeginTx(ctx, pgx.TxOptions{})
+	}
 	if err != nil {
-		return err
+		return s.HandleError(err)
 	}
 
-	err = fn(tx)
-	if err != nil {
-		err = tx.Rollback(ctx)
-		if err != nil {
-			return err
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
 		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			slog.Error("Error while rolling back transaction", "error", rbErr)
+		}
+		return err
 	}
-	return tx.Commit(ctx)
+
+	if err := tx.Commit(ctx); err != nil {
+		return s.HandleError(err)
+	}
+
+	return nil
 }
 
 func (s *Store) Close() {
-	s.db.Close(context.Background())
+	s.db.Close()
 }
 
 func (s *Store) CheckSensorExists(ctx context.Context, sensorID *int32) error {
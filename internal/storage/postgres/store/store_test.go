@@ -0,0 +1,147 @@
+package store_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	mapper "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper/generated"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/testutils"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/vehicle"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+var (
+	setupOnce sync.Once
+	shutdown  func()
+	pool      *pgxpool.Pool
+	setupErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if pool != nil {
+		pool.Close()
+	}
+	if shutdown != nil {
+		shutdown()
+	}
+	os.Exit(code)
+}
+
+// setupStore starts the postgres container on first use, tests are skipped if docker is not available
+func setupStore(t *testing.T) *store.Store {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	setupOnce.Do(func() {
+		var url *string
+		shutdown, url, setupErr = testutils.SetupPostgresContainer()
+		if setupErr == nil {
+			pool, setupErr = testutils.NewPool(context.Background(), *url)
+		}
+	})
+	if setupErr != nil {
+		t.Fatalf("error setting up postgres container: %v", setupErr)
+	}
+
+	return store.NewStore(pool)
+}
+
+func createVehicle(t *testing.T, ctx context.Context, s *store.Store, plate string) int32 {
+	t.Helper()
+	id, err := s.CreateVehicle(ctx, &sqlc.CreateVehicleParams{NumberPlate: plate, Description: "test", WaterCapacity: 1000})
+	if err != nil {
+		t.Fatalf("error creating vehicle: %v", err)
+	}
+
+	return id
+}
+
+func vehicleExists(t *testing.T, s *store.Store, id int32) bool {
+	t.Helper()
+	_, err := s.GetVehicleByID(context.Background(), id)
+	return err == nil
+}
+
+func TestStore_WithTx(t *testing.T) {
+	t.Run("should commit when function succeeds", func(t *testing.T) {
+		// given
+		s := setupStore(t)
+		var id int32
+
+		// when
+		err := s.WithTx(context.Background(), func(ctx context.Context) error {
+			id = createVehicle(t, ctx, s, "FL TX 1")
+			return nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, vehicleExists(t, s, id))
+	})
+
+	t.Run("should rollback and return error when function fails", func(t *testing.T) {
+		// given
+		s := setupStore(t)
+		var id int32
+
+		// when
+		err := s.WithTx(context.Background(), func(ctx context.Context) error {
+			id = createVehicle(t, ctx, s, "FL TX 2")
+			return assert.AnError
+		})
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, vehicleExists(t, s, id))
+	})
+
+	t.Run("should only rollback nested transaction", func(t *testing.T) {
+		// given
+		s := setupStore(t)
+		var outerID, innerID int32
+
+		// when
+		err := s.WithTx(context.Background(), func(ctx context.Context) error {
+			outerID = createVehicle(t, ctx, s, "FL TX 3")
+			innerErr := s.WithTx(ctx, func(ctx context.Context) error {
+				innerID = createVehicle(t, ctx, s, "FL TX 4")
+				return assert.AnError
+			})
+			assert.ErrorIs(t, innerErr, assert.AnError)
+			return nil
+		})
+
+		// then
+		assert.NoError(t, err)
+		assert.True(t, vehicleExists(t, s, outerID))
+		assert.False(t, vehicleExists(t, s, innerID))
+	})
+
+	t.Run("should rollback repository calls as unit of work", func(t *testing.T) {
+		// given
+		s := setupStore(t)
+		var uow storage.UnitOfWork = s
+		repo := vehicle.NewVehicleRepository(s, vehicle.NewVehicleRepositoryMappers(&mapper.InternalVehicleRepoMapperImpl{}))
+		id := createVehicle(t, context.Background(), s, "FL TX 5")
+
+		// when
+		err := uow.WithTx(context.Background(), func(ctx context.Context) error {
+			if err := repo.Delete(ctx, id); err != nil {
+				return err
+			}
+			return assert.AnError
+		})
+
+		// then
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.True(t, vehicleExists(t, s, id))
+	})
+}
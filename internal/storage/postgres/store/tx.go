@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// txAwareDB runs the queries in the transaction of the context if there is one,
+// otherwise on a connection of the pool
+type txAwareDB struct {
+	pool *pgxpool.Pool
+}
+
+func (d *txAwareDB) conn(ctx context.Context) sqlc.DBTX {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+
+	return d.pool
+}
+
+func (d *txAwareDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return d.conn(ctx).Exec(ctx, sql, args...)
+}
+
+func (d *txAwareDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return d.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (d *txAwareDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return d.conn(ctx).QueryRow(ctx, sql, args...)
+}
@@ -5,16 +5,20 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/twpayne/go-geos"
+	pgxgeos "github.com/twpayne/pgx-geos"
 )
 
 const (
@@ -28,6 +32,12 @@ var (
 	dbPort int
 )
 
+var (
+	setupOnce sync.Once
+	testPool  *pgxpool.Pool
+	setupErr  error
+)
+
 func SetupPostgresContainer() (shutdown func(), url *string, err error) {
 	slog.Info("Setting up postgres container")
 	ctx := context.Background()
@@ -124,6 +134,41 @@ func execMigration(db *sql.DB) error {
 	return nil
 }
 
+// NewPool creates a connection pool to the test database with the PostGIS types registered
+func NewPool(ctx context.Context, dbURL string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		return pgxgeos.Register(ctx, conn, geos.NewContext())
+	}
+
+	return pgxpool.NewWithConfig(ctx, cfg)
+}
+
+// SetupTestPool starts the postgres container on first use and returns a pool to it, which is shared by
+// all tests of the package. The test is skipped if docker is not available. The container is removed by
+// testcontainers when the test binary exits.
+func SetupTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	setupOnce.Do(func() {
+		var url *string
+		_, url, setupErr = SetupPostgresContainer()
+		if setupErr == nil {
+			testPool, setupErr = NewPool(context.Background(), *url)
+		}
+	})
+	if setupErr != nil {
+		t.Fatalf("error setting up postgres container: %v", setupErr)
+	}
+
+	return testPool
+}
+
 // WithTx Run tests with a transaction. This function will rollback the transaction after the test is done.
 func WithTx(_ *testing.T, fn func(db *pgx.Conn)) {
 	ctx := context.Background()
@@ -0,0 +1,63 @@
+package tile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type TileRepository struct {
+	store *store.Store
+}
+
+func NewTileRepository(s *store.Store) storage.TileRepository {
+	return &TileRepository{
+		store: s,
+	}
+}
+
+// GetTile renders the vector tile of the layer with PostGIS. A tile without features is empty.
+func (r *TileRepository) GetTile(ctx context.Context, query *entities.TileQuery) ([]byte, error) {
+	var tile []byte
+	var err error
+	switch query.Layer {
+	case entities.TileLayerTrees:
+		tile, err = r.store.GetTreeTile(ctx, &sqlc.GetTreeTileParams{
+			Z:               query.Z,
+			X:               query.X,
+			Y:               query.Y,
+			ExcludeArchived: query.ExcludeArchived,
+		})
+	case entities.TileLayerClusters:
+		tile, err = r.store.GetTreeClusterTile(ctx, &sqlc.GetTreeClusterTileParams{
+			Z:               query.Z,
+			X:               query.X,
+			Y:               query.Y,
+			ExcludeArchived: query.ExcludeArchived,
+		})
+	case entities.TileLayerFlowerbeds:
+		tile, err = r.store.GetFlowerbedTile(ctx, &sqlc.GetFlowerbedTileParams{
+			Z:               query.Z,
+			X:               query.X,
+			Y:               query.Y,
+			ExcludeArchived: query.ExcludeArchived,
+		})
+	case entities.TileLayerRegions:
+		tile, err = r.store.GetRegionTile(ctx, &sqlc.GetRegionTileParams{
+			Z: query.Z,
+			X: query.X,
+			Y: query.Y,
+		})
+	default:
+		return nil, fmt.Errorf("unknown tile layer %q", query.Layer)
+	}
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return tile, nil
+}
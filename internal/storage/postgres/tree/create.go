@@ -2,8 +2,10 @@ package tree
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 )
 
@@ -37,36 +39,109 @@ func (r *TreeRepository) Create(ctx context.Context, tFn ...entities.EntityFunc[
 }
 
 func (r *TreeRepository) CreateAndLinkImages(ctx context.Context, tFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
-	entity, err := r.Create(ctx, tFn...)
+	var entity *entities.Tree
+	err := r.store.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		entity, err = r.Create(ctx, tFn...)
+		if err != nil {
+			return err
+		}
+
+		return r.handleImages(ctx, entity.ID, entity.Images)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := r.handleImages(ctx, entity.ID, entity.Images); err != nil {
-		return nil, err
+	return entity, nil
+}
+
+// CreateBatch inserts the trees with a single statement and returns their ids in the same order.
+// Only the tree attributes are stored, tree cluster, sensor and images are not linked.
+func (r *TreeRepository) CreateBatch(ctx context.Context, trees []*entities.Tree) ([]int32, error) {
+	args := sqlc.CreateTreesParams{
+		Ages:                 make([]int32, len(trees)),
+		HeightsAboveSeaLevel: make([]float64, len(trees)),
+		PlantingYears:        make([]int32, len(trees)),
+		Species:              make([]string, len(trees)),
+		TreeNumbers:          make([]int32, len(trees)),
+		Latitudes:            make([]float64, len(trees)),
+		Longitudes:           make([]float64, len(trees)),
+		Geometries:           make([]string, len(trees)),
 	}
+	for i, t := range trees {
+		location, err := coordinate(t)
+		if err != nil {
+			return nil, err
+		}
 
-	return entity, nil
+		args.Ages[i] = t.Age
+		args.HeightsAboveSeaLevel[i] = t.HeightAboveSeaLevel
+		args.PlantingYears[i] = t.PlantingYear
+		args.Species[i] = t.Species
+		args.TreeNumbers[i] = t.Number
+		args.Latitudes[i] = t.Latitude
+		args.Longitudes[i] = t.Longitude
+		args.Geometries[i] = location.WKT()
+	}
+
+	ids, err := r.store.CreateTrees(ctx, &args)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return ids, nil
 }
 
 func (r *TreeRepository) createEntity(ctx context.Context, entity *entities.Tree) (int32, error) {
+	location, err := coordinate(entity)
+	if err != nil {
+		return -1, err
+	}
+
 	args := sqlc.CreateTreeParams{
-		TreeClusterID:       &entity.TreeCluster.ID,
+		TreeClusterID:       treeClusterID(entity),
 		Species:             entity.Species,
 		Age:                 entity.Age,
 		HeightAboveSeaLevel: entity.HeightAboveSeaLevel,
-		SensorID:            &entity.Sensor.ID,
+		SensorID:            sensorID(entity),
 		PlantingYear:        entity.PlantingYear,
+		TreeNumber:          entity.Number,
 		Latitude:            entity.Latitude,
 		Longitude:           entity.Longitude,
+		StGeomfromtext:      location.WKT(),
 	}
 
 	return r.store.CreateTree(ctx, &args)
 }
 
+func treeClusterID(t *entities.Tree) *int32 {
+	if t.TreeCluster == nil {
+		return nil
+	}
+	return &t.TreeCluster.ID
+}
+
+func sensorID(t *entities.Tree) *int32 {
+	if t.Sensor == nil {
+		return nil
+	}
+	return &t.Sensor.ID
+}
+
+// coordinate returns the location of the tree, which is stored as geometry
+func coordinate(t *entities.Tree) (entities.Coordinate, error) {
+	c, err := entities.NewCoordinate(t.Latitude, t.Longitude)
+	if err != nil {
+		return entities.Coordinate{}, fmt.Errorf("%w: tree %d: %w", storage.ErrInvalidGeometry, t.Number, err)
+	}
+
+	return c, nil
+}
+
 func (r *TreeRepository) handleImages(ctx context.Context, treeID int32, images []*entities.Image) error {
 	for _, img := range images {
-		err := r.linkImages(ctx, treeID, img.ID)
+		err := r.linkImage(ctx, treeID, img)
 		if err != nil {
 			return err
 		}
@@ -75,10 +150,13 @@ func (r *TreeRepository) handleImages(ctx context.Context, treeID int32, images
 	return nil
 }
 
-func (r *TreeRepository) linkImages(ctx context.Context, treeID, imgID int32) error {
+// linkImage links the image to the tree, the location mismatch flag of the image is kept
+// because the links are recreated on every update of the images
+func (r *TreeRepository) linkImage(ctx context.Context, treeID int32, img *entities.Image) error {
 	params := sqlc.LinkTreeImageParams{
-		TreeID:  treeID,
-		ImageID: imgID,
+		TreeID:           treeID,
+		ImageID:          img.ID,
+		LocationMismatch: img.LocationMismatch,
 	}
 
 	return r.store.LinkTreeImage(ctx, &params)
@@ -2,6 +2,8 @@ package tree
 
 import (
 	"context"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
@@ -10,25 +12,116 @@ import (
 	"github.com/twpayne/go-geos"
 )
 
-func (r *TreeRepository) GetAll(ctx context.Context) ([]*entities.Tree, error) {
-	rows, err := r.store.GetAllTrees(ctx)
+func (r *TreeRepository) GetAll(ctx context.Context, query entities.TreeQuery) ([]*entities.Tree, int64, error) {
+	sortBy, desc, err := store.SortParams(query.Query, "number", "species", "planting_year", "age", "created_at", "updated_at")
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, 0, err
+	}
+
+	spatial := store.SpatialFilterParams(query.SpatialFilter)
+	total, err := r.store.CountTrees(ctx, &sqlc.CountTreesParams{
+		Species:         query.Species,
+		MinAge:          query.MinAge,
+		MaxAge:          query.MaxAge,
+		TreeClusterID:   query.TreeClusterID,
+		MinLongitude:    spatial.MinLongitude,
+		MinLatitude:     spatial.MinLatitude,
+		MaxLongitude:    spatial.MaxLongitude,
+		MaxLatitude:     spatial.MaxLatitude,
+		Radius:          spatial.Radius,
+		CenterLongitude: spatial.CenterLongitude,
+		CenterLatitude:  spatial.CenterLatitude,
+		Area:            spatial.Area,
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllTrees(ctx, &sqlc.GetAllTreesParams{
+		Species:         query.Species,
+		MinAge:          query.MinAge,
+		MaxAge:          query.MaxAge,
+		TreeClusterID:   query.TreeClusterID,
+		MinLongitude:    spatial.MinLongitude,
+		MinLatitude:     spatial.MinLatitude,
+		MaxLongitude:    spatial.MaxLongitude,
+		MaxLatitude:     spatial.MaxLatitude,
+		Radius:          spatial.Radius,
+		CenterLongitude: spatial.CenterLongitude,
+		CenterLatitude:  spatial.CenterLatitude,
+		Area:            spatial.Area,
+		SortBy:          sortBy,
+		SortDesc:        desc,
+		RowOffset:       query.Offset(),
+		RowLimit:        store.LimitParam(query.Query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
 	}
 
 	t := r.mapper.FromSqlList(rows)
 	for _, tree := range t {
 		if err := r.mapFields(ctx, tree); err != nil {
+			return nil, 0, r.store.HandleError(err)
+		}
+	}
+
+	return t, total, nil
+}
+
+// GetNearby returns the trees closest to the point of the query ordered by distance
+func (r *TreeRepository) GetNearby(ctx context.Context, query *entities.NearbyTreeQuery) ([]*entities.NearbyTree, error) {
+	rows, err := r.store.GetNearbyTrees(ctx, &sqlc.GetNearbyTreesParams{
+		Latitude:  query.Latitude,
+		Longitude: query.Longitude,
+		RowLimit:  query.Limit,
+	})
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	nearby := make([]*entities.NearbyTree, len(rows))
+	for i, row := range rows {
+		t := r.mapper.FromSql(&row.Tree)
+		if err := r.mapFields(ctx, t); err != nil {
 			return nil, r.store.HandleError(err)
 		}
+		nearby[i] = &entities.NearbyTree{Tree: t, Distance: row.Distance}
 	}
 
-	return t, nil
+	return nearby, nil
+}
+
+// GetClusterSuggestions groups the trees with DBSCAN in web mercator. As web mercator stretches
+// distances by 1 / cos(latitude), the distance is scaled by the mean latitude of the trees.
+func (r *TreeRepository) GetClusterSuggestions(ctx context.Context, query *entities.TreeClusterSuggestionQuery) ([]*entities.TreeClusterSuggestion, error) {
+	rows, err := r.store.GetTreeClusterSuggestions(ctx, &sqlc.GetTreeClusterSuggestionsParams{
+		RegionID: query.RegionID,
+		Distance: query.Distance,
+		MinSize:  query.MinSize,
+	})
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	suggestions := make([]*entities.TreeClusterSuggestion, len(rows))
+	for i, row := range rows {
+		suggestions[i] = &entities.TreeClusterSuggestion{
+			TreeIDs:   row.TreeIds,
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+		}
+	}
+
+	return suggestions, nil
 }
 
 func (r *TreeRepository) GetByID(ctx context.Context, id int32) (*entities.Tree, error) {
 	row, err := r.store.GetTreeByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrTreeNotFound
+		}
 		return nil, r.store.HandleError(err)
 	}
 
@@ -56,7 +149,8 @@ func (r *TreeRepository) GetCenterPoint(ctx context.Context, ids []int32) (lat,
 		return 0, 0, errors.New("empty geometry")
 	}
 
-	return g.X(), g.Y(), nil
+	// the longitude is the x coordinate
+	return g.Y(), g.X(), nil
 }
 
 func (r *TreeRepository) GetByTreeClusterID(ctx context.Context, id int32) ([]*entities.Tree, error) {
@@ -75,13 +169,36 @@ func (r *TreeRepository) GetByTreeClusterID(ctx context.Context, id int32) ([]*e
 	return t, nil
 }
 
+func (r *TreeRepository) GetBySensorID(ctx context.Context, id int32) (*entities.Tree, error) {
+	row, err := r.store.GetTreeBySensorID(ctx, &id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrTreeNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	t := r.mapper.FromSql(row)
+	if err := r.mapFields(ctx, t); err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return t, nil
+}
+
 func (r *TreeRepository) GetAllImagesByID(ctx context.Context, id int32) ([]*entities.Image, error) {
 	rows, err := r.store.GetAllImagesByTreeID(ctx, id)
 	if err != nil {
 		return nil, r.store.HandleError(err)
 	}
 
-	return r.iMapper.FromSqlList(rows), nil
+	images := make([]*entities.Image, len(rows))
+	for i, row := range rows {
+		images[i] = r.iMapper.FromSql(&row.Image)
+		images[i].LocationMismatch = row.LocationMismatch
+	}
+
+	return images, nil
 }
 
 func (r *TreeRepository) GetSensorByTreeID(ctx context.Context, flowerbedID int32) (*entities.Sensor, error) {
@@ -153,8 +270,40 @@ func mapSensor(ctx context.Context, r *TreeRepository, t *entities.Tree) error {
 func mapTreeCluster(ctx context.Context, r *TreeRepository, t *entities.Tree) error {
 	treeCluster, err := r.GetTreeClusterByTreeID(ctx, t.ID)
 	if err != nil {
+		if errors.Is(err, storage.ErrTreeClusterNotFound) {
+			// If tree is not linked to a tree cluster, set tree cluster to nil
+			t.TreeCluster = nil
+			return nil
+		}
 		return r.store.HandleError(err)
 	}
 	t.TreeCluster = treeCluster
 	return nil
 }
+
+// GetByTreeNumbers returns all trees with one of the given tree numbers. To keep bulk lookups cheap
+// images and sensor are not loaded and the tree cluster only carries its id.
+func (r *TreeRepository) GetByTreeNumbers(ctx context.Context, numbers []int32) ([]*entities.Tree, error) {
+	rows, err := r.store.GetTreesByTreeNumbers(ctx, numbers)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	t := r.mapper.FromSqlList(rows)
+	for i, row := range rows {
+		if row.TreeClusterID != nil {
+			t[i].TreeCluster = &entities.TreeCluster{ID: *row.TreeClusterID}
+		}
+	}
+
+	return t, nil
+}
+
+func (r *TreeRepository) GetTreeClusterIDs(ctx context.Context, treeIDs []int32) ([]int32, error) {
+	ids, err := r.store.GetTreeClusterIDsOfTrees(ctx, treeIDs)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return ids, nil
+}
@@ -3,6 +3,7 @@ package tree
 import (
 	"context"
 
+	"github.com/green-ecolution/green-ecolution-backend/config"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
@@ -13,6 +14,7 @@ import (
 
 type TreeRepository struct {
 	store *store.Store
+	hull  config.HullConfig
 	TreeMappers
 }
 
@@ -37,10 +39,11 @@ func NewTreeRepositoryMappers(
 	}
 }
 
-func NewTreeRepository(s *store.Store, mappers TreeMappers) storage.TreeRepository {
+func NewTreeRepository(s *store.Store, mappers TreeMappers, hull *config.HullConfig) storage.TreeRepository {
 	s.SetEntityType(store.Tree)
 	return &TreeRepository{
 		store:       s,
+		hull:        *hull,
 		TreeMappers: mappers,
 	}
 }
@@ -106,33 +109,37 @@ func WithImages(images []*entities.Image) entities.EntityFunc[entities.Tree] {
 }
 
 func (r *TreeRepository) Delete(ctx context.Context, id int32) error {
-	images, err := r.GetAllImagesByID(ctx, id)
-	if err != nil {
-		return r.store.HandleError(errors.Wrap(err, "failed to get images"))
-	}
-
-	for _, img := range images {
-		args := sqlc.UnlinkTreeImageParams{
-			TreeID:  id,
-			ImageID: img.ID,
-		}
-		if err = r.store.UnlinkTreeImage(ctx, &args); err != nil {
-			return r.store.HandleError(errors.Wrap(err, "failed to unlink image"))
+	return r.store.WithTx(ctx, func(ctx context.Context) error {
+		images, err := r.GetAllImagesByID(ctx, id)
+		if err != nil {
+			return r.store.HandleError(errors.Wrap(err, "failed to get images"))
 		}
 
-		if err = r.store.DeleteImage(ctx, img.ID); err != nil {
-			return r.store.HandleError(errors.Wrap(err, "failed to delete image"))
+		for _, img := range images {
+			args := sqlc.UnlinkTreeImageParams{
+				TreeID:  id,
+				ImageID: img.ID,
+			}
+			if err = r.store.UnlinkTreeImage(ctx, &args); err != nil {
+				return r.store.HandleError(errors.Wrap(err, "failed to unlink image"))
+			}
+
+			if err = r.store.DeleteImage(ctx, img.ID); err != nil {
+				return r.store.HandleError(errors.Wrap(err, "failed to delete image"))
+			}
 		}
-	}
-	return r.store.DeleteTree(ctx, id)
+		return r.store.DeleteTree(ctx, id)
+	})
 }
 
 func (r *TreeRepository) DeleteAndUnlinkImages(ctx context.Context, id int32) error {
-	if err := r.UnlinkAllImages(ctx, id); err != nil {
-		return r.store.HandleError(errors.Wrap(err, "failed to unlink images"))
-	}
+	return r.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.UnlinkAllImages(ctx, id); err != nil {
+			return r.store.HandleError(errors.Wrap(err, "failed to unlink images"))
+		}
 
-	return r.Delete(ctx, id)
+		return r.Delete(ctx, id)
+	})
 }
 
 func (r *TreeRepository) UnlinkImage(ctx context.Context, treeID, imageID int32) error {
@@ -148,5 +155,25 @@ func (r *TreeRepository) UnlinkAllImages(ctx context.Context, treeID int32) erro
 }
 
 func (r *TreeRepository) UnlinkTreeClusterID(ctx context.Context, treeClusterID int32) error {
-	return r.store.UnlinkTreeClusterID(ctx, &treeClusterID)
+	if err := r.store.UnlinkTreeClusterID(ctx, &treeClusterID); err != nil {
+		return err
+	}
+
+	return r.UpdateTreeClusterHulls(ctx, []int32{treeClusterID})
+}
+
+// UpdateTreeClusterHulls recalculates the area covered by the tree clusters from their trees.
+// Tree clusters without trees have no hull.
+func (r *TreeRepository) UpdateTreeClusterHulls(ctx context.Context, treeClusterIDs []int32) error {
+	if len(treeClusterIDs) == 0 {
+		return nil
+	}
+
+	args := sqlc.UpdateTreeClusterHullsParams{
+		Concavity: r.hull.Concavity,
+		Buffer:    r.hull.Buffer,
+		Ids:       treeClusterIDs,
+	}
+
+	return r.store.UpdateTreeClusterHulls(ctx, &args)
 }
@@ -27,39 +27,99 @@ func (r *TreeRepository) Update(ctx context.Context, id int32, tFn ...entities.E
 }
 
 func (r *TreeRepository) UpdateWithImages(ctx context.Context, id int32, tFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error) {
-	t, err := r.Update(ctx, id, tFn...)
-	if err != nil {
-		return nil, err
-	}
+	err := r.store.WithTx(ctx, func(ctx context.Context) error {
+		t, err := r.Update(ctx, id, tFn...)
+		if err != nil {
+			return err
+		}
 
-	if err := r.updateImages(ctx, t); err != nil {
+		return r.updateImages(ctx, t)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return r.GetByID(ctx, id)
 }
 
+// UpdateTreeClusterID links the trees to the tree cluster and updates the hull of the tree cluster
+// and of the tree clusters the trees were linked to before
 func (r *TreeRepository) UpdateTreeClusterID(ctx context.Context, treeIDs []int32, treeClusterID *int32) error {
+	treeClusterIDs, err := r.store.GetTreeClusterIDsOfTrees(ctx, treeIDs)
+	if err != nil {
+		return err
+	}
+
 	args := &sqlc.UpdateTreeClusterIDParams{
 		Column1:       treeIDs,
 		TreeClusterID: treeClusterID,
 	}
 
-	return r.store.UpdateTreeClusterID(ctx, args)
+	if err := r.store.UpdateTreeClusterID(ctx, args); err != nil {
+		return err
+	}
+
+	if treeClusterID != nil {
+		treeClusterIDs = append(treeClusterIDs, *treeClusterID)
+	}
+
+	return r.UpdateTreeClusterHulls(ctx, treeClusterIDs)
+}
+
+// UpdateBatch updates the attributes of the trees with a single statement. Tree number, tree cluster,
+// sensor and images of the trees are left unchanged.
+func (r *TreeRepository) UpdateBatch(ctx context.Context, trees []*entities.Tree) error {
+	args := sqlc.UpdateTreesParams{
+		Ids:                  make([]int32, len(trees)),
+		Ages:                 make([]int32, len(trees)),
+		HeightsAboveSeaLevel: make([]float64, len(trees)),
+		PlantingYears:        make([]int32, len(trees)),
+		Species:              make([]string, len(trees)),
+		Latitudes:            make([]float64, len(trees)),
+		Longitudes:           make([]float64, len(trees)),
+		Geometries:           make([]string, len(trees)),
+	}
+	for i, t := range trees {
+		location, err := coordinate(t)
+		if err != nil {
+			return err
+		}
+
+		args.Ids[i] = t.ID
+		args.Ages[i] = t.Age
+		args.HeightsAboveSeaLevel[i] = t.HeightAboveSeaLevel
+		args.PlantingYears[i] = t.PlantingYear
+		args.Species[i] = t.Species
+		args.Latitudes[i] = t.Latitude
+		args.Longitudes[i] = t.Longitude
+		args.Geometries[i] = location.WKT()
+	}
+
+	if err := r.store.UpdateTrees(ctx, &args); err != nil {
+		return r.store.HandleError(err)
+	}
+
+	return nil
 }
 
 func (r *TreeRepository) updateEntity(ctx context.Context, t *entities.Tree) error {
+	location, err := coordinate(t)
+	if err != nil {
+		return err
+	}
+
 	args := sqlc.UpdateTreeParams{
 		ID:                  t.ID,
 		Species:             t.Species,
 		Age:                 t.Age,
 		HeightAboveSeaLevel: t.HeightAboveSeaLevel,
-		SensorID:            &t.Sensor.ID,
+		SensorID:            sensorID(t),
 		PlantingYear:        t.PlantingYear,
 		Latitude:            t.Latitude,
 		Longitude:           t.Longitude,
 		TreeNumber:          t.Number,
-		TreeClusterID:       &t.TreeCluster.ID,
+		TreeClusterID:       treeClusterID(t),
+		StGeomfromtext:      location.WKT(),
 	}
 
 	return r.store.UpdateTree(ctx, &args)
@@ -71,10 +131,21 @@ func (r *TreeRepository) updateImages(ctx context.Context, tree *entities.Tree)
 	}
 
 	for _, img := range tree.Images {
-		if r.linkImages(ctx, tree.ID, img.ID) != nil {
+		if r.linkImage(ctx, tree.ID, img) != nil {
 			return errors.New("error linking image")
 		}
 	}
 
 	return nil
 }
+
+// UpdateImageLocationMismatch flags the link between the tree and the image if the image was taken far away from the tree
+func (r *TreeRepository) UpdateImageLocationMismatch(ctx context.Context, id, imageID int32, mismatch bool) error {
+	params := sqlc.UpdateTreeImageLocationMismatchParams{
+		TreeID:           id,
+		ImageID:          imageID,
+		LocationMismatch: mismatch,
+	}
+
+	return r.store.UpdateTreeImageLocationMismatch(ctx, &params)
+}
@@ -2,8 +2,10 @@ package treecluster
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 )
 
@@ -60,16 +62,28 @@ func (r *TreeClusterRepository) createEntity(ctx context.Context, entity *entiti
 		return -1, err
 	}
 
-	if entity.Latitude != nil && entity.Longitude != nil {
-		err = r.store.SetTreeClusterLocation(ctx, &sqlc.SetTreeClusterLocationParams{
-			ID:        id,
-			Latitude:  entity.Latitude,
-			Longitude: entity.Longitude,
-		})
-		if err != nil {
-			return -1, err
-		}
+	if err := r.setLocation(ctx, id, entity); err != nil {
+		return -1, err
 	}
 
 	return id, nil
 }
+
+// setLocation stores the location of the tree cluster if it is known and removes it otherwise
+func (r *TreeClusterRepository) setLocation(ctx context.Context, id int32, tc *entities.TreeCluster) error {
+	if tc.Latitude == nil || tc.Longitude == nil {
+		return r.store.RemoveTreeClusterLocation(ctx, id)
+	}
+
+	location, err := entities.NewCoordinate(*tc.Latitude, *tc.Longitude)
+	if err != nil {
+		return fmt.Errorf("%w: %w", storage.ErrInvalidGeometry, err)
+	}
+
+	return r.store.SetTreeClusterLocation(ctx, &sqlc.SetTreeClusterLocationParams{
+		ID:             id,
+		Latitude:       &location.Latitude,
+		Longitude:      &location.Longitude,
+		StGeomfromtext: location.WKT(),
+	})
+}
@@ -3,37 +3,78 @@ package treecluster
 import (
 	"context"
 	"errors"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	"github.com/jackc/pgx/v5"
 )
 
-func (r *TreeClusterRepository) GetAll(ctx context.Context) ([]*entities.TreeCluster, error) {
-	rows, err := r.store.GetAllTreeClusters(ctx)
+func (r *TreeClusterRepository) GetAll(ctx context.Context, query entities.TreeClusterQuery) ([]*entities.TreeCluster, int64, error) {
+	sortBy, desc, err := store.SortParams(query.Query, "name", "created_at", "updated_at")
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, 0, err
+	}
+
+	spatial := store.SpatialFilterParams(query.SpatialFilter)
+	wateringStatus := (*string)(query.WateringStatus)
+	total, err := r.store.CountTreeClusters(ctx, &sqlc.CountTreeClustersParams{
+		MinLongitude:    spatial.MinLongitude,
+		MinLatitude:     spatial.MinLatitude,
+		MaxLongitude:    spatial.MaxLongitude,
+		MaxLatitude:     spatial.MaxLatitude,
+		Radius:          spatial.Radius,
+		CenterLongitude: spatial.CenterLongitude,
+		CenterLatitude:  spatial.CenterLatitude,
+		Area:            spatial.Area,
+		WateringStatus:  wateringStatus,
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllTreeClusters(ctx, &sqlc.GetAllTreeClustersParams{
+		MinLongitude:    spatial.MinLongitude,
+		MinLatitude:     spatial.MinLatitude,
+		MaxLongitude:    spatial.MaxLongitude,
+		MaxLatitude:     spatial.MaxLatitude,
+		Radius:          spatial.Radius,
+		CenterLongitude: spatial.CenterLongitude,
+		CenterLatitude:  spatial.CenterLatitude,
+		Area:            spatial.Area,
+		WateringStatus:  wateringStatus,
+		SortBy:          sortBy,
+		SortDesc:        desc,
+		RowOffset:       query.Offset(),
+		RowLimit:        store.LimitParam(query.Query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
 	}
 
 	data := r.mapper.FromSqlList(rows)
 	for _, f := range data {
 		f.Region, err = r.GetRegionByTreeClusterID(ctx, f.ID)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		f.Trees, err = r.GetLinkedTreesByTreeClusterID(ctx, f.ID)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 
-	return data, nil
+	return data, total, nil
 }
 
 func (r *TreeClusterRepository) GetByID(ctx context.Context, id int32) (*entities.TreeCluster, error) {
 	row, err := r.store.GetTreeClusterByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrTreeClusterNotFound
+		}
 		return nil, r.store.HandleError(err)
 	}
 
@@ -86,3 +127,24 @@ func (r *TreeClusterRepository) GetLinkedTreesByTreeClusterID(ctx context.Contex
 
 	return r.treeMapper.FromSqlList(rows), nil
 }
+
+// GetByRegionOrArea returns the tree clusters linked to the region or located inside the area.
+// The trees are not loaded and the region only carries its id.
+func (r *TreeClusterRepository) GetByRegionOrArea(ctx context.Context, regionID int32, area entities.MultiPolygon) ([]*entities.TreeCluster, error) {
+	rows, err := r.store.GetTreeClustersByRegionOrArea(ctx, &sqlc.GetTreeClustersByRegionOrAreaParams{
+		RegionID: regionID,
+		Wkt:      area.WKT(),
+	})
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	data := r.mapper.FromSqlList(rows)
+	for i, row := range rows {
+		if row.RegionID != nil {
+			data[i].Region = &entities.Region{ID: *row.RegionID}
+		}
+	}
+
+	return data, nil
+}
@@ -27,9 +27,15 @@ func (r *TreeClusterRepository) Update(ctx context.Context, id int32, tcFn ...en
 }
 
 func (r *TreeClusterRepository) updateEntity(ctx context.Context, tc *entities.TreeCluster) error {
+	var region *int32
+	if tc.Region != nil {
+		region = &tc.Region.ID
+	}
+
 	args := sqlc.UpdateTreeClusterParams{
 		ID:             tc.ID,
-		RegionID:       &tc.Region.ID,
+		Name:           tc.Name,
+		RegionID:       region,
 		Address:        tc.Address,
 		Description:    tc.Description,
 		MoistureLevel:  tc.MoistureLevel,
@@ -39,5 +45,9 @@ func (r *TreeClusterRepository) updateEntity(ctx context.Context, tc *entities.T
 		Archived:       tc.Archived,
 	}
 
-	return r.store.UpdateTreeCluster(ctx, &args)
+	if err := r.store.UpdateTreeCluster(ctx, &args); err != nil {
+		return err
+	}
+
+	return r.setLocation(ctx, tc.ID, tc)
 }
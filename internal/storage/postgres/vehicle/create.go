@@ -23,7 +23,7 @@ func (r *VehicleRepository) Create(ctx context.Context, vFn ...entities.EntityFu
 
 	id, err := r.createEntity(ctx, entity)
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, err
 	}
 
 	entity.ID = *id
@@ -2,23 +2,48 @@ package vehicle
 
 import (
 	"context"
+	"errors"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
 
+	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5"
 )
 
-func (r *VehicleRepository) GetAll(ctx context.Context) ([]*entities.Vehicle, error) {
-	rows, err := r.store.GetAllVehicles(ctx)
+func (r *VehicleRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.Vehicle, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "number_plate", "water_capacity", "created_at", "updated_at")
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return r.mapper.FromSqlList(rows), nil
+	total, err := r.store.CountVehicles(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllVehicles(ctx, &sqlc.GetAllVehiclesParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), total, nil
 }
 
 func (r *VehicleRepository) GetByID(ctx context.Context, id int32) (*entities.Vehicle, error) {
 	row, err := r.store.GetVehicleByID(ctx, id)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrVehicleNotFound
+		}
+		return nil, r.store.HandleError(err)
 	}
 
 	return r.mapper.FromSql(row), nil
@@ -27,8 +52,34 @@ func (r *VehicleRepository) GetByID(ctx context.Context, id int32) (*entities.Ve
 func (r *VehicleRepository) GetByPlate(ctx context.Context, plate string) (*entities.Vehicle, error) {
 	row, err := r.store.GetVehicleByPlate(ctx, plate)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrVehicleNotFound
+		}
+		return nil, r.store.HandleError(err)
 	}
 
 	return r.mapper.FromSql(row), nil
 }
+
+func (r *VehicleRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Vehicle, error) {
+	rows, err := r.store.GetVehiclesByUserID(ctx, utils.UUIDToPgUUID(userID))
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), nil
+}
+
+func (r *VehicleRepository) GetUserIDs(ctx context.Context, id int32) ([]uuid.UUID, error) {
+	rows, err := r.store.GetUserIDsByVehicleID(ctx, id)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	userIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		userIDs[i] = utils.PgUUIDToUUID(row)
+	}
+
+	return userIDs, nil
+}
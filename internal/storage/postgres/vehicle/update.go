@@ -10,7 +10,7 @@ import (
 func (r *VehicleRepository) Update(ctx context.Context, id int32, vFn ...entities.EntityFunc[entities.Vehicle]) (*entities.Vehicle, error) {
 	entity, err := r.GetByID(ctx, id)
 	if err != nil {
-		return nil, r.store.HandleError(err)
+		return nil, err
 	}
 
 	for _, fn := range vFn {
@@ -18,7 +18,7 @@ func (r *VehicleRepository) Update(ctx context.Context, id int32, vFn ...entitie
 	}
 
 	if err := r.updateEntity(ctx, entity); err != nil {
-		return nil, err
+		return nil, r.store.HandleError(err)
 	}
 
 	return r.GetByID(ctx, entity.ID)
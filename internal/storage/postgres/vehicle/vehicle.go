@@ -4,8 +4,11 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
 
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
 	store "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
@@ -55,6 +58,30 @@ func WithWaterCapacity(waterCapacity float64) entities.EntityFunc[entities.Vehic
 	}
 }
 
+func (r *VehicleRepository) AssignToUser(ctx context.Context, id int32, userID uuid.UUID) error {
+	args := sqlc.AssignVehicleToUserParams{
+		UserID:    utils.UUIDToPgUUID(userID),
+		VehicleID: id,
+	}
+
+	return r.store.HandleError(r.store.AssignVehicleToUser(ctx, &args))
+}
+
+func (r *VehicleRepository) UnassignFromUser(ctx context.Context, id int32, userID uuid.UUID) error {
+	args := sqlc.UnassignVehicleFromUserParams{
+		UserID:    utils.UUIDToPgUUID(userID),
+		VehicleID: id,
+	}
+
+	return r.store.HandleError(r.store.UnassignVehicleFromUser(ctx, &args))
+}
+
 func (r *VehicleRepository) Delete(ctx context.Context, id int32) error {
-	return r.store.DeleteVehicle(ctx, id)
+	return r.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.store.UnassignAllUsersFromVehicle(ctx, id); err != nil {
+			return r.store.HandleError(err)
+		}
+
+		return r.store.HandleError(r.store.DeleteVehicle(ctx, id))
+	})
 }
@@ -0,0 +1,41 @@
+package wateringevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultWateringEvent() *entities.WateringEvent {
+	return &entities.WateringEvent{
+		VehicleID:   nil,
+		UserID:      nil,
+		WateredAt:   time.Now(),
+		WaterAmount: 0,
+	}
+}
+
+func (r *WateringEventRepository) Create(ctx context.Context, weFn ...entities.EntityFunc[entities.WateringEvent]) (*entities.WateringEvent, error) {
+	entity := defaultWateringEvent()
+	for _, fn := range weFn {
+		fn(entity)
+	}
+
+	args := sqlc.CreateWateringEventParams{
+		TreeClusterID: entity.TreeClusterID,
+		VehicleID:     entity.VehicleID,
+		UserID:        utils.UUIDPtrToPgUUID(entity.UserID),
+		WateredAt:     utils.TimeToPgTimestamp(&entity.WateredAt),
+		WaterAmount:   entity.WaterAmount,
+	}
+
+	id, err := r.store.CreateWateringEvent(ctx, &args)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.GetByID(ctx, id)
+}
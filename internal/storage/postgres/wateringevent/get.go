@@ -0,0 +1,72 @@
+package wateringevent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *WateringEventRepository) GetAll(ctx context.Context, query entities.WateringEventQuery) ([]*entities.WateringEvent, int64, error) {
+	sortBy, desc, err := store.SortParams(query.Query, "watered_at", "water_amount", "created_at")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.store.CountWateringEvents(ctx, &sqlc.CountWateringEventsParams{
+		TreeClusterID: query.TreeClusterID,
+		VehicleID:     query.VehicleID,
+		WateredFrom:   utils.TimeToPgTimestamp(query.From),
+		WateredTo:     utils.TimeToPgTimestamp(query.To),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllWateringEvents(ctx, &sqlc.GetAllWateringEventsParams{
+		TreeClusterID: query.TreeClusterID,
+		VehicleID:     query.VehicleID,
+		WateredFrom:   utils.TimeToPgTimestamp(query.From),
+		WateredTo:     utils.TimeToPgTimestamp(query.To),
+		SortBy:        sortBy,
+		SortDesc:      desc,
+		RowOffset:     query.Offset(),
+		RowLimit:      store.LimitParam(query.Query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSqlList(rows), total, nil
+}
+
+func (r *WateringEventRepository) GetByID(ctx context.Context, id int32) (*entities.WateringEvent, error) {
+	row, err := r.store.GetWateringEventByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWateringEventNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapper.FromSql(row), nil
+}
+
+func (r *WateringEventRepository) GetTotalWaterAmount(ctx context.Context, query entities.WateringEventQuery) (float64, error) {
+	total, err := r.store.SumWateringEventsWaterAmount(ctx, &sqlc.SumWateringEventsWaterAmountParams{
+		TreeClusterID: query.TreeClusterID,
+		VehicleID:     query.VehicleID,
+		WateredFrom:   utils.TimeToPgTimestamp(query.From),
+		WateredTo:     utils.TimeToPgTimestamp(query.To),
+	})
+	if err != nil {
+		return 0, r.store.HandleError(err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,74 @@
+package wateringevent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+)
+
+type WateringEventRepository struct {
+	store *store.Store
+	WateringEventRepositoryMappers
+}
+
+type WateringEventRepositoryMappers struct {
+	mapper mapper.InternalWateringEventRepoMapper
+}
+
+func NewWateringEventRepositoryMappers(weMapper mapper.InternalWateringEventRepoMapper) WateringEventRepositoryMappers {
+	return WateringEventRepositoryMappers{
+		mapper: weMapper,
+	}
+}
+
+func NewWateringEventRepository(s *store.Store, mappers WateringEventRepositoryMappers) storage.WateringEventRepository {
+	return &WateringEventRepository{
+		store:                          s,
+		WateringEventRepositoryMappers: mappers,
+	}
+}
+
+func WithTreeClusterID(treeClusterID int32) entities.EntityFunc[entities.WateringEvent] {
+	return func(we *entities.WateringEvent) {
+		slog.Debug("updating tree cluster id", "tree cluster id", treeClusterID)
+		we.TreeClusterID = treeClusterID
+	}
+}
+
+func WithVehicleID(vehicleID *int32) entities.EntityFunc[entities.WateringEvent] {
+	return func(we *entities.WateringEvent) {
+		slog.Debug("updating vehicle id", "vehicle id", vehicleID)
+		we.VehicleID = vehicleID
+	}
+}
+
+func WithUserID(userID *uuid.UUID) entities.EntityFunc[entities.WateringEvent] {
+	return func(we *entities.WateringEvent) {
+		slog.Debug("updating user id", "user id", userID)
+		we.UserID = userID
+	}
+}
+
+func WithWateredAt(wateredAt time.Time) entities.EntityFunc[entities.WateringEvent] {
+	return func(we *entities.WateringEvent) {
+		slog.Debug("updating watered at", "watered at", wateredAt)
+		we.WateredAt = wateredAt
+	}
+}
+
+func WithWaterAmount(waterAmount float64) entities.EntityFunc[entities.WateringEvent] {
+	return func(we *entities.WateringEvent) {
+		slog.Debug("updating water amount", "water amount", waterAmount)
+		we.WaterAmount = waterAmount
+	}
+}
+
+func (r *WateringEventRepository) Delete(ctx context.Context, id int32) error {
+	return r.store.HandleError(r.store.DeleteWateringEvent(ctx, id))
+}
@@ -0,0 +1,60 @@
+package wateringplan
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func defaultWateringPlan() *entities.WateringPlan {
+	return &entities.WateringPlan{
+		Description:        "",
+		Status:             entities.WateringPlanStatusPlanned,
+		TotalWaterRequired: 0,
+		Vehicle:            nil,
+		UserIDs:            make([]uuid.UUID, 0),
+		TreeClusters:       make([]*entities.TreeCluster, 0),
+	}
+}
+
+func (r *WateringPlanRepository) Create(ctx context.Context, wpFn ...entities.EntityFunc[entities.WateringPlan]) (*entities.WateringPlan, error) {
+	entity := defaultWateringPlan()
+	for _, fn := range wpFn {
+		fn(entity)
+	}
+
+	err := r.store.WithTx(ctx, func(ctx context.Context) error {
+		id, err := r.createEntity(ctx, entity)
+		if err != nil {
+			return err
+		}
+
+		entity.ID = *id
+		return r.setLinks(ctx, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, entity.ID)
+}
+
+func (r *WateringPlanRepository) createEntity(ctx context.Context, entity *entities.WateringPlan) (*int32, error) {
+	args := sqlc.CreateWateringPlanParams{
+		Date:               utils.TimeToPgDate(entity.Date),
+		Description:        entity.Description,
+		Status:             sqlc.WateringPlanStatus(entity.Status),
+		TotalWaterRequired: entity.TotalWaterRequired,
+		VehicleID:          vehicleID(entity),
+	}
+
+	id, err := r.store.CreateWateringPlan(ctx, &args)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,89 @@
+package wateringplan
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *WateringPlanRepository) GetAll(ctx context.Context, query entities.Query) ([]*entities.WateringPlan, int64, error) {
+	sortBy, desc, err := store.SortParams(query, "date", "status", "created_at", "updated_at")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.store.CountWateringPlans(ctx)
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	rows, err := r.store.GetAllWateringPlans(ctx, &sqlc.GetAllWateringPlansParams{
+		SortBy:    sortBy,
+		SortDesc:  desc,
+		RowOffset: query.Offset(),
+		RowLimit:  store.LimitParam(query),
+	})
+	if err != nil {
+		return nil, 0, r.store.HandleError(err)
+	}
+
+	data := make([]*entities.WateringPlan, len(rows))
+	for i, row := range rows {
+		data[i], err = r.mapWithLinks(ctx, row)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return data, total, nil
+}
+
+func (r *WateringPlanRepository) GetByID(ctx context.Context, id int32) (*entities.WateringPlan, error) {
+	row, err := r.store.GetWateringPlanByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWateringPlanNotFound
+		}
+		return nil, r.store.HandleError(err)
+	}
+
+	return r.mapWithLinks(ctx, row)
+}
+
+// mapWithLinks maps a plan and loads its vehicle, users and ordered tree clusters
+func (r *WateringPlanRepository) mapWithLinks(ctx context.Context, row *sqlc.WateringPlan) (*entities.WateringPlan, error) {
+	entity := r.mapper.FromSql(row)
+
+	if row.VehicleID != nil {
+		vehicle, err := r.store.GetVehicleByID(ctx, *row.VehicleID)
+		if err != nil {
+			return nil, r.store.HandleError(err)
+		}
+		entity.Vehicle = r.vehicleMapper.FromSql(vehicle)
+	}
+
+	userIDs, err := r.store.GetUserIDsByWateringPlanID(ctx, row.ID)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+
+	entity.UserIDs = make([]uuid.UUID, len(userIDs))
+	for i, userID := range userIDs {
+		entity.UserIDs[i] = utils.PgUUIDToUUID(userID)
+	}
+
+	treeClusters, err := r.store.GetTreeClustersByWateringPlanID(ctx, row.ID)
+	if err != nil {
+		return nil, r.store.HandleError(err)
+	}
+	entity.TreeClusters = r.treeClusterMapper.FromSqlList(treeClusters)
+
+	return entity, nil
+}
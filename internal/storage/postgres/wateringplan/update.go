@@ -0,0 +1,46 @@
+package wateringplan
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+func (r *WateringPlanRepository) Update(ctx context.Context, id int32, wpFn ...entities.EntityFunc[entities.WateringPlan]) (*entities.WateringPlan, error) {
+	entity, err := r.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range wpFn {
+		fn(entity)
+	}
+
+	err = r.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := r.updateEntity(ctx, entity); err != nil {
+			return r.store.HandleError(err)
+		}
+
+		return r.setLinks(ctx, entity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetByID(ctx, entity.ID)
+}
+
+func (r *WateringPlanRepository) updateEntity(ctx context.Context, entity *entities.WateringPlan) error {
+	params := sqlc.UpdateWateringPlanParams{
+		ID:                 entity.ID,
+		Date:               utils.TimeToPgDate(entity.Date),
+		Description:        entity.Description,
+		Status:             sqlc.WateringPlanStatus(entity.Status),
+		TotalWaterRequired: entity.TotalWaterRequired,
+		VehicleID:          vehicleID(entity),
+	}
+
+	return r.store.UpdateWateringPlan(ctx, &params)
+}
@@ -0,0 +1,141 @@
+package wateringplan
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/mapper"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/store"
+	"github.com/green-ecolution/green-ecolution-backend/internal/utils"
+)
+
+type WateringPlanRepository struct {
+	store *store.Store
+	WateringPlanRepositoryMappers
+}
+
+type WateringPlanRepositoryMappers struct {
+	mapper            mapper.InternalWateringPlanRepoMapper
+	vehicleMapper     mapper.InternalVehicleRepoMapper
+	treeClusterMapper mapper.InternalTreeClusterRepoMapper
+}
+
+func NewWateringPlanRepositoryMappers(
+	wpMapper mapper.InternalWateringPlanRepoMapper,
+	vMapper mapper.InternalVehicleRepoMapper,
+	tcMapper mapper.InternalTreeClusterRepoMapper,
+) WateringPlanRepositoryMappers {
+	return WateringPlanRepositoryMappers{
+		mapper:            wpMapper,
+		vehicleMapper:     vMapper,
+		treeClusterMapper: tcMapper,
+	}
+}
+
+func NewWateringPlanRepository(s *store.Store, mappers WateringPlanRepositoryMappers) storage.WateringPlanRepository {
+	return &WateringPlanRepository{
+		store:                         s,
+		WateringPlanRepositoryMappers: mappers,
+	}
+}
+
+func WithDate(date time.Time) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating date", "date", date)
+		wp.Date = date
+	}
+}
+
+func WithDescription(description string) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating description", "description", description)
+		wp.Description = description
+	}
+}
+
+func WithStatus(status entities.WateringPlanStatus) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating status", "status", status)
+		wp.Status = status
+	}
+}
+
+func WithTotalWaterRequired(water float64) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating total water required", "water", water)
+		wp.TotalWaterRequired = water
+	}
+}
+
+func WithVehicle(vehicle *entities.Vehicle) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating vehicle", "vehicle", vehicle)
+		wp.Vehicle = vehicle
+	}
+}
+
+func WithUserIDs(userIDs []uuid.UUID) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating users", "users", userIDs)
+		wp.UserIDs = userIDs
+	}
+}
+
+// WithTreeClusters sets the tree clusters of the plan, they are watered in the order of the list
+func WithTreeClusters(treeClusters []*entities.TreeCluster) entities.EntityFunc[entities.WateringPlan] {
+	return func(wp *entities.WateringPlan) {
+		slog.Debug("updating tree clusters", "tree clusters", len(treeClusters))
+		wp.TreeClusters = treeClusters
+	}
+}
+
+func (r *WateringPlanRepository) Delete(ctx context.Context, id int32) error {
+	return r.store.HandleError(r.store.DeleteWateringPlan(ctx, id))
+}
+
+// setLinks replaces the users and the ordered tree clusters of a plan
+func (r *WateringPlanRepository) setLinks(ctx context.Context, entity *entities.WateringPlan) error {
+	if err := r.store.RemoveAllUsersFromWateringPlan(ctx, entity.ID); err != nil {
+		return r.store.HandleError(err)
+	}
+
+	for _, userID := range entity.UserIDs {
+		args := sqlc.AddUserToWateringPlanParams{
+			WateringPlanID: entity.ID,
+			UserID:         utils.UUIDToPgUUID(userID),
+		}
+		if err := r.store.AddUserToWateringPlan(ctx, &args); err != nil {
+			return r.store.HandleError(err)
+		}
+	}
+
+	if err := r.store.RemoveAllTreeClustersFromWateringPlan(ctx, entity.ID); err != nil {
+		return r.store.HandleError(err)
+	}
+
+	for i, tc := range entity.TreeClusters {
+		args := sqlc.AddTreeClusterToWateringPlanParams{
+			WateringPlanID: entity.ID,
+			TreeClusterID:  tc.ID,
+			Position:       int32(i),
+		}
+		if err := r.store.AddTreeClusterToWateringPlan(ctx, &args); err != nil {
+			return r.store.HandleError(err)
+		}
+	}
+
+	return nil
+}
+
+func vehicleID(entity *entities.WateringPlan) *int32 {
+	if entity.Vehicle == nil {
+		return nil
+	}
+
+	return &entity.Vehicle.ID
+}
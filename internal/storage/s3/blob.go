@@ -0,0 +1,157 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+var ErrMissingConfig = errors.New("s3 endpoint and bucket are required")
+
+// BlobRepository stores objects in a bucket of an S3 compatible object storage (AWS S3, MinIO, ...).
+// Objects are addressed path-style (<endpoint>/<bucket>/<key>) as this is supported by all implementations.
+type BlobRepository struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	publicURL string
+	signer    *signer
+}
+
+func NewBlobRepository(cfg *config.S3ObjectStorageConfig) (*BlobRepository, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, ErrMissingConfig
+	}
+
+	endpoint, err := parseEndpoint(cfg.Endpoint, cfg.UseSSL)
+	if err != nil {
+		return nil, err
+	}
+
+	publicURL := strings.TrimSuffix(cfg.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = endpoint.JoinPath(cfg.Bucket).String()
+	}
+
+	return &BlobRepository{
+		client:    &http.Client{Timeout: 60 * time.Second},
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		publicURL: publicURL,
+		signer: &signer{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    cfg.Region,
+		},
+	}, nil
+}
+
+func (r *BlobRepository) Upload(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	req, err := r.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (r *BlobRepository) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := r.newRequest(ctx, http.MethodGet, key, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (r *BlobRepository) Delete(ctx context.Context, key string) error {
+	req, err := r.newRequest(ctx, http.MethodDelete, key, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.do(req)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (r *BlobRepository) URL(key string) string {
+	return r.publicURL + "/" + url.PathEscape(key)
+}
+
+func (r *BlobRepository) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, storage.ErrInvalidBlobKey
+	}
+
+	u := r.endpoint.JoinPath(r.bucket, key)
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+// do signs and sends the request. Responses with an error status are closed and returned as error.
+func (r *BlobRepository) do(req *http.Request) (*http.Response, error) {
+	r.signer.sign(req, time.Now())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, storage.ErrBlobNotFound
+		}
+
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 request %s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, msg)
+	}
+
+	return resp, nil
+}
+
+func parseEndpoint(endpoint string, useSSL bool) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		scheme := "http"
+		if useSSL {
+			scheme = "https"
+		}
+		endpoint = scheme + "://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u, nil
+}
@@ -0,0 +1,126 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3 compatible server
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") || r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = data
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestRepo(t *testing.T) (*BlobRepository, *fakeS3) {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	repo, err := NewBlobRepository(&config.S3ObjectStorageConfig{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "images",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	require.NoError(t, err)
+
+	return repo, fake
+}
+
+func TestBlobRepository(t *testing.T) {
+	t.Run("should upload, download and delete object", func(t *testing.T) {
+		// given
+		repo, fake := newTestRepo(t)
+		ctx := context.Background()
+
+		// when
+		err := repo.Upload(ctx, "tree.jpg", "image/jpeg", strings.NewReader("data"), 4)
+		require.NoError(t, err)
+		body, err := repo.Download(ctx, "tree.jpg")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(body)
+		body.Close()
+		err = repo.Delete(ctx, "tree.jpg")
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, "data", string(data))
+		assert.Empty(t, fake.objects)
+	})
+
+	t.Run("should return blob not found for missing object", func(t *testing.T) {
+		// given
+		repo, _ := newTestRepo(t)
+
+		// when
+		body, err := repo.Download(context.Background(), "missing.jpg")
+
+		// then
+		assert.Nil(t, body)
+		assert.ErrorIs(t, err, storage.ErrBlobNotFound)
+	})
+
+	t.Run("should build url from public url", func(t *testing.T) {
+		// given
+		repo, err := NewBlobRepository(&config.S3ObjectStorageConfig{
+			Endpoint:  "localhost:9000",
+			Bucket:    "images",
+			PublicURL: "https://cdn.example.com/images/",
+		})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "https://cdn.example.com/images/tree.jpg", repo.URL("tree.jpg"))
+	})
+
+	t.Run("should default url to endpoint and bucket", func(t *testing.T) {
+		// given
+		repo, err := NewBlobRepository(&config.S3ObjectStorageConfig{
+			Endpoint: "localhost:9000",
+			Bucket:   "images",
+			UseSSL:   true,
+		})
+		require.NoError(t, err)
+
+		// then
+		assert.Equal(t, "https://localhost:9000/images/tree.jpg", repo.URL("tree.jpg"))
+	})
+}
@@ -0,0 +1,17 @@
+package s3
+
+import (
+	"github.com/green-ecolution/green-ecolution-backend/config"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage"
+)
+
+func NewRepository(cfg *config.S3ObjectStorageConfig) (*storage.Repository, error) {
+	blobRepo, err := NewBlobRepository(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.Repository{
+		Blob: blobRepo,
+	}, nil
+}
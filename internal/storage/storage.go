@@ -3,7 +3,9 @@ package storage
 import (
 	"context"
 	"errors"
+	"io"
 
+	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 )
 
@@ -14,15 +16,26 @@ var (
 	ErrHostnameNotFound      = errors.New("cant get hostname")
 	ErrCannotGetAppURL       = errors.New("cannot get app url")
 
-	ErrIDNotFound          = errors.New("entity id not found")
-	ErrIDAlreadyExists     = errors.New("entity id already exists")
-	ErrEntityNotFound      = errors.New("entity not found")
-	ErrSensorNotFound      = errors.New("sensor not found")
-	ErrImageNotFound       = errors.New("image not found")
-	ErrFlowerbedNotFound   = errors.New("flowerbed not found")
-	ErrTreeClusterNotFound = errors.New("treecluster not found")
-	ErrRegionNotFound      = errors.New("region not found")
-	ErrTreeNotFound        = errors.New("tree not found")
+	ErrIDNotFound            = errors.New("entity id not found")
+	ErrIDAlreadyExists       = errors.New("entity id already exists")
+	ErrEntityNotFound        = errors.New("entity not found")
+	ErrSensorNotFound        = errors.New("sensor not found")
+	ErrSensorDataNotFound    = errors.New("sensor data not found")
+	ErrImageNotFound         = errors.New("image not found")
+	ErrFlowerbedNotFound     = errors.New("flowerbed not found")
+	ErrTreeClusterNotFound   = errors.New("treecluster not found")
+	ErrRegionNotFound        = errors.New("region not found")
+	ErrTreeNotFound          = errors.New("tree not found")
+	ErrVehicleNotFound       = errors.New("vehicle not found")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrRoleNotFound          = errors.New("role not found")
+	ErrWateringPlanNotFound  = errors.New("watering plan not found")
+	ErrWateringEventNotFound = errors.New("watering event not found")
+	ErrDuplicateEntity       = errors.New("entity with the same unique key already exists")
+	ErrBlobNotFound          = errors.New("blob not found")
+	ErrInvalidBlobKey        = errors.New("invalid blob key")
+	ErrInvalidSortField      = errors.New("invalid sort field or direction")
+	ErrInvalidGeometry       = errors.New("invalid geometry")
 
 	ErrUnknowError      = errors.New("unknown error")
 	ErrToManyRows       = errors.New("receive more rows then expected")
@@ -32,7 +45,6 @@ var (
 )
 
 type BasicCrudRepository[T entities.Entities] interface {
-	GetAll(ctx context.Context) ([]*T, error)
 	GetByID(ctx context.Context, id int32) (*T, error)
 
 	Create(ctx context.Context, fn ...entities.EntityFunc[T]) (*T, error)
@@ -40,67 +52,155 @@ type BasicCrudRepository[T entities.Entities] interface {
 	Delete(ctx context.Context, id int32) error
 }
 
+// ListRepository returns one page of the entities matching the query Q
+// together with the total number of matching entities
+type ListRepository[T entities.Entities, Q any] interface {
+	GetAll(ctx context.Context, query Q) ([]*T, int64, error)
+}
+
+// UnitOfWork runs several repository calls atomically
+type UnitOfWork interface {
+	// WithTx runs fn in a transaction, which is committed if fn returns nil and rolled back otherwise.
+	// Repository calls are part of the transaction if they are made with the context passed to fn.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type InfoRepository interface {
 	GetAppInfo(context.Context) (*entities.App, error)
 }
 
 type RegionRepository interface {
 	BasicCrudRepository[entities.Region]
+	ListRepository[entities.Region, entities.Query]
 	GetByName(ctx context.Context, name string) (*entities.Region, error)
 	GetByPoint(ctx context.Context, latitude, longitude float64) (*entities.Region, error)
+	CheckGeometry(ctx context.Context, geometry entities.MultiPolygon) error
+	GetOverlapping(ctx context.Context, geometry entities.MultiPolygon, excludeID int32) ([]*entities.Region, error)
+}
+
+// TileRepository renders Mapbox vector tiles of the map layers
+type TileRepository interface {
+	GetTile(ctx context.Context, query *entities.TileQuery) ([]byte, error)
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User, password string, roles *[]string) (*entities.User, error)
+	GetAll(ctx context.Context, query entities.UserQuery) ([]*entities.User, int64, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	GetByAccessToken(ctx context.Context, token string) (*entities.User, error)
+	// Update stores the changed fields of the user, attributes not known to the app are kept
+	Update(ctx context.Context, user *entities.User) (*entities.User, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetRoles(ctx context.Context, id uuid.UUID) ([]*entities.Role, error)
+	AddRoles(ctx context.Context, id uuid.UUID, roles []string) error
+	RemoveRoles(ctx context.Context, id uuid.UUID, roles []string) error
 	RemoveSession(ctx context.Context, token string) error
 }
 
 type RoleRepository interface {
+	GetAll(ctx context.Context) ([]*entities.Role, error)
 	GetByName(ctx context.Context, name string) (*entities.Role, error)
+	GetUsers(ctx context.Context, name string) ([]*entities.User, error)
 }
 
 type ImageRepository interface {
 	BasicCrudRepository[entities.Image]
+	ListRepository[entities.Image, entities.Query]
+	GetByObjectKey(ctx context.Context, key string) (*entities.Image, error)
+}
+
+// BlobRepository stores binary objects like uploaded images under a unique key
+type BlobRepository interface {
+	Upload(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the url under which the object with the given key is reachable
+	URL(key string) string
 }
 
 type VehicleRepository interface {
 	BasicCrudRepository[entities.Vehicle]
+	ListRepository[entities.Vehicle, entities.Query]
 	GetByPlate(ctx context.Context, plate string) (*entities.Vehicle, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Vehicle, error)
+	GetUserIDs(ctx context.Context, id int32) ([]uuid.UUID, error)
+	AssignToUser(ctx context.Context, id int32, userID uuid.UUID) error
+	UnassignFromUser(ctx context.Context, id int32, userID uuid.UUID) error
 }
 
 type TreeClusterRepository interface {
 	BasicCrudRepository[entities.TreeCluster]
+	ListRepository[entities.TreeCluster, entities.TreeClusterQuery]
 	GetSensorByTreeClusterID(ctx context.Context, id int32) (*entities.Sensor, error)
 	Archive(ctx context.Context, id int32) error
+	// GetByRegionOrArea returns the tree clusters linked to the region or located inside the area
+	GetByRegionOrArea(ctx context.Context, regionID int32, area entities.MultiPolygon) ([]*entities.TreeCluster, error)
+}
+
+type WateringPlanRepository interface {
+	BasicCrudRepository[entities.WateringPlan]
+	ListRepository[entities.WateringPlan, entities.Query]
+}
+
+// WateringEventRepository stores the log of waterings. Events are not changed once recorded.
+type WateringEventRepository interface {
+	ListRepository[entities.WateringEvent, entities.WateringEventQuery]
+	GetByID(ctx context.Context, id int32) (*entities.WateringEvent, error)
+	Create(ctx context.Context, fn ...entities.EntityFunc[entities.WateringEvent]) (*entities.WateringEvent, error)
+	Delete(ctx context.Context, id int32) error
+	// GetTotalWaterAmount returns the liters of all events matching the filters of the query
+	GetTotalWaterAmount(ctx context.Context, query entities.WateringEventQuery) (float64, error)
 }
 
 type TreeRepository interface {
 	BasicCrudRepository[entities.Tree]
+	ListRepository[entities.Tree, entities.TreeQuery]
 	GetByTreeClusterID(ctx context.Context, id int32) ([]*entities.Tree, error)
+	GetBySensorID(ctx context.Context, id int32) (*entities.Tree, error)
 	GetAllImagesByID(ctx context.Context, id int32) ([]*entities.Image, error)
 	GetSensorByTreeID(ctx context.Context, id int32) (*entities.Sensor, error)
+	// GetByTreeNumbers returns the trees with one of the given numbers, without images and sensor
+	GetByTreeNumbers(ctx context.Context, numbers []int32) ([]*entities.Tree, error)
+	// GetNearby returns the trees closest to a point ordered by distance
+	GetNearby(ctx context.Context, query *entities.NearbyTreeQuery) ([]*entities.NearbyTree, error)
+	// GetTreeClusterIDs returns the ids of the tree clusters the trees are linked to
+	GetTreeClusterIDs(ctx context.Context, treeIDs []int32) ([]int32, error)
+	// GetClusterSuggestions groups the trees without tree cluster by their location.
+	// The suggestions only have the tree ids and the center of the trees set.
+	GetClusterSuggestions(ctx context.Context, query *entities.TreeClusterSuggestionQuery) ([]*entities.TreeClusterSuggestion, error)
 
 	UpdateWithImages(ctx context.Context, id int32, fFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error)
 	DeleteAndUnlinkImages(ctx context.Context, id int32) error
 	UnlinkAllImages(ctx context.Context, id int32) error
 	UnlinkTreeClusterID(ctx context.Context, treeClusterID int32) error
 	UnlinkImage(ctx context.Context, flowerbedID, imageID int32) error
+	// UpdateImageLocationMismatch flags the link between the tree and the image
+	UpdateImageLocationMismatch(ctx context.Context, id, imageID int32, mismatch bool) error
 	CreateAndLinkImages(ctx context.Context, tcFn ...entities.EntityFunc[entities.Tree]) (*entities.Tree, error)
 	UpdateTreeClusterID(ctx context.Context, treeIDs []int32, treeClusterID *int32) error
+	// UpdateTreeClusterHulls recalculates the area covered by the tree clusters from their trees
+	UpdateTreeClusterHulls(ctx context.Context, treeClusterIDs []int32) error
 	GetCenterPoint(ctx context.Context, id []int32) (float64, float64, error)
+	// CreateBatch and UpdateBatch write many trees at once, e.g. for an import of a tree inventory
+	CreateBatch(ctx context.Context, trees []*entities.Tree) ([]int32, error)
+	UpdateBatch(ctx context.Context, trees []*entities.Tree) error
 }
 
 type SensorRepository interface {
 	BasicCrudRepository[entities.Sensor]
+	ListRepository[entities.Sensor, entities.Query]
 	GetStatusByID(ctx context.Context, id int32) (*entities.SensorStatus, error)
+	GetByDeviceID(ctx context.Context, deviceID string) (*entities.Sensor, error)
+	GetByDevEUI(ctx context.Context, devEUI string) (*entities.Sensor, error)
 	GetSensorByStatus(ctx context.Context, status *entities.SensorStatus) ([]*entities.Sensor, error)
 	GetSensorDataByID(ctx context.Context, id int32) ([]*entities.SensorData, error)
-	InsertSensorData(ctx context.Context, data []*entities.SensorData) ([]*entities.SensorData, error)
+	GetLatestSensorDataByID(ctx context.Context, id int32) (*entities.SensorData, error)
+	InsertSensorData(ctx context.Context, sensorID int32, data []*entities.SensorData) ([]*entities.SensorData, error)
 }
 
 type FlowerbedRepository interface {
 	BasicCrudRepository[entities.Flowerbed]
+	ListRepository[entities.Flowerbed, entities.Query]
 	GetSensorByFlowerbedID(ctx context.Context, id int32) (*entities.Sensor, error)
 	GetAllImagesByID(ctx context.Context, id int32) ([]*entities.Image, error)
 
@@ -109,7 +209,11 @@ type FlowerbedRepository interface {
 	DeleteAndUnlinkImages(ctx context.Context, id int32) error
 	UnlinkAllImages(ctx context.Context, id int32) error
 	UnlinkImage(ctx context.Context, flowerbedID, imageID int32) error
+	// UpdateImageLocationMismatch flags the link between the flowerbed and the image
+	UpdateImageLocationMismatch(ctx context.Context, id, imageID int32, mismatch bool) error
 	Archive(ctx context.Context, id int32) error
+	// GetByRegionOrArea returns the flowerbeds linked to the region or located inside the area
+	GetByRegionOrArea(ctx context.Context, regionID int32, area entities.MultiPolygon) ([]*entities.Flowerbed, error)
 }
 
 type AuthRepository interface {
@@ -118,15 +222,20 @@ type AuthRepository interface {
 }
 
 type Repository struct {
-	Auth        AuthRepository
-	Info        InfoRepository
-	Sensor      SensorRepository
-	Tree        TreeRepository
-	User        UserRepository
-	Role        RoleRepository
-	Image       ImageRepository
-	Vehicle     VehicleRepository
-	TreeCluster TreeClusterRepository
-	Flowerbed   FlowerbedRepository
-	Region      RegionRepository
+	Auth          AuthRepository
+	Info          InfoRepository
+	Sensor        SensorRepository
+	Tree          TreeRepository
+	User          UserRepository
+	Role          RoleRepository
+	Image         ImageRepository
+	Blob          BlobRepository
+	Vehicle       VehicleRepository
+	TreeCluster   TreeClusterRepository
+	Flowerbed     FlowerbedRepository
+	Region        RegionRepository
+	WateringPlan  WateringPlanRepository
+	WateringEvent WateringEventRepository
+	Tile          TileRepository
+	UnitOfWork    UnitOfWork
 }
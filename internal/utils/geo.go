@@ -0,0 +1,17 @@
+package utils
+
+import "math"
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371000.0
+
+// HaversineDistance returns the great-circle distance in meters between two points given in degrees
+func HaversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) + math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadius * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
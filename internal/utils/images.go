@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
+)
+
+// ImageLinkUpdater stores for the link between an object and an image whether the image was taken far away from the object
+type ImageLinkUpdater interface {
+	UpdateImageLocationMismatch(ctx context.Context, id, imageID int32, mismatch bool) error
+}
+
+// FlagMisplacedImages marks the links to the images whose GPS position is too far away from the tree or
+// flowerbed with the given id to show it, e.g. because they were attached to the wrong object
+func FlagMisplacedImages(ctx context.Context, repo ImageLinkUpdater, id int32, images []*entities.Image, lat, long float64) error {
+	for _, img := range images {
+		if img.Latitude == nil || img.Longitude == nil {
+			continue
+		}
+
+		mismatch := HaversineDistance(*img.Latitude, *img.Longitude, lat, long) > entities.MaxImageDistance
+		if mismatch == img.LocationMismatch {
+			continue
+		}
+
+		if err := repo.UpdateImageLocationMismatch(ctx, id, img.ID, mismatch); err != nil {
+			return err
+		}
+		img.LocationMismatch = mismatch
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/green-ecolution/green-ecolution-backend/internal/entities"
 	sqlc "github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres/_sqlc"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -26,10 +27,58 @@ func TimeToPgTimestamp(t *time.Time) pgtype.Timestamp {
 	}
 
 	return pgtype.Timestamp{
-		Time: *t,
+		Time:  *t,
+		Valid: true,
 	}
 }
 
+func PgDateToTime(d pgtype.Date) time.Time {
+	return d.Time
+}
+
+func TimeToPgDate(t time.Time) pgtype.Date {
+	if t.IsZero() {
+		return pgtype.Date{}
+	}
+
+	return pgtype.Date{
+		Time:  t,
+		Valid: true,
+	}
+}
+
+func UUIDToPgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{
+		Bytes: id,
+		Valid: true,
+	}
+}
+
+func PgUUIDToUUID(id pgtype.UUID) uuid.UUID {
+	if !id.Valid {
+		return uuid.Nil
+	}
+
+	return id.Bytes
+}
+
+func PgUUIDToUUIDPtr(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+
+	u := uuid.UUID(id.Bytes)
+	return &u
+}
+
+func UUIDPtrToPgUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+
+	return UUIDToPgUUID(*id)
+}
+
 //nolint:gocritic
 func ConvertNullableImage(img sqlc.Image) *entities.Image {
 	if img.ID == 0 {
@@ -28,10 +28,8 @@ import (
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/auth"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/local"
 	"github.com/green-ecolution/green-ecolution-backend/internal/storage/postgres"
-	"github.com/jackc/pgx/v5"
+	"github.com/green-ecolution/green-ecolution-backend/internal/storage/s3"
 	"github.com/spf13/viper"
-	"github.com/twpayne/go-geos"
-	pgxgeos "github.com/twpayne/pgx-geos"
 )
 
 var version = "develop"
@@ -65,6 +63,17 @@ func main() {
 	logg := logger.CreateLogger(os.Stdout, cfg.Server.Logs.Format, cfg.Server.Logs.Level)
 	slog.SetDefault(logg)
 
+	if len(os.Args) > 1 && os.Args[1] == "import-trees" {
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		err := runImportTrees(ctx, cfg, os.Args[2:])
+		cancel()
+		if err != nil {
+			slog.Error("Error while importing trees", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	setSwaggerInfo(cfg.Server.AppURL)
 
 	slog.Info("Starting Green Space Management API")
@@ -72,18 +81,14 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	conn, err := pgx.Connect(ctx, fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", cfg.Server.Database.Host, cfg.Server.Database.Port, cfg.Server.Database.Username, cfg.Server.Database.Password, cfg.Server.Database.Name))
+	pool, err := postgres.NewPool(ctx, &cfg.Server.Database)
 	if err != nil {
 		slog.Error("Error while connecting to PostgreSQL", "error", err)
 		return
 	}
-	defer conn.Close(context.Background())
+	defer pool.Close()
 
-	if err := pgxgeos.Register(ctx, conn, geos.NewContext()); err != nil {
-		slog.Error("Error while registering GEOS", "error", err)
-		return
-	}
-	postgresRepo := postgres.NewRepository(conn)
+	postgresRepo := postgres.NewRepository(pool, &cfg.TreeCluster)
 
 	localRepo, err := local.NewRepository(cfg)
 	if err != nil {
@@ -97,19 +102,40 @@ func main() {
 		return
 	}
 
+	blobRepo := localRepo.Blob
+	if cfg.Storage.Type == "s3" {
+		s3Repo, err := s3.NewRepository(&cfg.Storage.S3)
+		if err != nil {
+			slog.Error("Error while creating S3 repository", "error", err)
+			return
+		}
+		blobRepo = s3Repo.Blob
+	}
+
+	if blobRepo == nil {
+		slog.Error("Unknown object storage type", "type", cfg.Storage.Type)
+		return
+	}
+
 	keycloakRepo := auth.NewRepository(&cfg.IdentityAuth)
 	repositories := &storage.Repository{
 		Auth: keycloakRepo.Auth,
 		User: keycloakRepo.User,
-
-		Info:        localRepo.Info,
-		Sensor:      postgresRepo.Sensor,
-		Tree:        postgresRepo.Tree,
-		TreeCluster: postgresRepo.TreeCluster,
-		Vehicle:     postgresRepo.Vehicle,
-		Flowerbed:   postgresRepo.Flowerbed,
-		Image:       postgresRepo.Image,
-		Region:      postgresRepo.Region,
+		Role: keycloakRepo.Role,
+
+		Info:          localRepo.Info,
+		Sensor:        postgresRepo.Sensor,
+		Tree:          postgresRepo.Tree,
+		TreeCluster:   postgresRepo.TreeCluster,
+		Vehicle:       postgresRepo.Vehicle,
+		Flowerbed:     postgresRepo.Flowerbed,
+		Image:         postgresRepo.Image,
+		Blob:          blobRepo,
+		Region:        postgresRepo.Region,
+		WateringPlan:  postgresRepo.WateringPlan,
+		WateringEvent: postgresRepo.WateringEvent,
+		Tile:          postgresRepo.Tile,
+		UnitOfWork:    postgresRepo.UnitOfWork,
 	}
 
 	services := domain.NewService(cfg, repositories)